@@ -1,6 +1,7 @@
 package hyperview
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/hypergopher/hyperview/response"
@@ -27,3 +28,17 @@ type Adapter interface {
 	// RenderUnauthorized renders the unauthorized page.
 	RenderUnauthorized(w http.ResponseWriter, r *http.Request, opts *response.Response)
 }
+
+// WriterRenderer is an optional interface for adapters that can render a response to an arbitrary io.Writer,
+// rather than an http.ResponseWriter. Headers, cookies, and the status code of the response are not written.
+type WriterRenderer interface {
+	// RenderToWriter renders the response to the given writer.
+	RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error
+}
+
+// StatusRenderer is an optional interface for adapters that can render the system page of any status code, such as
+// 400 Bad Request or 429 Too Many Requests. Adapters without it respond to these status codes with a plain text error.
+type StatusRenderer interface {
+	// RenderStatus renders the system page of the status code of the response.
+	RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response)
+}
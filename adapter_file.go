@@ -0,0 +1,164 @@
+package hyperview
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// FileAdapter is an adapter that streams the body or file of a response (see Response.Body and Response.File) to the
+// client, so that downloads flow through the same Response pipeline as views, with its headers, cookies, and
+// Content-Disposition (see Response.Download).
+//
+// Seekable bodies and files are served with http.ServeContent, which handles Range and conditional requests and sets
+// Content-Length. Other bodies are copied as they are read, with a Content-Length if their length is known.
+type FileAdapter struct {
+	logger *slog.Logger
+}
+
+// FileAdapterOptions are the options for the FileAdapter.
+type FileAdapterOptions struct {
+	// Logger is the logger to use for the adapter.
+	Logger *slog.Logger
+}
+
+// NewFileAdapter creates a new FileAdapter.
+func NewFileAdapter(opts FileAdapterOptions) *FileAdapter {
+	return &FileAdapter{logger: opts.Logger}
+}
+
+func (a *FileAdapter) Init() error {
+	return nil
+}
+
+func (a *FileAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	body, name, modTime, err := a.open(resp)
+	if errors.Is(err, fs.ErrNotExist) {
+		a.RenderNotFound(w, r, resp)
+		return
+	}
+	if err != nil {
+		a.RenderSystemError(w, r, err, resp)
+		return
+	}
+	if closer, ok := body.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// Add any additional headers
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+
+	// Range and conditional requests only apply to successful responses
+	if seeker, ok := body.(io.ReadSeeker); ok && resp.StatusCode() == http.StatusOK {
+		http.ServeContent(w, r, name, modTime, seeker)
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	if sized, ok := body.(interface{ Len() int }); ok {
+		w.Header().Set("Content-Length", strconv.Itoa(sized.Len()))
+	}
+
+	w.WriteHeader(resp.StatusCode())
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, body); err != nil && a.logger != nil {
+		a.logger.Error("Error streaming response body", slog.String("err", err.Error()))
+	}
+}
+
+// RenderToWriter copies the body or file of the response to the given io.Writer.
+// Headers and the status code of the response are ignored.
+func (a *FileAdapter) RenderToWriter(wr io.Writer, _ *http.Request, resp *response.Response) error {
+	body, _, _, err := a.open(resp)
+	if err != nil {
+		return err
+	}
+	if closer, ok := body.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	_, err = io.Copy(wr, body)
+	return err
+}
+
+// open returns the body of the response, or opens its file, with the name used to detect the content type and the
+// modification time for conditional requests.
+func (a *FileAdapter) open(resp *response.Response) (io.Reader, string, time.Time, error) {
+	if body := resp.BodyReader(); body != nil {
+		if file, ok := body.(*os.File); ok {
+			if info, err := file.Stat(); err == nil {
+				return body, info.Name(), info.ModTime(), nil
+			}
+		}
+		return body, "", time.Time{}, nil
+	}
+
+	if resp.FilePath() == "" {
+		return nil, "", time.Time{}, fmt.Errorf("response has no body or file to stream")
+	}
+
+	file, err := os.Open(resp.FilePath())
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, "", time.Time{}, err
+	}
+	if info.IsDir() {
+		_ = file.Close()
+		return nil, "", time.Time{}, fmt.Errorf("%s is a directory: %w", resp.FilePath(), fs.ErrNotExist)
+	}
+
+	return file, filepath.Base(resp.FilePath()), info.ModTime(), nil
+}
+
+func (a *FileAdapter) RenderForbidden(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+func (a *FileAdapter) RenderMaintenance(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Maintenance", http.StatusServiceUnavailable)
+}
+
+func (a *FileAdapter) RenderMethodNotAllowed(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+}
+
+func (a *FileAdapter) RenderNotFound(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Not Found", http.StatusNotFound)
+}
+
+func (a *FileAdapter) RenderStatus(w http.ResponseWriter, _ *http.Request, resp *response.Response) {
+	http.Error(w, http.StatusText(resp.StatusCode()), resp.StatusCode())
+}
+
+func (a *FileAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, _ *response.Response) {
+	reportRenderError(r, err)
+	if a.logger != nil {
+		a.logger.Error("Server error", slog.String("err", err.Error()))
+	}
+	// The error may contain paths of the server, so it is only logged
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func (a *FileAdapter) RenderUnauthorized(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+}
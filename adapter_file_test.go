@@ -0,0 +1,164 @@
+package hyperview_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestFileAdapter_Render(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(path, []byte("0123456789"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		header     map[string]string
+		resp       func() *response.Response
+		wantStatus int
+		wantBody   string
+		wantHeader map[string]string
+	}{
+		{
+			name: "seekable body",
+			resp: func() *response.Response {
+				return response.NewResponse().Body(strings.NewReader("hello world"), "text/plain; charset=utf-8")
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "hello world",
+			wantHeader: map[string]string{"Content-Type": "text/plain; charset=utf-8", "Content-Length": "11", "Accept-Ranges": "bytes"},
+		},
+		{
+			name:   "range",
+			header: map[string]string{"Range": "bytes=6-"},
+			resp: func() *response.Response {
+				return response.NewResponse().Body(strings.NewReader("hello world"), "text/plain; charset=utf-8")
+			},
+			wantStatus: http.StatusPartialContent,
+			wantBody:   "world",
+			wantHeader: map[string]string{"Content-Range": "bytes 6-10/11", "Content-Length": "5"},
+		},
+		{
+			name: "stream with length",
+			resp: func() *response.Response {
+				return response.NewResponse().Body(bytes.NewBufferString("streamed"), "")
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "streamed",
+			wantHeader: map[string]string{"Content-Type": "application/octet-stream", "Content-Length": "8"},
+		},
+		{
+			name: "stream without length",
+			resp: func() *response.Response {
+				return response.NewResponse().Body(io.MultiReader(strings.NewReader("a"), strings.NewReader("b")), "text/csv").Status(http.StatusCreated)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   "ab",
+			wantHeader: map[string]string{"Content-Type": "text/csv", "Content-Length": ""},
+		},
+		{
+			name: "file download",
+			resp: func() *response.Response {
+				return response.NewResponse().File(path).Download("Q1 report.pdf")
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "0123456789",
+			wantHeader: map[string]string{
+				"Content-Type":        "application/pdf",
+				"Content-Disposition": `attachment; filename="Q1 report.pdf"`,
+				"Last-Modified":       "Tue, 02 Jan 2024 03:04:05 GMT",
+			},
+		},
+		{
+			name:   "file range",
+			header: map[string]string{"Range": "bytes=0-3"},
+			resp: func() *response.Response {
+				return response.NewResponse().File(path)
+			},
+			wantStatus: http.StatusPartialContent,
+			wantBody:   "0123",
+			wantHeader: map[string]string{"Content-Type": "application/pdf"},
+		},
+		{
+			name:   "file not modified",
+			header: map[string]string{"If-Modified-Since": "Tue, 02 Jan 2024 03:04:05 GMT"},
+			resp: func() *response.Response {
+				return response.NewResponse().File(path)
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:   "head",
+			method: "HEAD",
+			resp: func() *response.Response {
+				return response.NewResponse().Body(bytes.NewBufferString("streamed"), "")
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{"Content-Length": "8"},
+		},
+		{
+			name: "missing file",
+			resp: func() *response.Response {
+				return response.NewResponse().File(filepath.Join(dir, "missing.pdf"))
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Not Found\n",
+		},
+		{
+			name: "file error",
+			resp: func() *response.Response {
+				return response.NewResponse().File(filepath.Join(path, "nested.pdf"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "/", nil)
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, tt.resp())
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			for key, want := range tt.wantHeader {
+				if got := w.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,17 +1,121 @@
 package hyperview
 
 import (
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/hypergopher/hyperview/response"
 )
 
 // JSONAdapter is an adapter for rendering JSON responses.
-type JSONAdapter struct{}
+type JSONAdapter struct {
+	compact        bool              // render the output without whitespace by default
+	encoder        JSONEncoder       // encodes the output, if set
+	etags          bool              // set ETags and respond with 304 Not Modified when they match
+	formatter      EnvelopeFormatter // formats the envelopes, if set
+	jsonp          bool              // wrap the output in the callback of the request, if any
+	prettyQuery    bool              // let the pretty query parameter toggle the indentation
+	problemDetails bool              // render failures and system errors as RFC 9457 problem details
+	streaming      bool              // stream the output of responses
+	threshold      int               // maximum size of streamed output that is buffered
+}
+
+// EnvelopeFormatter formats the envelopes of the JSON adapter before they are serialized.
+type EnvelopeFormatter interface {
+	// FormatEnvelope returns the value to serialize for the envelope of the request. It can return the envelope
+	// data to drop the wrapper, or a custom type to rename fields or add members such as a request ID.
+	FormatEnvelope(r *http.Request, envelope Envelope) any
+}
+
+// EnvelopeFormatterFunc is a function that implements EnvelopeFormatter.
+type EnvelopeFormatterFunc func(r *http.Request, envelope Envelope) any
+
+// FormatEnvelope calls f(r, envelope).
+func (f EnvelopeFormatterFunc) FormatEnvelope(r *http.Request, envelope Envelope) any {
+	return f(r, envelope)
+}
+
+// JSONAdapterOption is a function that configures the JSONAdapter.
+type JSONAdapterOption func(*JSONAdapter)
+
+// WithCompactJSON renders the output without whitespace, instead of indenting it with tabs. Responses can override
+// the format with Response.PrettyJSON and Response.CompactJSON.
+func WithCompactJSON() JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.compact = true
+	}
+}
+
+// WithPrettyQuery lets clients toggle the indentation with the "pretty" query parameter (e.g. ?pretty=1 or
+// ?pretty=false), unless the response sets the format.
+func WithPrettyQuery() JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.prettyQuery = true
+	}
+}
+
+// WithJSONETags sets an ETag computed from the output on successful responses, and responds with 304 Not Modified when the
+// If-None-Match header of the request matches. ETags are not computed for streamed responses (see WithStreaming).
+func WithJSONETags() JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.etags = true
+	}
+}
+
+// WithJSONEncoder encodes the output of the adapter with the encoder, instead of the encoder set with SetJSONEncoder.
+func WithJSONEncoder(enc JSONEncoder) JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.encoder = enc
+	}
+}
+
+// WithStreaming streams the output of responses to the client as it is encoded, so that large payloads, such as
+// exports, are not buffered in memory. Output up to the threshold (in bytes) is still buffered and written with a
+// Content-Length, larger output is streamed without one. A threshold of 0 streams all output. System pages and JSONP
+// responses are not streamed. See JSONStream for how encoding errors are handled.
+func WithStreaming(threshold int) JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.streaming = true
+		v.threshold = threshold
+	}
+}
+
+// WithEnvelopeFormatter formats the success, failure, and error envelopes with the formatter. Problem details are
+// not formatted.
+func WithEnvelopeFormatter(formatter EnvelopeFormatter) JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.formatter = formatter
+	}
+}
+
+// WithJSONP wraps the output in a JavaScript callback when the request has a "callback" query parameter, for
+// legacy clients that load the response with a script tag. The output is served as text/javascript with the status
+// code of the response. Requests with a callback name that is not a valid (optionally dotted) JavaScript identifier
+// are rejected with 400 Bad Request.
+func WithJSONP() JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.jsonp = true
+	}
+}
+
+// WithProblemDetails renders failures (responses with a status above 299) and system pages as RFC 9457
+// (formerly RFC 7807) problem details with the application/problem+json content type, instead of the failure and
+// error envelopes. The error message of the view data is used as the detail, the field errors are added as the
+// "errors" extension member, and the request path is used as the instance.
+func WithProblemDetails() JSONAdapterOption {
+	return func(v *JSONAdapter) {
+		v.problemDetails = true
+	}
+}
 
 // NewJSONViewAdapter creates a new JSON view adapter.
-func NewJSONViewAdapter() *JSONAdapter {
-	return &JSONAdapter{}
+func NewJSONViewAdapter(opts ...JSONAdapterOption) *JSONAdapter {
+	v := &JSONAdapter{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 func (v *JSONAdapter) Init() error {
@@ -23,58 +127,222 @@ func (v *JSONAdapter) Render(w http.ResponseWriter, r *http.Request, resp *respo
 		resp.Status(http.StatusOK)
 	}
 
-	if resp.StatusCode() > 299 {
-		err := JSONFailure(w, resp.ViewData(r).Data(), "Failure", resp.StatusCode(), resp.HTTPHeader())
+	contentType, data := v.body(r, resp)
+	setCookies(w, resp)
+
+	if v.streaming && !v.hasJSONPCallback(r) {
+		err := streamJSON(w, v.jsonEncoder(), v.indent(r, resp.JSONFormat()), v.threshold, resp.StatusCode(), contentType, data, resp.HTTPHeader())
 		if err != nil {
 			v.RenderSystemError(w, r, err, resp)
 		}
 		return
 	}
 
-	err := JSONSuccessWithStatus(w, resp.StatusCode(), resp.ViewData(r).Data(), resp.HTTPHeader())
-	if err != nil {
+	if !v.etags {
+		if err := v.write(w, r, v.indent(r, resp.JSONFormat()), resp.StatusCode(), contentType, data, resp.HTTPHeader()); err != nil {
+			v.RenderSystemError(w, r, err, resp)
+		}
+		return
+	}
+
+	bw := newBufferedWriter(w)
+	if err := v.write(bw, r, v.indent(r, resp.JSONFormat()), resp.StatusCode(), contentType, data, resp.HTTPHeader()); err != nil {
 		v.RenderSystemError(w, r, err, resp)
+		return
 	}
-}
 
-func (v *JSONAdapter) RenderForbidden(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
-	err := JSONFailure(w, nil, "Forbidden", http.StatusForbidden, nil)
-	if err != nil {
+	if err := bw.flush(r, true); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
-func (v *JSONAdapter) RenderMaintenance(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
-	err := JSONFailure(w, nil, "Maintenance", http.StatusServiceUnavailable, nil)
+func (v *JSONAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request, _ *response.Response) {
+	v.renderSystem(w, r, http.StatusForbidden, "fail", "Forbidden")
+}
+
+func (v *JSONAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, _ *response.Response) {
+	v.renderSystem(w, r, http.StatusServiceUnavailable, "fail", "Maintenance")
+}
+
+func (v *JSONAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, _ *response.Response) {
+	v.renderSystem(w, r, http.StatusMethodNotAllowed, "fail", "Method not allowed")
+}
+
+func (v *JSONAdapter) RenderNotFound(w http.ResponseWriter, r *http.Request, _ *response.Response) {
+	v.renderSystem(w, r, http.StatusNotFound, "fail", "Not found")
+}
+
+// RenderStatus renders a failure envelope (an error envelope for a 5xx status code) with the status code of the
+// response, or problem details. The message is the error message of the response or the status text, and field
+// errors of the response are included as "errors".
+func (v *JSONAdapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	status := resp.StatusCode()
+	data := resp.ViewData(r)
+
+	message := http.StatusText(status)
+	if data.HasError() {
+		message = data.Error()
+	}
+
+	var err error
+	if v.problemDetails {
+		problem := NewProblemDetails(status, message).withInstance(r)
+		if data.HasErrors() {
+			problem.With("errors", data.Errors())
+		}
+		err = v.write(w, r, v.indent(r, response.JSONFormatDefault), status, problemContentType, problem)
+	} else {
+		envelope := Envelope{Status: "fail", Code: status, Message: message}
+		if status >= http.StatusInternalServerError {
+			envelope.Status = "error"
+		}
+		if data.HasErrors() {
+			envelope.Data = map[string]any{"errors": data.Errors()}
+		}
+		err = v.write(w, r, v.indent(r, response.JSONFormatDefault), status, jsonContentType, v.format(r, envelope))
+	}
+
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
-func (v *JSONAdapter) RenderMethodNotAllowed(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
-	err := JSONFailure(w, nil, "Method not allowed", http.StatusMethodNotAllowed, nil)
+func (v *JSONAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, _ *response.Response) {
+	reportRenderError(r, err)
+	v.renderSystem(w, r, http.StatusInternalServerError, "error", err.Error())
+}
+
+func (v *JSONAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, _ *response.Response) {
+	v.renderSystem(w, r, http.StatusUnauthorized, "fail", "Unauthorized")
+}
+
+// RenderToWriter renders the response data as a (formatted) JSON envelope to the given io.Writer.
+// Headers and the status code of the response are not written.
+func (v *JSONAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	_, data := v.body(r, resp)
+
+	js, err := marshalJSON(v.jsonEncoder(), v.indent(r, resp.JSONFormat()), data)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
 	}
+
+	_, err = wr.Write(append(js, '\n'))
+	return err
 }
 
-func (v *JSONAdapter) RenderNotFound(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
-	err := JSONFailure(w, nil, "Not found", http.StatusNotFound, nil)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+// body returns the content type and the data to serialize for the response: the raw JSON value, a success envelope,
+// or a failure envelope or problem details for a status above 299.
+func (v *JSONAdapter) body(r *http.Request, resp *response.Response) (string, any) {
+	if raw := resp.RawJSONValue(); raw != nil {
+		return jsonContentType, raw
 	}
+
+	if resp.StatusCode() > 299 {
+		if v.problemDetails {
+			return problemContentType, v.problem(r, resp.StatusCode(), resp.ViewData(r))
+		}
+
+		return jsonContentType, v.format(r, Envelope{
+			Status:  "fail",
+			Code:    resp.StatusCode(),
+			Message: "Failure",
+			Data:    resp.ViewData(r).Data(),
+		})
+	}
+
+	return jsonContentType, v.format(r, Envelope{
+		Status:  "success",
+		Code:    resp.StatusCode(),
+		Message: "Success",
+		Data:    resp.ViewData(r).Data(),
+	})
 }
 
-func (v *JSONAdapter) RenderSystemError(w http.ResponseWriter, _ *http.Request, err error, _ *response.Response) {
-	e := JSONError(w, err.Error(), http.StatusInternalServerError, nil)
-	if e != nil {
-		http.Error(w, e.Error(), http.StatusInternalServerError)
+// format formats the envelope with the envelope formatter, if any.
+func (v *JSONAdapter) format(r *http.Request, envelope Envelope) any {
+	if v.formatter == nil {
+		return envelope
 	}
+	return v.formatter.FormatEnvelope(r, envelope)
 }
 
-func (v *JSONAdapter) RenderUnauthorized(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
-	err := JSONFailure(w, nil, "Unauthorized", http.StatusUnauthorized, nil)
+// renderSystem renders a system page as an envelope with the status and message, or as problem details,
+// falling back to a plain text error if it cannot be written.
+func (v *JSONAdapter) renderSystem(w http.ResponseWriter, r *http.Request, status int, envelopeStatus, message string) {
+	var err error
+	if v.problemDetails {
+		err = v.write(w, r, v.indent(r, response.JSONFormatDefault), status, problemContentType, NewProblemDetails(status, message).withInstance(r))
+	} else {
+		err = v.write(w, r, v.indent(r, response.JSONFormatDefault), status, jsonContentType, v.format(r, Envelope{Status: envelopeStatus, Code: status, Message: message}))
+	}
+
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// write writes the data as JSON with the indent (compact if empty), or as JSONP if enabled and the request has a
+// callback.
+func (v *JSONAdapter) write(w http.ResponseWriter, r *http.Request, indent string, status int, contentType string, data any, headers ...http.Header) error {
+	if v.hasJSONPCallback(r) {
+		callback := r.URL.Query().Get(jsonpCallbackParam)
+		if !validJSONPCallback(callback) {
+			return writeJSON(w, v.jsonEncoder(), indent, http.StatusBadRequest, jsonContentType, v.format(r, Envelope{
+				Status:  "fail",
+				Code:    http.StatusBadRequest,
+				Message: "Invalid callback",
+			}))
+		}
+
+		return writeJSONP(w, v.jsonEncoder(), status, callback, data, headers...)
+	}
+
+	return writeJSON(w, v.jsonEncoder(), indent, status, contentType, data, headers...)
+}
+
+// indent returns the indent of the output (compact if empty), determined by the JSON format of the response, the
+// pretty query parameter (if enabled), or the adapter default, in that order.
+func (v *JSONAdapter) indent(r *http.Request, format response.JSONFormat) string {
+	switch format {
+	case response.JSONFormatPretty:
+		return jsonIndent
+	case response.JSONFormatCompact:
+		return ""
+	}
+
+	if v.prettyQuery {
+		if pretty, err := strconv.ParseBool(r.URL.Query().Get(prettyQueryParam)); err == nil {
+			if pretty {
+				return jsonIndent
+			}
+			return ""
+		}
+	}
+
+	if v.compact {
+		return ""
+	}
+	return jsonIndent
+}
+
+// hasJSONPCallback returns true if JSONP is enabled and the request has a callback.
+func (v *JSONAdapter) hasJSONPCallback(r *http.Request) bool {
+	return v.jsonp && r.URL.Query().Get(jsonpCallbackParam) != ""
+}
+
+// jsonEncoder returns the encoder of the adapter, or the default encoder.
+func (v *JSONAdapter) jsonEncoder() JSONEncoder {
+	if v.encoder == nil {
+		return currentJSONEncoder()
+	}
+	return v.encoder
+}
+
+// problem returns the problem details for a failed response with the error message and field errors of the view data.
+func (v *JSONAdapter) problem(r *http.Request, status int, data *response.Data) *ProblemDetails {
+	problem := NewProblemDetails(status, data.Error()).withInstance(r)
+	if data.HasErrors() {
+		problem.With("errors", data.Errors())
+	}
+	return problem
+}
@@ -0,0 +1,310 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestJSONAdapter_JSONP(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(hyperview.WithJSONP())))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		target          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "callback",
+			target:          "/widget?callback=loadWidget",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/javascript; charset=UTF-8",
+			wantBody:        "/**/ typeof loadWidget === 'function' && loadWidget({\"status\":\"success\",\"message\":\"Success\",\"data\":{\"Error\":\"\",\"Errors\":{},\"Name\":\"gopher\",\"View\":{}},\"code\":200});\n",
+		},
+		{
+			name:            "dotted callback",
+			target:          "/widget?callback=jQuery.cb_1",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/javascript; charset=UTF-8",
+			wantBody:        "/**/ typeof jQuery.cb_1 === 'function' && jQuery.cb_1({\"status\":\"success\",\"message\":\"Success\",\"data\":{\"Error\":\"\",\"Errors\":{},\"Name\":\"gopher\",\"View\":{}},\"code\":200});\n",
+		},
+		{
+			name:            "no callback",
+			target:          "/widget",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json; charset=UTF-8",
+			wantBody:        "{\n\t\"status\": \"success\",\n\t\"message\": \"Success\",\n\t\"data\": {\n\t\t\"Error\": \"\",\n\t\t\"Errors\": {},\n\t\t\"Name\": \"gopher\",\n\t\t\"View\": {}\n\t},\n\t\"code\": 200\n}\n",
+		},
+		{
+			name:            "invalid callback",
+			target:          "/widget?callback=alert(1)//",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json; charset=UTF-8",
+			wantBody:        "{\n\t\"status\": \"fail\",\n\t\"message\": \"Invalid callback\",\n\t\"data\": null,\n\t\"code\": 400\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", tt.target, nil), response.NewResponse().Path("widget.json").Data(map[string]any{"Name": "gopher"}))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestJSONAdapter_EnvelopeFormatter(t *testing.T) {
+	formatter := hyperview.EnvelopeFormatterFunc(func(r *http.Request, envelope hyperview.Envelope) any {
+		if r.URL.Path == "/raw" {
+			return envelope.Data
+		}
+		return map[string]any{
+			"ok":         envelope.Status == "success",
+			"result":     envelope.Data,
+			"request_id": r.Header.Get("X-Request-ID"),
+		}
+	})
+
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(hyperview.WithEnvelopeFormatter(formatter))))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		render   func(w http.ResponseWriter, r *http.Request)
+		wantBody string
+	}{
+		{
+			name:   "custom fields",
+			target: "/users",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Path("users.json").Data(map[string]any{"Name": "gopher"}))
+			},
+			wantBody: "{\n\t\"ok\": true,\n\t\"request_id\": \"abc\",\n\t\"result\": {\n\t\t\"Error\": \"\",\n\t\t\"Errors\": {},\n\t\t\"Name\": \"gopher\",\n\t\t\"View\": {}\n\t}\n}\n",
+		},
+		{
+			name:   "no wrapper",
+			target: "/raw",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Path("users.json").Data(map[string]any{"Name": "gopher"}))
+			},
+			wantBody: "{\n\t\"Error\": \"\",\n\t\"Errors\": {},\n\t\"Name\": \"gopher\",\n\t\"View\": {}\n}\n",
+		},
+		{
+			name:   "system page",
+			target: "/users",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.RenderNotFoundAs(w, r, "json")
+			},
+			wantBody: "{\n\t\"ok\": false,\n\t\"request_id\": \"abc\",\n\t\"result\": null\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			r.Header.Set("X-Request-ID", "abc")
+			w := httptest.NewRecorder()
+			tt.render(w, r)
+
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestJSONAdapter_RawJSON(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(hyperview.WithProblemDetails())))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		resp       *response.Response
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "object",
+			resp:       response.NewResponse().RawJSON(map[string]any{"id": 1, "name": "gopher"}),
+			wantStatus: http.StatusOK,
+			wantBody:   "{\n\t\"id\": 1,\n\t\"name\": \"gopher\"\n}\n",
+		},
+		{
+			name:       "array",
+			resp:       response.NewResponse().RawJSON([]string{"a", "b"}).StatusCreated(),
+			wantStatus: http.StatusCreated,
+			wantBody:   "[\n\t\"a\",\n\t\"b\"\n]\n",
+		},
+		{
+			name:       "failure status",
+			resp:       response.NewResponse().RawJSON(map[string]string{"error": "conflict"}).Status(http.StatusConflict),
+			wantStatus: http.StatusConflict,
+			wantBody:   "{\n\t\"error\": \"conflict\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/users", nil), tt.resp)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json; charset=UTF-8" {
+				t.Errorf("Content-Type = %q, want application/json; charset=UTF-8", got)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+// markerEncoder is a JSONEncoder that marks its output, to verify which encoder is used.
+type markerEncoder struct{}
+
+func (markerEncoder) Marshal(v any) ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+func (markerEncoder) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	return []byte(`"custom indented"`), nil
+}
+
+func TestJSONAdapter_Encoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		adapter  *hyperview.JSONAdapter
+		wantBody string
+	}{
+		{
+			name:     "default encoder",
+			adapter:  hyperview.NewJSONViewAdapter(),
+			wantBody: "{\n\t\"html\": \"\\u003cb\\u003e\"\n}\n",
+		},
+		{
+			name:     "no html escaping",
+			adapter:  hyperview.NewJSONViewAdapter(hyperview.WithJSONEncoder(hyperview.StdJSONEncoder{DisableHTMLEscaping: true})),
+			wantBody: "{\n\t\"html\": \"<b>\"\n}\n",
+		},
+		{
+			name:     "custom encoder",
+			adapter:  hyperview.NewJSONViewAdapter(hyperview.WithJSONEncoder(markerEncoder{})),
+			wantBody: "\"custom indented\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", tt.adapter))
+			if err != nil {
+				t.Fatalf("error creating HyperView: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().RawJSON(map[string]string{"html": "<b>"}))
+
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSetJSONEncoder(t *testing.T) {
+	hyperview.SetJSONEncoder(markerEncoder{})
+	t.Cleanup(func() { hyperview.SetJSONEncoder(nil) })
+
+	w := httptest.NewRecorder()
+	if err := hyperview.JSONWithHeaders(w, http.StatusOK, map[string]string{"name": "gopher"}); err != nil {
+		t.Fatalf("JSONWithHeaders() error = %v", err)
+	}
+
+	if got, want := w.Body.String(), "\"custom indented\"\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestSetJSONEncoder_Concurrent(t *testing.T) {
+	t.Cleanup(func() { hyperview.SetJSONEncoder(nil) })
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			hyperview.SetJSONEncoder(markerEncoder{})
+			hyperview.SetJSONEncoder(nil)
+		}
+	}()
+	for range 100 {
+		if err := hyperview.JSONWithHeaders(httptest.NewRecorder(), http.StatusOK, "gopher"); err != nil {
+			t.Fatalf("JSONWithHeaders() error = %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestJSONAdapter_Format(t *testing.T) {
+	const (
+		pretty  = "{\n\t\"id\": 1\n}\n"
+		compact = "{\"id\":1}\n"
+	)
+
+	tests := []struct {
+		name     string
+		opts     []hyperview.JSONAdapterOption
+		target   string
+		resp     *response.Response
+		wantBody string
+	}{
+		{name: "default", target: "/", resp: response.NewResponse(), wantBody: pretty},
+		{name: "compact adapter", opts: []hyperview.JSONAdapterOption{hyperview.WithCompactJSON()}, target: "/", resp: response.NewResponse(), wantBody: compact},
+		{name: "compact response", target: "/", resp: response.NewResponse().CompactJSON(), wantBody: compact},
+		{name: "pretty response", opts: []hyperview.JSONAdapterOption{hyperview.WithCompactJSON()}, target: "/", resp: response.NewResponse().PrettyJSON(), wantBody: pretty},
+		{name: "pretty query", opts: []hyperview.JSONAdapterOption{hyperview.WithCompactJSON(), hyperview.WithPrettyQuery()}, target: "/?pretty=1", resp: response.NewResponse(), wantBody: pretty},
+		{name: "compact query", opts: []hyperview.JSONAdapterOption{hyperview.WithPrettyQuery()}, target: "/?pretty=false", resp: response.NewResponse(), wantBody: compact},
+		{name: "query disabled", opts: []hyperview.JSONAdapterOption{hyperview.WithCompactJSON()}, target: "/?pretty=1", resp: response.NewResponse(), wantBody: compact},
+		{name: "response over query", opts: []hyperview.JSONAdapterOption{hyperview.WithPrettyQuery()}, target: "/?pretty=1", resp: response.NewResponse().CompactJSON(), wantBody: compact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(tt.opts...)))
+			if err != nil {
+				t.Fatalf("error creating HyperView: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", tt.target, nil), tt.resp.RawJSON(map[string]int{"id": 1}))
+
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package hyperview
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// frontMatterDelimiter separates the YAML front-matter from the Markdown content.
+const frontMatterDelimiter = "---"
+
+// MarkdownAdapter is an adapter for rendering Markdown files from the views directories of the template filesystems.
+// The rendered HTML is wrapped in the layout of the response, using the layouts and partials of a TemplateAdapter.
+// System pages are rendered by the TemplateAdapter.
+//
+// Markdown files may start with a YAML front-matter block. The "title" key sets the page title (unless the handler
+// sets one), and all other keys are added to the view data (unless the handler sets them):
+//
+//	---
+//	title: Getting Started
+//	section: docs
+//	---
+//	# Getting Started
+type MarkdownAdapter struct {
+	extension     string
+	fileSystemMap map[string]fs.FS
+	logger        *slog.Logger
+	markdown      goldmark.Markdown
+	templates     *TemplateAdapter
+	pages         map[string]markdownPage
+	mu            sync.RWMutex // protects the pages
+}
+
+// markdownPage is a rendered Markdown file.
+type markdownPage struct {
+	key         string         // identifies the page and its content for the template cache
+	title       string         // title from the front-matter
+	frontMatter map[string]any // other front-matter values
+	content     template.HTML  // rendered HTML
+}
+
+// MarkdownAdapterOptions are the options for the MarkdownAdapter.
+type MarkdownAdapterOptions struct {
+	// Extension is the file extension for the Markdown files. Default is ".md".
+	Extension string
+	// FileSystemMap is a map of file systems to use for the Markdown files.
+	FileSystemMap map[string]fs.FS
+	// Logger is the logger to use for the adapter.
+	Logger *slog.Logger
+	// Markdown is the Markdown engine. Default is goldmark with GitHub Flavored Markdown enabled. Raw HTML in the
+	// Markdown files is omitted, unless the engine is configured with html.WithUnsafe.
+	Markdown goldmark.Markdown
+	// Templates is the template adapter that provides the layouts, partials, and system pages (required).
+	Templates *TemplateAdapter
+}
+
+// NewMarkdownAdapter creates a new MarkdownAdapter.
+func NewMarkdownAdapter(opts MarkdownAdapterOptions) *MarkdownAdapter {
+	if opts.Extension == "" {
+		opts.Extension = ".md"
+	}
+
+	if opts.Markdown == nil {
+		opts.Markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	}
+
+	return &MarkdownAdapter{
+		extension:     opts.Extension,
+		fileSystemMap: opts.FileSystemMap,
+		logger:        opts.Logger,
+		markdown:      opts.Markdown,
+		templates:     opts.Templates,
+		pages:         make(map[string]markdownPage),
+	}
+}
+
+func (a *MarkdownAdapter) Init() error {
+	if a.templates == nil {
+		return fmt.Errorf("markdown adapter requires a template adapter")
+	}
+
+	pages := make(map[string]markdownPage)
+
+	for fsID, fsys := range a.fileSystemMap {
+		prefix := ""
+		if fsID != constants.RootFSID {
+			prefix = fsID + ":"
+		}
+
+		processDirectory := func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || filepath.Ext(path) != a.extension {
+				return nil
+			}
+
+			source, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+
+			pageName := prefix + strings.TrimSuffix(path, a.extension)
+			page, err := a.parsePage(source)
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", pageName, err)
+			}
+
+			h := fnv.New64a()
+			_, _ = h.Write(source)
+			page.key = fmt.Sprintf("%s@%x", pageName, h.Sum64())
+			pages[pageName] = page
+			return nil
+		}
+
+		// If the "views" directory exists, parse it
+		if _, err := fsys.Open(constants.ViewsDir); err == nil {
+			if err := fs.WalkDir(fsys, constants.ViewsDir, processDirectory); err != nil {
+				return err
+			}
+		}
+	}
+
+	a.mu.Lock()
+	a.pages = pages
+	a.mu.Unlock()
+
+	return nil
+}
+
+// parsePage splits the front-matter from the Markdown source and renders the Markdown to HTML.
+func (a *MarkdownAdapter) parsePage(source []byte) (markdownPage, error) {
+	page := markdownPage{frontMatter: make(map[string]any)}
+
+	source = bytes.TrimPrefix(source, []byte("\ufeff"))
+	// Files with Windows line endings have the same front-matter delimiters
+	source = bytes.ReplaceAll(source, []byte("\r\n"), []byte("\n"))
+	if rest, ok := bytes.CutPrefix(source, []byte(frontMatterDelimiter+"\n")); ok {
+		frontMatter, body, found := bytes.Cut(rest, []byte("\n"+frontMatterDelimiter+"\n"))
+		if !found {
+			frontMatter, found = bytes.CutSuffix(rest, []byte("\n"+frontMatterDelimiter))
+		}
+		if !found {
+			return page, fmt.Errorf("unterminated front-matter")
+		}
+
+		if err := yaml.Unmarshal(frontMatter, &page.frontMatter); err != nil {
+			return page, fmt.Errorf("invalid front-matter: %w", err)
+		}
+		source = body
+	}
+
+	if title, ok := page.frontMatter["title"].(string); ok {
+		page.title = title
+		delete(page.frontMatter, "title")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := a.markdown.Convert(source, buf); err != nil {
+		return page, err
+	}
+	page.content = template.HTML(buf.String())
+
+	return page, nil
+}
+
+// page returns the rendered Markdown page for the given path.
+func (a *MarkdownAdapter) page(path string) (markdownPage, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	page, ok := a.pages[path]
+	return page, ok
+}
+
+func (a *MarkdownAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	buf := new(bytes.Buffer)
+	if err := a.RenderToWriter(buf, r, resp); err != nil {
+		reportRenderError(r, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Add any additional headers
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+
+	w.WriteHeader(resp.StatusCode())
+
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RenderToWriter renders the Markdown page of the response, wrapped in its layout, to the given io.Writer.
+// Headers and the status code of the response are ignored.
+func (a *MarkdownAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	page, ok := a.page(resp.TemplatePath())
+	if !ok {
+		return fmt.Errorf("markdown page not found: %s", resp.TemplatePath())
+	}
+
+	if resp.PageTitle() == "" && page.title != "" {
+		resp.Title(page.title)
+	}
+
+	data := resp.ViewData(r).Data()
+	for key, value := range page.frontMatter {
+		if _, ok := data[key]; !ok {
+			data[key] = value
+		}
+	}
+
+	if err := a.templates.executeContent(wr, r, resp, page.key, page.content); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	return nil
+}
+
+func (a *MarkdownAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderForbidden(w, r, resp)
+}
+
+func (a *MarkdownAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderMaintenance(w, r, resp)
+}
+
+func (a *MarkdownAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderMethodNotAllowed(w, r, resp)
+}
+
+func (a *MarkdownAdapter) RenderNotFound(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderNotFound(w, r, resp)
+}
+
+func (a *MarkdownAdapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderStatus(w, r, resp)
+}
+
+func (a *MarkdownAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, resp *response.Response) {
+	a.templates.RenderSystemError(w, r, err, resp)
+}
+
+func (a *MarkdownAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.templates.RenderUnauthorized(w, r, resp)
+}
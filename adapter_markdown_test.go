@@ -0,0 +1,68 @@
+package hyperview_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestMarkdownAdapter_Render(t *testing.T) {
+	fsys := testTemplateFS()
+	fsys["layouts/base.html"] = &fstest.MapFile{Data: []byte(`{{define "layout:base"}}<title>{{.View.Title}}</title><main>{{template "page:main" .}}</main>{{end}}`)}
+	fsys["views/docs/intro.md"] = &fstest.MapFile{Data: []byte("---\ntitle: Intro\nsection: docs\n---\n# Hello {{.Name}}\n")}
+	fsys["views/changelog.md"] = &fstest.MapFile{Data: []byte("- *one*\n")}
+	fsys["views/docs/windows.md"] = &fstest.MapFile{Data: []byte("---\r\ntitle: Windows\r\n---\r\n# Hello\r\n")}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+
+	tests := []struct {
+		name string
+		resp *response.Response
+		want string
+	}{
+		{
+			name: "front-matter title",
+			resp: response.NewResponse().Path("docs/intro.md"),
+			want: "<title>Intro</title><main><h1>Hello {{.Name}}</h1>\n</main>",
+		},
+		{
+			name: "handler title takes precedence",
+			resp: response.NewResponse().Path("docs/intro.md").Title("Docs"),
+			want: "<title>Docs</title><main><h1>Hello {{.Name}}</h1>\n</main>",
+		},
+		{
+			name: "front-matter with CRLF line endings",
+			resp: response.NewResponse().Path("docs/windows.md"),
+			want: "<title>Windows</title><main><h1>Hello</h1>\n</main>",
+		},
+		{
+			name: "without front-matter",
+			resp: response.NewResponse().Path("changelog.md"),
+			want: "<title></title><main><ul>\n<li><em>one</em></li>\n</ul>\n</main>",
+		},
+		{
+			name: "partial",
+			resp: response.NewResponse().Path("changelog.md").PartialOnly(),
+			want: "<ul>\n<li><em>one</em></li>\n</ul>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hv.RenderToString(r, tt.resp)
+			if err != nil {
+				t.Fatalf("RenderToString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package hyperview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// ErrorPageFunc returns the node to render for a system page with the given status code. The error is only set for
+// system errors.
+type ErrorPageFunc func(r *http.Request, status int, err error, resp *response.Response) response.Node
+
+// NodeAdapter is an adapter for rendering nodes that render themselves, such as gomponents trees, which are set on the
+// response with Response.Node. This allows Go-native HTML builders to be used behind the same HyperView API.
+type NodeAdapter struct {
+	errorPage ErrorPageFunc
+}
+
+// NodeAdapterOptions are the options for the NodeAdapter.
+type NodeAdapterOptions struct {
+	// ErrorPage returns the node to render for system pages (not found, system error, etc.). If nil, or if it returns
+	// nil, a plain text error is rendered.
+	ErrorPage ErrorPageFunc
+}
+
+// NewNodeAdapter creates a new NodeAdapter.
+func NewNodeAdapter(opts NodeAdapterOptions) *NodeAdapter {
+	return &NodeAdapter{
+		errorPage: opts.ErrorPage,
+	}
+}
+
+func (a *NodeAdapter) Init() error {
+	return nil
+}
+
+func (a *NodeAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	buf := new(bytes.Buffer)
+	if err := a.RenderToWriter(buf, r, resp); err != nil {
+		a.RenderSystemError(w, r, err, resp)
+		return
+	}
+
+	setCookies(w, resp)
+	a.write(w, resp.Headers(), resp.StatusCode(), buf)
+}
+
+// RenderToWriter renders the node of the response to the given io.Writer.
+// Headers and the status code of the response are ignored.
+func (a *NodeAdapter) RenderToWriter(wr io.Writer, _ *http.Request, resp *response.Response) error {
+	if resp.TemplateNode() == nil {
+		return fmt.Errorf("response has no node to render")
+	}
+
+	return resp.TemplateNode().Render(wr)
+}
+
+func (a *NodeAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, http.StatusForbidden, nil, resp, "Forbidden")
+}
+
+func (a *NodeAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, http.StatusServiceUnavailable, nil, resp, "Maintenance")
+}
+
+func (a *NodeAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, http.StatusMethodNotAllowed, nil, resp, "Method Not Allowed")
+}
+
+func (a *NodeAdapter) RenderNotFound(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, http.StatusNotFound, nil, resp, "Not Found")
+}
+
+func (a *NodeAdapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, resp.StatusCode(), nil, resp, http.StatusText(resp.StatusCode()))
+}
+
+func (a *NodeAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, resp *response.Response) {
+	reportRenderError(r, err)
+	a.renderErrorPage(w, r, http.StatusInternalServerError, err, resp, err.Error())
+}
+
+func (a *NodeAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderErrorPage(w, r, http.StatusUnauthorized, nil, resp, "Unauthorized")
+}
+
+// renderErrorPage renders the error page node for the status, falling back to a plain text error with the message.
+func (a *NodeAdapter) renderErrorPage(w http.ResponseWriter, r *http.Request, status int, err error, resp *response.Response, message string) {
+	if a.errorPage != nil {
+		if node := a.errorPage(r, status, err, resp); node != nil {
+			buf := new(bytes.Buffer)
+			if renderErr := node.Render(buf); renderErr == nil {
+				a.write(w, nil, status, buf)
+				return
+			}
+		}
+	}
+
+	http.Error(w, message, status)
+}
+
+// write writes the rendered HTML to the response writer with the given headers and status code.
+func (a *NodeAdapter) write(w http.ResponseWriter, headers map[string]string, status int, buf *bytes.Buffer) {
+	for key, value := range headers {
+		w.Header().Set(key, value)
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+
+	w.WriteHeader(status)
+
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,89 @@
+package hyperview_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// nodeFunc is a minimal node, like gomponents.NodeFunc.
+type nodeFunc func(w io.Writer) error
+
+func (fn nodeFunc) Render(w io.Writer) error {
+	return fn(w)
+}
+
+func text(s string) response.Node {
+	return nodeFunc(func(w io.Writer) error {
+		_, err := io.WriteString(w, s)
+		return err
+	})
+}
+
+func TestNodeAdapter_Render(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("node", hyperview.NewNodeAdapter(hyperview.NodeAdapterOptions{
+		ErrorPage: func(_ *http.Request, status int, _ error, _ *response.Response) response.Node {
+			return text(fmt.Sprintf("<h1>Error %d</h1>", status))
+		},
+	})))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	failing := nodeFunc(func(io.Writer) error { return errors.New("boom") })
+
+	tests := []struct {
+		name       string
+		render     func(w http.ResponseWriter, r *http.Request)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "node",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Node(text("<p>Hello</p>")).StatusCreated())
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   "<p>Hello</p>",
+		},
+		{
+			name: "render error",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Node(failing))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "<h1>Error 500</h1>",
+		},
+		{
+			name: "not found",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.RenderNotFoundAs(w, r, "node")
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "<h1>Error 404</h1>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.render(w, httptest.NewRequest("GET", "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/html; charset=utf-8", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package hyperview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/flosch/pongo2/v6"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/funcs"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// Pongo2Adapter is an adapter for rendering pongo2 templates, which use the Django/Jinja template syntax. This eases
+// migrating server-rendered apps from Django or Jinja.
+//
+// Views are the files with the configured extension in the views directories. Layouts are inherited with the
+// pongo2 {% extends %} tag, and partials included with the {% include %} tag. Paths in tags are relative to the root of
+// the template filesystem, e.g. {% extends "layouts/base.p2" %}.
+//
+// The view data is passed as the template context, so data is available as {{ Name }} and the view helpers as
+// {{ View.Title }}. The template functions (see funcs.Base) are available both as functions, e.g.
+// {{ title(Name) }}, and as filters, if they take one or two arguments, e.g. {{ Name|title }}. Filters are global in
+// pongo2, so functions are only registered as filters if no filter with the same name exists.
+type Pongo2Adapter struct {
+	extension     string
+	fileSystemMap map[string]fs.FS
+	funcMap       map[string]any
+	logger        *slog.Logger
+	templates     map[string]*pongo2.Template
+	mu            sync.RWMutex // protects the templates
+}
+
+// Pongo2AdapterOptions are the options for the Pongo2Adapter.
+type Pongo2AdapterOptions struct {
+	// Extension is the file extension for the templates. Default is ".p2".
+	Extension string
+	// FileSystemMap is a map of file systems to use for the templates.
+	FileSystemMap map[string]fs.FS
+	// Funcs is a map of functions to add to the template functions and filters.
+	Funcs map[string]any
+	// Logger is the logger to use for the adapter.
+	Logger *slog.Logger
+}
+
+// NewPongo2Adapter creates a new Pongo2Adapter.
+func NewPongo2Adapter(opts Pongo2AdapterOptions) *Pongo2Adapter {
+	if opts.Extension == "" {
+		opts.Extension = ".p2"
+	}
+
+	return &Pongo2Adapter{
+		extension:     opts.Extension,
+		fileSystemMap: opts.FileSystemMap,
+		funcMap:       funcs.Merge(opts.Funcs),
+		logger:        opts.Logger,
+		templates:     make(map[string]*pongo2.Template),
+	}
+}
+
+func (a *Pongo2Adapter) Init() error {
+	for name, fn := range a.funcMap {
+		if filter, ok := pongo2Filter(fn); ok && !pongo2.FilterExists(name) {
+			if err := pongo2.RegisterFilter(name, filter); err != nil {
+				return fmt.Errorf("error registering filter %s: %w", name, err)
+			}
+		}
+	}
+
+	templates := make(map[string]*pongo2.Template)
+	for fsID, fsys := range a.fileSystemMap {
+		prefix := ""
+		if fsID != constants.RootFSID {
+			prefix = fsID + ":"
+		}
+
+		set := pongo2.NewSet(fsID, &pongo2FSLoader{fsys: fsys})
+		for name, fn := range a.funcMap {
+			set.Globals[name] = fn
+		}
+
+		processDirectory := func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || filepath.Ext(path) != a.extension {
+				return nil
+			}
+
+			tmpl, err := set.FromFile(path)
+			if err != nil {
+				return err
+			}
+			templates[prefix+strings.TrimSuffix(path, a.extension)] = tmpl
+			return nil
+		}
+
+		// If the "views" directory exists, parse it
+		if _, err := fsys.Open(constants.ViewsDir); err == nil {
+			if err := fs.WalkDir(fsys, constants.ViewsDir, processDirectory); err != nil {
+				return err
+			}
+		}
+	}
+
+	a.mu.Lock()
+	a.templates = templates
+	a.mu.Unlock()
+
+	return nil
+}
+
+// template returns the cached template for the given path
+func (a *Pongo2Adapter) template(path string) (*pongo2.Template, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	tmpl, ok := a.templates[path]
+	return tmpl, ok
+}
+
+func (a *Pongo2Adapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	buf := new(bytes.Buffer)
+	if err := a.RenderToWriter(buf, r, resp); err != nil {
+		reportRenderError(r, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Add any additional headers
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+
+	w.WriteHeader(resp.StatusCode())
+
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RenderToWriter renders the response template to the given io.Writer.
+// Headers and the status code of the response are ignored.
+func (a *Pongo2Adapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	tmpl, ok := a.template(resp.TemplatePath())
+	if !ok {
+		return fmt.Errorf("template not found: %s", resp.TemplatePath())
+	}
+
+	if err := tmpl.ExecuteWriter(resp.ViewData(r).Data(), wr); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Pongo2Adapter) RenderForbidden(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderSystemPage(w, r, resp, "403", http.StatusForbidden, "Forbidden")
+}
+
+func (a *Pongo2Adapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderSystemPage(w, r, resp, "503", http.StatusServiceUnavailable, "Maintenance")
+}
+
+func (a *Pongo2Adapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderSystemPage(w, r, resp, "405", http.StatusMethodNotAllowed, "Method Not Allowed")
+}
+
+func (a *Pongo2Adapter) RenderNotFound(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderSystemPage(w, r, resp, "404", http.StatusNotFound, "Not Found")
+}
+
+func (a *Pongo2Adapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	status := resp.StatusCode()
+	a.renderSystemPage(w, r, resp, strconv.Itoa(status), status, http.StatusText(status))
+}
+
+func (a *Pongo2Adapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, resp *response.Response) {
+	reportRenderError(r, err)
+	if a.logger != nil {
+		a.logger.Error("Server error", slog.String("err", err.Error()))
+	}
+	a.renderSystemPage(w, r, resp.Errors(err.Error(), nil), "500", http.StatusInternalServerError, err.Error())
+}
+
+func (a *Pongo2Adapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.renderSystemPage(w, r, resp, "401", http.StatusUnauthorized, "Unauthorized")
+}
+
+// renderSystemPage renders the system view with the given name (e.g. views/system/404.p2), if it exists, and falls
+// back to a plain text error with the message otherwise.
+func (a *Pongo2Adapter) renderSystemPage(w http.ResponseWriter, r *http.Request, resp *response.Response, name string, status int, message string) {
+	systemPath := path.Join(constants.ViewsDir, constants.SystemDir, name)
+	if _, ok := a.template(systemPath); ok {
+		a.Render(w, r, resp.Path(systemPath).Status(status))
+		return
+	}
+
+	http.Error(w, message, status)
+}
+
+// pongo2FSLoader loads pongo2 templates from an fs.FS. Unlike pongo2.FSLoader, paths are always relative to the root
+// of the filesystem, rather than to the including template, consistent with the other adapters.
+type pongo2FSLoader struct {
+	fsys fs.FS
+}
+
+func (l *pongo2FSLoader) Abs(_, name string) string {
+	return path.Clean(strings.TrimPrefix(name, "/"))
+}
+
+func (l *pongo2FSLoader) Get(path string) (io.Reader, error) {
+	content, err := fs.ReadFile(l.fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+// pongo2Filter converts a template function that takes one or two arguments and returns a value (and optionally an
+// error) to a pongo2 filter, where the input is the first argument and the filter parameter the second.
+func pongo2Filter(fn any) (pongo2.FilterFunction, bool) {
+	fv := reflect.ValueOf(fn)
+	ft := fv.Type()
+	if ft.Kind() != reflect.Func || ft.IsVariadic() || ft.NumIn() < 1 || ft.NumIn() > 2 || ft.NumOut() < 1 || ft.NumOut() > 2 {
+		return nil, false
+	}
+
+	errorType := reflect.TypeFor[error]()
+	if ft.NumOut() == 2 && ft.Out(1) != errorType {
+		return nil, false
+	}
+
+	return func(in *pongo2.Value, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		values := []*pongo2.Value{in, param}
+		args := make([]reflect.Value, ft.NumIn())
+		for i := range args {
+			arg, err := pongo2Arg(values[i], ft.In(i))
+			if err != nil {
+				return nil, &pongo2.Error{Sender: "filter", OrigError: err}
+			}
+			args[i] = arg
+		}
+
+		out := fv.Call(args)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, &pongo2.Error{Sender: "filter", OrigError: out[1].Interface().(error)}
+		}
+		return pongo2.AsValue(out[0].Interface()), nil
+	}, true
+}
+
+// pongo2Arg converts a pongo2 value to an argument of the given type.
+func pongo2Arg(value *pongo2.Value, typ reflect.Type) (reflect.Value, error) {
+	if value == nil || value.IsNil() {
+		return reflect.Zero(typ), nil
+	}
+
+	v := reflect.ValueOf(value.Interface())
+	switch {
+	case v.Type().AssignableTo(typ):
+		return v, nil
+	case v.Type().ConvertibleTo(typ) && v.Kind() != reflect.String && typ.Kind() != reflect.String:
+		return v.Convert(typ), nil
+	case typ.Kind() == reflect.String:
+		return reflect.ValueOf(value.String()).Convert(typ), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), typ)
+}
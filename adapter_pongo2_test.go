@@ -0,0 +1,79 @@
+package hyperview_test
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestPongo2Adapter_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.p2":     {Data: []byte(`<title>{{ View.Title() }}</title>{% block content %}{% endblock %}`)},
+		"partials/name.p2":    {Data: []byte(`<b>{{ Name }}</b>`)},
+		"views/home.p2":       {Data: []byte(`{% extends "layouts/base.p2" %}{% block content %}Hello {% include "partials/name.p2" %}{% endblock %}`)},
+		"views/filters.p2":    {Data: []byte(`{{ Name|shout }} {{ shout(Name) }} {{ Name|upper }}`)},
+		"views/system/404.p2": {Data: []byte(`Missing {{ View.RequestPath() }}`)},
+	}
+
+	adapter := hyperview.NewPongo2Adapter(hyperview.Pongo2AdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+		Funcs:         map[string]any{"shout": func(s string) string { return strings.ToUpper(s) + "!" }},
+	})
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("p2", adapter))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		render     func(w http.ResponseWriter, r *http.Request)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "extends and include",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Path("home.p2").Title("Home").Data(map[string]any{"Name": "<Gopher>"}))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "<title>Home</title>Hello <b>&lt;Gopher&gt;</b>",
+		},
+		{
+			name: "functions and filters",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Path("filters.p2").Data(map[string]any{"Name": "go"}))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "GO! GO! GO",
+		},
+		{
+			name: "system page",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.RenderNotFoundAs(w, r, "p2")
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Missing /missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.render(w, httptest.NewRequest("GET", "/missing", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
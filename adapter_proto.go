@@ -0,0 +1,150 @@
+package hyperview
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// ProtoMessageFactory creates the Protocol Buffers message for a response from its view data.
+type ProtoMessageFactory func(r *http.Request, data *response.Data) (proto.Message, error)
+
+// ProtoAdapter is an adapter for rendering Protocol Buffers messages. The message is either set on the response with
+// Response.Proto, or created from the view data by a message factory registered for the template path.
+//
+// Messages are serialized as application/x-protobuf for clients that accept it (application/x-protobuf or
+// application/protobuf), and as JSON (using the protojson mapping) otherwise, e.g. for browsers. System pages are
+// rendered by the JSON adapter.
+type ProtoAdapter struct {
+	factories map[string]ProtoMessageFactory
+	json      *JSONAdapter
+	mu        sync.RWMutex // protects the factories
+}
+
+// NewProtoAdapter creates a new Protocol Buffers adapter.
+func NewProtoAdapter() *ProtoAdapter {
+	return &ProtoAdapter{
+		factories: make(map[string]ProtoMessageFactory),
+		json:      NewJSONViewAdapter(),
+	}
+}
+
+// RegisterMessage registers a message factory for the template path (e.g. "users/show"), which creates the message
+// for responses with the path that don't carry a message. Such responses select the adapter with the ".proto"
+// extension (e.g. resp.Path("users/show.proto")) or with HyperView.RenderAs.
+func (a *ProtoAdapter) RegisterMessage(path string, factory ProtoMessageFactory) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.factories[response.NewResponse().Path(path).TemplatePath()] = factory
+}
+
+func (a *ProtoAdapter) Init() error {
+	return nil
+}
+
+func (a *ProtoAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	contentType, out, err := a.marshal(r, resp)
+	if err != nil {
+		a.RenderSystemError(w, r, err, resp)
+		return
+	}
+
+	// Add any additional headers
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(resp.StatusCode())
+	_, _ = w.Write(out)
+}
+
+// RenderToWriter renders the message of the response to the given io.Writer, as binary Protocol Buffers if the
+// request accepts them and as JSON otherwise. Headers and the status code of the response are ignored.
+func (a *ProtoAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	_, out, err := a.marshal(r, resp)
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.Write(out)
+	return err
+}
+
+// marshal serializes the message of the response for the request and returns the content type and the output.
+func (a *ProtoAdapter) marshal(r *http.Request, resp *response.Response) (string, []byte, error) {
+	msg, err := a.message(r, resp)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if acceptsProtobuf(r) {
+		out, err := proto.Marshal(msg)
+		return "application/x-protobuf", out, err
+	}
+
+	out, err := protojson.Marshal(msg)
+	return jsonContentType, out, err
+}
+
+// message returns the message of the response, or creates it with the message factory for the template path.
+func (a *ProtoAdapter) message(r *http.Request, resp *response.Response) (proto.Message, error) {
+	if msg := resp.ProtoMessage(); msg != nil {
+		return msg, nil
+	}
+
+	a.mu.RLock()
+	factory, ok := a.factories[resp.TemplatePath()]
+	a.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no proto message for path: %s", resp.TemplatePath())
+	}
+
+	return factory(r, resp.ViewData(r))
+}
+
+func (a *ProtoAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderForbidden(w, r, resp)
+}
+
+func (a *ProtoAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderMaintenance(w, r, resp)
+}
+
+func (a *ProtoAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderMethodNotAllowed(w, r, resp)
+}
+
+func (a *ProtoAdapter) RenderNotFound(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderNotFound(w, r, resp)
+}
+
+func (a *ProtoAdapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderStatus(w, r, resp)
+}
+
+func (a *ProtoAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, resp *response.Response) {
+	a.json.RenderSystemError(w, r, err, resp)
+}
+
+func (a *ProtoAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	a.json.RenderUnauthorized(w, r, resp)
+}
+
+// acceptsProtobuf returns true if the request accepts a Protocol Buffers response.
+func acceptsProtobuf(r *http.Request) bool {
+	switch request.PreferredMediaType(r) {
+	case "application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf":
+		return true
+	}
+	return false
+}
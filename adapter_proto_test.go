@@ -0,0 +1,90 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestProtoAdapter_Render(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	binary, err := proto.Marshal(wrapperspb.String("hello"))
+	if err != nil {
+		t.Fatalf("error marshaling message: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "protobuf", accept: "application/x-protobuf", wantContentType: "application/x-protobuf", wantBody: string(binary)},
+		{name: "protobuf alias", accept: "application/protobuf", wantContentType: "application/x-protobuf", wantBody: string(binary)},
+		{name: "browser", accept: "text/html,application/xhtml+xml,*/*;q=0.8", wantContentType: "application/json; charset=UTF-8", wantBody: `"hello"`},
+		{name: "no accept header", wantContentType: "application/json; charset=UTF-8", wantBody: `"hello"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/greeting", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			hv.Render(w, r, response.NewResponse().Proto(wrapperspb.String("hello")).Status(http.StatusCreated))
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusCreated)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("Render() = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestProtoAdapter_RegisterMessage(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	adapter, ok := hv.Adapter("proto")
+	if !ok {
+		t.Fatal("proto adapter is not registered")
+	}
+	adapter.(*hyperview.ProtoAdapter).RegisterMessage("greeting", func(r *http.Request, data *response.Data) (proto.Message, error) {
+		return wrapperspb.String(data.GetString("Name")), nil
+	})
+
+	r := httptest.NewRequest("GET", "/greeting", nil)
+	w := httptest.NewRecorder()
+	hv.Render(w, r, response.NewResponse().Path("greeting.proto").Data(map[string]any{"Name": "gopher"}))
+
+	if got, want := w.Body.String(), `"gopher"`; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	r = httptest.NewRequest("GET", "/unknown", nil)
+	w = httptest.NewRecorder()
+	hv.Render(w, r, response.NewResponse().Path("unknown.proto"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
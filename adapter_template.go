@@ -3,123 +3,388 @@ package hyperview
 import (
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"log/slog"
+	"maps"
+	"net/http"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
+	"golang.org/x/sync/singleflight"
+
+	"github.com/hypergopher/hyperview/cache"
 	"github.com/hypergopher/hyperview/constants"
 	"github.com/hypergopher/hyperview/funcs"
+	"github.com/hypergopher/hyperview/response"
 )
 
+// extendsDirective matches the layout inheritance directive, e.g. {{/* extends "base" */}}
+var extendsDirective = regexp.MustCompile(`\{\{-?\s*/\*\s*extends\s+"([^"]+)"\s*\*/\s*-?\}\}`)
+
 // TemplateAdapter is a template adapter for the HyperView framework that uses the Go html/template package.
+//
+// Init compiles every page with the layouts and partials of its namespace, so that pages rendered with a single layout
+// are not parsed during requests. With LazyCompile, Init only parses the layouts and partials, and each page is
+// compiled on its first render instead (once, even for concurrent requests). Pages rendered with a layout chain (see
+// Response.Layouts and the extends directive) are compiled with the chain on first use and memoized per page and chain.
+// Init (and HyperView.Reinit) replaces all compiled templates at once and drops the memoized chains, so they are
+// compiled again from the current files; AddFS and RemoveFS only drop the templates of their tenant.
 type TemplateAdapter struct {
 	extension     string
 	fileSystemMap map[string]fs.FS
 	logger        *slog.Logger
 	funcMap       template.FuncMap
-	templates     map[string]*template.Template
+	partial       string
+	etags         bool
+	devErrors     bool
+	lazy          bool
+	metrics       Metrics
+	onMissing     MissingTemplateFunc
+	renderCache   cache.Store
+	tenants       map[string]fs.FS              // tenant file systems by tenant ID
+	sets          map[string]*templateSet       // shared templates by namespace ("" for the root namespace)
+	pages         map[string]templatePage       // page files by page name
+	templates     map[string]*template.Template // compiled page templates by page name
+	chains        map[string]*template.Template // compiled page templates by page name and layout chain (memoized)
+	mu            sync.RWMutex                  // protects the tenants and the template caches
+	compiling     singleflight.Group            // deduplicates lazy page compilations
+}
+
+// templateFile is the location of a template file in one of the template filesystems.
+type templateFile struct {
+	fsys fs.FS
+	path string
+}
+
+// templatePage is a page template file and the namespace of the shared templates it is compiled with.
+type templatePage struct {
+	templateFile
+	namespace string
 }
 
+// templateSet holds the layouts and partials shared by the pages of a namespace.
+type templateSet struct {
+	common  *template.Template      // layouts and partials shared by all pages (never executed)
+	layouts map[string]templateFile // layout files by layout name
+	parents map[string]string       // parent layout names by child layout name
+}
+
+// MissingTemplateFunc handles the render of a template path that does not exist, e.g. by rendering a catch-all
+// template, a page from a CMS, or the not found page. It returns false if it did not handle the render, in which case
+// the adapter responds with a server error. The function must not render the same missing path again.
+type MissingTemplateFunc func(w http.ResponseWriter, r *http.Request, path string) bool
+
 // TemplateViewAdapterOptions are the options for the TemplateAdapter.
 type TemplateViewAdapterOptions struct {
+	// Cache is the render cache for the cache template function and for responses with a cache key (see
+	// Response.Cache). Without a cache, the output is always rendered.
+	Cache cache.Store
+	// DevErrors renders template parse and execution errors as a developer error page with the failing template and
+	// line, the surrounding source, and the keys of the view data. The page exposes template source, so it should only
+	// be enabled in development.
+	DevErrors bool
+	// ETags sets an ETag computed from the rendered output on successful responses, and responds with 304 Not
+	// Modified when the If-None-Match header of the request matches.
+	ETags bool
 	// Extension is the file extension for the templates. Default is ".html".
 	Extension string
 	// FileSystemMap is a map of file systems to use for the templates.
 	FileSystemMap map[string]fs.FS
-	// Funcs is a map of functions to add to the template.FuncMap.
+	// Funcs is a map of functions to add to the built-in template functions (see funcs.Merge). The functions are only
+	// available to this adapter.
 	Funcs template.FuncMap
+	// LazyCompile compiles page templates on their first render instead of at Init, which shortens the startup of
+	// large (multi-tenant) template sets where many views are rarely rendered. Layouts and partials are still parsed at
+	// Init, but errors in pages are only reported when they are rendered.
+	LazyCompile bool
 	// Logger is the logger to use for the adapter.
 	Logger *slog.Logger
+	// Metrics observes the lookups of the render cache.
+	Metrics Metrics
+	// OnMissingTemplate handles renders of template paths that do not exist. Without it, or if it does not handle the
+	// render, the adapter responds with a server error.
+	OnMissingTemplate MissingTemplateFunc
+	// PartialTemplate is the name of the template rendered for partial responses (without the layout).
+	// Default is "page:main".
+	PartialTemplate string
 }
 
 // NewTemplateViewAdapter creates a new TemplateAdapter.
 func NewTemplateViewAdapter(opts TemplateViewAdapterOptions) *TemplateAdapter {
-	// Merge the other functions into the base template functions
-	for k, v := range opts.Funcs {
-		funcs.FuncMap[k] = v
-	}
-
 	if opts.Extension == "" {
 		opts.Extension = ".html"
 	}
 
+	if opts.PartialTemplate == "" {
+		opts.PartialTemplate = "page:main"
+	}
+
 	return &TemplateAdapter{
 		extension:     opts.Extension,
 		fileSystemMap: opts.FileSystemMap,
-		funcMap:       funcs.FuncMap,
+		funcMap:       funcs.Merge(opts.Funcs),
 		logger:        opts.Logger,
+		partial:       opts.PartialTemplate,
+		etags:         opts.ETags,
+		devErrors:     opts.DevErrors,
+		lazy:          opts.LazyCompile,
+		metrics:       opts.Metrics,
+		onMissing:     opts.OnMissingTemplate,
+		renderCache:   opts.Cache,
+		tenants:       make(map[string]fs.FS),
+		sets:          make(map[string]*templateSet),
+		pages:         make(map[string]templatePage),
 		templates:     make(map[string]*template.Template),
+		chains:        make(map[string]*template.Template),
 	}
 }
 
 func (a *TemplateAdapter) Init() error {
-	// Reset the template cache
-	a.templates = make(map[string]*template.Template)
+	// Build a new template cache, which replaces the current cache once all templates are parsed. This keeps
+	// in-flight renders working with the previous templates while the adapter is being reinitialized.
+	templates := make(map[string]*template.Template)
+	pages := make(map[string]templatePage)
+	sets := make(map[string]*templateSet)
 
-	commonTemplates, err := a.loadCommonTemplates()
+	rootSet, err := a.loadTemplateSet(nil, slices.Collect(maps.Values(a.fileSystemMap))...)
 	if err != nil {
 		return fmt.Errorf("error loading partials. %w", err)
 	}
+	sets[""] = rootSet
 
-	// Function to recursively process directories from all FileSystemMap
+	// Recursively process the views directories from all FileSystemMap
 	for fsID, fsys := range a.fileSystemMap {
-		processDirectory := func(path string, dir fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
+		prefix := ""
+		if fsID != constants.RootFSID {
+			prefix = fsID + ":"
+		}
 
-			if !dir.IsDir() && filepath.Ext(path) == a.extension {
-				relPath, err := filepath.Rel("", path)
-				if err != nil {
-					return err
-				}
-				pageName := strings.TrimSuffix(relPath, filepath.Ext(relPath))
-				if fsID != constants.RootFSID {
-					pageName = fsID + ":" + pageName
-				}
+		if err := a.loadPages(fsys, prefix, "", rootSet, templates, pages); err != nil {
+			return err
+		}
+	}
 
-				// Clone the common templates and parse the page template, so we can reuse the common templates for variants
-				tmpl, err := template.Must(commonTemplates.Clone()).ParseFS(fsys, path)
+	a.mu.RLock()
+	tenants := maps.Clone(a.tenants)
+	a.mu.RUnlock()
 
-				if err != nil {
-					return err
-				}
-				a.templates[pageName] = tmpl
-			}
-			return nil
+	// Tenants are compiled with their own layouts and partials on top of the root templates
+	for id, fsys := range tenants {
+		set, err := a.loadTemplateSet(rootSet, fsys)
+		if err != nil {
+			return fmt.Errorf("error loading partials for tenant %s. %w", id, err)
 		}
+		sets[id] = set
 
-		// If the "views" directory exists, parse it.
-		if _, err := fsys.Open(constants.ViewsDir); err == nil {
-			if err := fs.WalkDir(fsys, constants.ViewsDir, processDirectory); err != nil {
-				return err
-			}
+		if err := a.loadPages(fsys, id+":", id, set, templates, pages); err != nil {
+			return err
 		}
 	}
 
-	// Uncomment to view the template names found
-	//a.printTemplateNames()
+	a.mu.Lock()
+	a.sets = sets
+	a.pages = pages
+	a.templates = templates
+	a.chains = make(map[string]*template.Template)
+	a.mu.Unlock()
+
+	return nil
+}
+
+// AddFS registers a tenant filesystem under the given ID and compiles its views, which can then be rendered as
+// "id:views/..." or resolved transparently for requests of the tenant (see ContextWithTenant). Tenant views are compiled
+// with the root layouts and partials plus the tenant's own layouts and partials, which shadow the root ones. The
+// templates of a tenant are never visible to the root namespace or to other tenants.
+//
+// Registering an existing tenant ID replaces its templates.
+func (a *TemplateAdapter) AddFS(id string, fsys fs.FS) error {
+	if id == "" || id == constants.RootFSID || strings.Contains(id, ":") {
+		return fmt.Errorf("invalid tenant ID: %q", id)
+	}
+
+	if _, ok := a.fileSystemMap[id]; ok {
+		return fmt.Errorf("tenant ID conflicts with a registered filesystem: %s", id)
+	}
+
+	a.mu.RLock()
+	rootSet := a.sets[""]
+	a.mu.RUnlock()
+
+	if rootSet == nil {
+		return fmt.Errorf("adapter must be initialized before adding tenants")
+	}
+
+	set, err := a.loadTemplateSet(rootSet, fsys)
+	if err != nil {
+		return fmt.Errorf("error loading partials for tenant %s. %w", id, err)
+	}
+
+	templates := make(map[string]*template.Template)
+	pages := make(map[string]templatePage)
+	if err := a.loadPages(fsys, id+":", id, set, templates, pages); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.removeTenantTemplates(id)
+	a.tenants[id] = fsys
+	a.sets[id] = set
+	maps.Copy(a.templates, templates)
+	maps.Copy(a.pages, pages)
 
 	return nil
 }
 
-func (a *TemplateAdapter) loadCommonTemplates() (*template.Template, error) {
-	commonTemplates := template.New("_common_").Funcs(a.funcMap)
+// RemoveFS removes the tenant filesystem with the given ID and all of its templates.
+func (a *TemplateAdapter) RemoveFS(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.removeTenantTemplates(id)
+	delete(a.tenants, id)
+	delete(a.sets, id)
+}
+
+// removeTenantTemplates removes the compiled templates of a tenant. The caller must hold the write lock.
+func (a *TemplateAdapter) removeTenantTemplates(id string) {
+	prefix := id + ":"
+	for _, m := range []map[string]*template.Template{a.templates, a.chains} {
+		maps.DeleteFunc(m, func(name string, _ *template.Template) bool {
+			return strings.HasPrefix(name, prefix)
+		})
+	}
+	maps.DeleteFunc(a.pages, func(name string, _ templatePage) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// template returns the compiled template of the given page. With LazyCompile, the page is compiled on first use.
+func (a *TemplateAdapter) template(path string) (*template.Template, error) {
+	a.mu.RLock()
+	tmpl, ok := a.templates[path]
+	page, pageOK := a.pages[path]
+	set := a.sets[page.namespace]
+	a.mu.RUnlock()
+
+	if ok {
+		return tmpl, nil
+	}
+
+	if !pageOK || set == nil {
+		return nil, fmt.Errorf("template not found: %s", path)
+	}
+
+	// Concurrent renders of the same page wait for a single compilation. The key includes the template set, so renders
+	// after a reinitialization never wait for a compilation of the previous templates.
+	v, err, _ := a.compiling.Do(fmt.Sprintf("%p|%s", set, path), func() (any, error) {
+		tmpl, err := a.compilePage(set, page)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only cache the template if the templates were not reloaded in the meantime
+		a.mu.Lock()
+		if a.sets[page.namespace] == set {
+			a.templates[path] = tmpl
+		}
+		a.mu.Unlock()
+
+		return tmpl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*template.Template), nil
+}
+
+// resolvePage returns the name of the page to render for the given path. For requests of a tenant, the tenant's
+// version of the page is preferred, falling back to the root page. Pages of other tenants are never resolved.
+func (a *TemplateAdapter) resolvePage(r *http.Request, path string) (string, bool) {
+	tenant := TenantFromContext(r.Context())
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if ns, _, found := strings.Cut(path, ":"); found {
+		if _, isTenant := a.tenants[ns]; isTenant && ns != tenant {
+			return "", false
+		}
+	} else if tenant != "" {
+		if _, ok := a.pages[tenant+":"+path]; ok {
+			return tenant + ":" + path, true
+		}
+	}
+
+	_, ok := a.pages[path]
+	return path, ok
+}
+
+// HasTemplate returns true if the adapter has a page for the path and the request, e.g. views/pricing@b. It implements
+// TemplateFinder.
+func (a *TemplateAdapter) HasTemplate(r *http.Request, path string) bool {
+	_, ok := a.resolvePage(r, path)
+	return ok
+}
+
+// loadTemplateSet parses the layouts and partials of the given filesystems into a new template set, on top of the
+// templates of the base set, if any. Layouts that extend another layout are only recorded in the layouts and parents
+// of the set, as they are parsed on top of their parents when a layout chain is compiled.
+func (a *TemplateAdapter) loadTemplateSet(base *templateSet, fileSystems ...fs.FS) (*templateSet, error) {
+	set := &templateSet{
+		common:  template.New("_common_").Funcs(a.funcMap).Funcs(template.FuncMap{cacheFuncName: unboundCacheFunc}),
+		layouts: make(map[string]templateFile),
+		parents: make(map[string]string),
+	}
+
+	if base != nil {
+		common, err := base.common.Clone()
+		if err != nil {
+			return nil, err
+		}
+		set.common = common
+		maps.Copy(set.layouts, base.layouts)
+		maps.Copy(set.parents, base.parents)
+	}
+
+	for _, fsys := range fileSystems {
+		// If the "layouts" directory exists, parse it
+		layoutPaths, err := fs.Glob(fsys, constants.LayoutsDir+"/*"+a.extension)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range layoutPaths {
+			content, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return nil, err
+			}
+
+			name := strings.TrimSuffix(filepath.Base(path), a.extension)
+			set.layouts[name] = templateFile{fsys: fsys, path: path}
+			delete(set.parents, name)
+
+			if match := extendsDirective.FindSubmatch(content); match != nil {
+				set.parents[name] = string(match[1])
+				continue
+			}
+
+			if _, err := set.common.ParseFS(fsys, path); err != nil {
+				return nil, err
+			}
+		}
 
-	for _, fsys := range a.fileSystemMap {
 		processPartials := func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
 
 			if !d.IsDir() && filepath.Ext(path) == a.extension {
-				fullPath := path
-
-				layoutPath := constants.LayoutsDir + "/*" + a.extension
-				_, err := commonTemplates.ParseFS(fsys, layoutPath, fullPath)
-
-				if err != nil {
+				if _, err := set.common.ParseFS(fsys, path); err != nil {
 					return err
 				}
 			}
@@ -134,15 +399,268 @@ func (a *TemplateAdapter) loadCommonTemplates() (*template.Template, error) {
 		}
 	}
 
-	return commonTemplates, nil
+	return set, nil
 }
 
-func (a *TemplateAdapter) printTemplateNames() {
-	for name, tmpl := range a.templates {
-		fmt.Printf("Template: %s\n", name)
-		associatedTemplates := tmpl.Templates()
-		for _, tmpl := range associatedTemplates {
-			fmt.Printf("\tPartial/Child: %s\n", tmpl.Name())
+// loadPages compiles the views of the filesystem with the shared templates of the given set (or only records them
+// with LazyCompile). Page names are prefixed with the given prefix, e.g. "plugin:" for plugin filesystems.
+func (a *TemplateAdapter) loadPages(fsys fs.FS, prefix, namespace string, set *templateSet, templates map[string]*template.Template, pages map[string]templatePage) error {
+	processDirectory := func(path string, dir fs.DirEntry, err error) error {
+		if err != nil {
+			return err
 		}
+
+		if !dir.IsDir() && filepath.Ext(path) == a.extension {
+			relPath, err := filepath.Rel("", path)
+			if err != nil {
+				return err
+			}
+			pageName := prefix + strings.TrimSuffix(relPath, filepath.Ext(relPath))
+			page := templatePage{templateFile: templateFile{fsys: fsys, path: path}, namespace: namespace}
+			pages[pageName] = page
+
+			if a.lazy {
+				return nil
+			}
+
+			tmpl, err := a.compilePage(set, page)
+			if err != nil {
+				return err
+			}
+			templates[pageName] = tmpl
+		}
+		return nil
+	}
+
+	// If the "views" directory exists, parse it.
+	if _, err := fsys.Open(constants.ViewsDir); err == nil {
+		if err := fs.WalkDir(fsys, constants.ViewsDir, processDirectory); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compilePage compiles the page with the shared templates of the given set.
+func (a *TemplateAdapter) compilePage(set *templateSet, page templatePage) (*template.Template, error) {
+	// Clone the common templates and parse the page template, so we can reuse the common templates for variants
+	common, err := set.common.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := common.ParseFS(page.fsys, page.path)
+	if err != nil {
+		return nil, err
+	}
+	a.bindCacheFunc(tmpl, page.namespace)
+
+	return tmpl, nil
+}
+
+// layoutChain returns the chain of layouts for the response, from the outermost (root) layout to the innermost layout.
+// The chain is either set explicitly on the response via Response.Layouts, or follows the extends directives of the
+// layout files in the namespace of the page.
+func (a *TemplateAdapter) layoutChain(pageName string, resp *response.Response) ([]string, error) {
+	if chain := resp.TemplateLayouts(); len(chain) > 1 {
+		return chain, nil
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	chain := []string{resp.TemplateLayout()}
+	set, ok := a.sets[a.pages[pageName].namespace]
+	if !ok {
+		return chain, nil
+	}
+
+	for parent, ok := set.parents[chain[0]]; ok; parent, ok = set.parents[chain[0]] {
+		if slices.Contains(chain, parent) {
+			return nil, fmt.Errorf("layout %s has a circular extends chain", resp.TemplateLayout())
+		}
+		chain = append([]string{parent}, chain...)
+	}
+
+	return chain, nil
+}
+
+// chainTemplate returns the page template compiled with the given layout chain. Each layout in the chain is parsed
+// after its parent, so that it can fill the blocks of its parent, and the page is parsed last. Compiled templates
+// are cached until the adapter is reinitialized.
+func (a *TemplateAdapter) chainTemplate(pageName string, chain []string) (*template.Template, error) {
+	key := pageName + "|" + strings.Join(chain, ">")
+
+	a.mu.RLock()
+	tmpl, ok := a.chains[key]
+	page, pageOK := a.pages[pageName]
+	set := a.sets[page.namespace]
+	a.mu.RUnlock()
+
+	if ok {
+		return tmpl, nil
+	}
+
+	if !pageOK || set == nil {
+		return nil, fmt.Errorf("template not found: %s", pageName)
+	}
+
+	tmpl, err := set.compileChain(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tmpl.ParseFS(page.fsys, page.path); err != nil {
+		return nil, err
+	}
+	a.bindCacheFunc(tmpl, page.namespace)
+
+	// Only cache the template if the templates were not reloaded in the meantime
+	a.mu.Lock()
+	if a.sets[page.namespace] == set {
+		a.chains[key] = tmpl
+	}
+	a.mu.Unlock()
+
+	return tmpl, nil
+}
+
+// contentTemplate returns a template that renders the given HTML content as the "page:main" template of the root
+// namespace, compiled with the given layout chain. This allows other adapters (e.g. the MarkdownAdapter) to wrap
+// their output in the layouts of the adapter. The key identifies the content and must change when the content
+// changes, as compiled templates are cached until the adapter is reinitialized.
+func (a *TemplateAdapter) contentTemplate(key string, content template.HTML, chain []string) (*template.Template, error) {
+	key = "content|" + key + "|" + strings.Join(chain, ">")
+
+	a.mu.RLock()
+	tmpl, ok := a.chains[key]
+	set := a.sets[""]
+	a.mu.RUnlock()
+
+	if ok {
+		return tmpl, nil
+	}
+
+	if set == nil {
+		return nil, fmt.Errorf("template adapter is not initialized")
+	}
+
+	tmpl, err := set.compileChain(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.Funcs(template.FuncMap{"content": func() template.HTML { return content }})
+	if _, err := tmpl.New(key).Parse(`{{define "page:main"}}{{content}}{{end}}`); err != nil {
+		return nil, err
+	}
+	a.bindCacheFunc(tmpl, "")
+
+	a.mu.Lock()
+	if a.sets[""] == set {
+		a.chains[key] = tmpl
+	}
+	a.mu.Unlock()
+
+	return tmpl, nil
+}
+
+// executeContent executes the layout of the response (or only the partial page template for partial responses) with
+// the given HTML content as the page, and writes the output to wr. See contentTemplate for details.
+func (a *TemplateAdapter) executeContent(wr io.Writer, r *http.Request, resp *response.Response, key string, content template.HTML) error {
+	name := a.partial
+	var chain []string
+
+	if !resp.IsPartial() {
+		var err error
+		chain, err = a.layoutChain("", resp)
+		if err != nil {
+			return err
+		}
+		name = "layout:" + chain[0]
+	}
+
+	tmpl, err := a.contentTemplate(key, content, chain)
+	if err != nil {
+		return err
 	}
+
+	return tmpl.ExecuteTemplate(wr, name, resp.ViewData(r).Data())
+}
+
+// compileChain returns a clone of the common templates of the set with the layouts of the chain parsed on top, from
+// the outermost layout to the innermost, so that each layout can fill the blocks of its parent.
+func (set *templateSet) compileChain(chain []string) (*template.Template, error) {
+	tmpl, err := set.common.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) < 2 {
+		return tmpl, nil
+	}
+
+	for _, name := range chain {
+		layout, ok := set.layouts[name]
+		if !ok {
+			return nil, fmt.Errorf("layout not found in chain: %s", strings.Join(chain, " > "))
+		}
+
+		if _, err := tmpl.ParseFS(layout.fsys, layout.path); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl, nil
+}
+
+// TemplateInfo describes a page template of a TemplateAdapter, e.g. for the debug handler (see HyperView.DebugHandler).
+type TemplateInfo struct {
+	// Name is the name of the page, e.g. "views/home", "plugin:views/home", or "tenant:views/home".
+	Name string `json:"name"`
+	// FileSystem is the ID of the filesystem (or tenant) of the page.
+	FileSystem string `json:"filesystem"`
+	// Path is the path of the page file in its filesystem.
+	Path string `json:"path"`
+	// Compiled is false for pages that were not rendered yet with LazyCompile.
+	Compiled bool `json:"compiled"`
+	// Layouts are the names of the layouts the page can be rendered with.
+	Layouts []string `json:"layouts"`
+	// Templates are the names of the templates defined for the page, including its layouts and partials.
+	Templates []string `json:"templates"`
+}
+
+// Templates returns the page templates of the adapter, sorted by name.
+func (a *TemplateAdapter) Templates() []TemplateInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	infos := make([]TemplateInfo, 0, len(a.pages))
+	for name, page := range a.pages {
+		info := TemplateInfo{
+			Name:       name,
+			FileSystem: constants.RootFSID,
+			Path:       page.path,
+		}
+		if id, _, found := strings.Cut(name, ":"); found {
+			info.FileSystem = id
+		}
+		if set, ok := a.sets[page.namespace]; ok {
+			info.Layouts = slices.Sorted(maps.Keys(set.layouts))
+		}
+		if tmpl, ok := a.templates[name]; ok {
+			info.Compiled = true
+			for _, t := range tmpl.Templates() {
+				info.Templates = append(info.Templates, t.Name())
+			}
+			slices.Sort(info.Templates)
+		}
+		infos = append(infos, info)
+	}
+
+	slices.SortFunc(infos, func(a, b TemplateInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos
 }
@@ -0,0 +1,151 @@
+package hyperview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// cacheFuncName is the name of the template function that renders a template through the render cache.
+const cacheFuncName = "cache"
+
+// unboundCacheFunc is the placeholder of the cache function for parsing. It is replaced by a function bound to the
+// compiled template (see bindCacheFunc).
+func unboundCacheFunc(_, _, _ string, _ any, _ ...string) (template.HTML, error) {
+	return "", fmt.Errorf("cache function is not bound to a template")
+}
+
+// bindCacheFunc binds the cache function to the compiled template, so that it can execute the templates of the page:
+//
+//	{{ cache "sidebar:user:42" "5m" "partial:sidebar" . }}
+//	{{ cache "product:42" "1h" "partial:product" .Product "products" "product:42" }}
+//
+// The function executes the named template with the data, and caches the output under the key for the ttl (a duration
+// such as "30s" or "5m"), tagged with the optional tags for invalidation (see InvalidateCacheTags). Keys are scoped to
+// the namespace of the page, so that tenants never share cached output, but must otherwise include everything the
+// output depends on (e.g. the user).
+func (a *TemplateAdapter) bindCacheFunc(tmpl *template.Template, namespace string) {
+	tmpl.Funcs(template.FuncMap{
+		cacheFuncName: func(key, ttl, name string, data any, tags ...string) (template.HTML, error) {
+			duration, err := time.ParseDuration(ttl)
+			if err != nil {
+				return "", fmt.Errorf("invalid cache ttl %q: %w", ttl, err)
+			}
+
+			out, err := a.cached(context.Background(), renderCacheKey(namespace, key), duration, tags, func(wr io.Writer) error {
+				return tmpl.ExecuteTemplate(wr, name, data)
+			})
+			return template.HTML(out), err
+		},
+	})
+}
+
+// executeCached executes the response like executeResponse, but through the render cache if the response sets a
+// cache key (see Response.Cache). The output is cached per page mode, fragment, and layout chain, so that e.g. the
+// partial output of an HTMX request is never served for a full page load of the same key.
+func (a *TemplateAdapter) executeCached(wr io.Writer, r *http.Request, resp *response.Response, pageName string) error {
+	if resp.CacheKey() == "" {
+		return a.executeResponse(wr, r, resp, pageName)
+	}
+
+	variant, err := a.renderVariant(pageName, resp)
+	if err != nil {
+		return err
+	}
+
+	a.mu.RLock()
+	namespace := a.pages[pageName].namespace
+	a.mu.RUnlock()
+
+	key := renderCacheKey(namespace, resp.CacheKey()) + "|" + variant
+	out, err := a.cached(r.Context(), key, resp.CacheTTL(), resp.Tags(), func(wr io.Writer) error {
+		return a.executeResponse(wr, r, resp, pageName)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.Write(out)
+	return err
+}
+
+// cached returns the output cached under the key, or renders and caches it with the tags. Without a render cache,
+// the output is always rendered. Errors of the cache are logged, but never fail the render.
+func (a *TemplateAdapter) cached(ctx context.Context, key string, ttl time.Duration, tags []string, render func(wr io.Writer) error) ([]byte, error) {
+	if a.renderCache != nil {
+		out, ok, err := a.renderCache.Get(ctx, key)
+		if err != nil {
+			a.logCacheError("error reading render cache", key, err)
+		} else {
+			if a.metrics != nil {
+				a.metrics.ObserveCache(ok)
+			}
+			if ok {
+				return out, nil
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := render(buf); err != nil {
+		return nil, err
+	}
+
+	if a.renderCache != nil {
+		if err := a.renderCache.Set(ctx, key, buf.Bytes(), ttl, tags...); err != nil {
+			a.logCacheError("error writing render cache", key, err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// InvalidateCacheTags removes the output tagged with any of the tags from the render cache, e.g. after the tagged
+// content changed. Tags are shared with the page cache and CDNs (see Response.CacheTags), so the same tags can be
+// purged there.
+func (a *TemplateAdapter) InvalidateCacheTags(ctx context.Context, tags ...string) error {
+	if a.renderCache == nil {
+		return nil
+	}
+	return a.renderCache.DeleteTags(ctx, tags...)
+}
+
+// logCacheError logs an error of the render cache, if the adapter has a logger.
+func (a *TemplateAdapter) logCacheError(msg, key string, err error) {
+	if a.logger != nil {
+		a.logger.Error(msg, slog.String("key", key), slog.String("err", err.Error()))
+	}
+}
+
+// renderVariant returns what the output of the response depends on besides its cache key: the fragment, the partial
+// page mode, or the layout chain the page is rendered with.
+func (a *TemplateAdapter) renderVariant(pageName string, resp *response.Response) (string, error) {
+	switch {
+	case resp.TemplateFragment() != "":
+		return "fragment=" + resp.TemplateFragment(), nil
+	case resp.IsPartial():
+		return "partial", nil
+	}
+
+	chain, err := a.layoutChain(pageName, resp)
+	if err != nil {
+		return "", err
+	}
+	return "layouts=" + strings.Join(chain, ","), nil
+}
+
+// renderCacheKey returns the key of the render cache for the key in the namespace ("" for the root namespace).
+func renderCacheKey(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + ":" + key
+}
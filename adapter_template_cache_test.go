@@ -0,0 +1,182 @@
+package hyperview_test
+
+import (
+	"context"
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/cache"
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func newCachedTemplateAdapter(t *testing.T, store cache.Store) *hyperview.TemplateAdapter {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}<main>{{template "page:main" .}}</main>{{end}}`)},
+		"partials/sidebar.html": {Data: []byte(`{{define "partial:sidebar"}}<aside>{{.Name}}</aside>{{end}}`)},
+		"views/home.html":       {Data: []byte(`{{define "page:main"}}{{cache "sidebar" "1m" "partial:sidebar" .}}<p>{{.Name}}</p>{{end}}`)},
+	}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		Cache:         store,
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+	return adapter
+}
+
+func TestTemplateAdapter_CacheFunc(t *testing.T) {
+	store := cache.NewMemoryStore()
+	adapter := newCachedTemplateAdapter(t, store)
+	r := httptest.NewRequest("GET", "/", nil)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Gopher", want: "<main><aside>Gopher</aside><p>Gopher</p></main>"},
+		{name: "Ferris", want: "<main><aside>Gopher</aside><p>Ferris</p></main>"},
+	}
+
+	for _, tt := range tests {
+		got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": tt.name}))
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+		}
+	}
+
+	if err := store.Delete(context.Background(), "sidebar"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Ferris"}))
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+	if want := "<main><aside>Ferris</aside><p>Ferris</p></main>"; got != want {
+		t.Errorf("RenderToString() after Delete() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateAdapter_CacheFuncWithoutStore(t *testing.T) {
+	adapter := newCachedTemplateAdapter(t, nil)
+	r := httptest.NewRequest("GET", "/", nil)
+
+	for _, name := range []string{"Gopher", "Ferris"} {
+		got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": name}))
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		if want := "<main><aside>" + name + "</aside><p>" + name + "</p></main>"; got != want {
+			t.Errorf("RenderToString() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestTemplateAdapter_CacheResponse(t *testing.T) {
+	adapter := newCachedTemplateAdapter(t, cache.NewMemoryStore())
+	r := httptest.NewRequest("GET", "/", nil)
+
+	for _, name := range []string{"Gopher", "Ferris"} {
+		w := httptest.NewRecorder()
+		adapter.Render(w, r, response.NewResponse().Layout("base").Path("home").PartialOnly().Cache("home", time.Minute).Data(map[string]any{"Name": name}))
+
+		if want := "<aside>Gopher</aside><p>Gopher</p>"; w.Body.String() != want {
+			t.Errorf("Render(%s) = %q, want %q", name, w.Body.String(), want)
+		}
+	}
+}
+
+func TestTemplateAdapter_CacheResponsePageModes(t *testing.T) {
+	const (
+		partial = "<aside>Gopher</aside><p>Gopher</p>"
+		full    = "<main><aside>Gopher</aside><p>Gopher</p></main>"
+	)
+	r := httptest.NewRequest("GET", "/", nil)
+
+	tests := []struct {
+		name  string
+		order []bool // partial renders
+		want  []string
+	}{
+		{name: "partial then full", order: []bool{true, false}, want: []string{partial, full}},
+		{name: "full then partial", order: []bool{false, true}, want: []string{full, partial}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := newCachedTemplateAdapter(t, cache.NewMemoryStore())
+			for i, isPartial := range tt.order {
+				resp := response.NewResponse().Layout("base").Path("home").Cache("home", time.Minute).Data(map[string]any{"Name": "Gopher"})
+				if isPartial {
+					resp.PartialOnly()
+				}
+				w := httptest.NewRecorder()
+				adapter.Render(w, r, resp)
+				if w.Body.String() != tt.want[i] {
+					t.Errorf("Render() #%d = %q, want %q", i+1, w.Body.String(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateAdapter_CacheInvalidTTL(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}{{cache "home" "soon" "page:main" .}}{{end}}`)},
+	}
+	adapter := newTestTemplateAdapter(t, fsys)
+
+	_, err := adapter.RenderToString(httptest.NewRequest("GET", "/", nil), response.NewResponse().Layout("base").Path("home"))
+	if err == nil {
+		t.Error("RenderToString() error = nil, want invalid cache ttl")
+	}
+}
+
+func TestTemplateAdapter_InvalidateCacheTags(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"partials/product.html": {Data: []byte(`{{define "partial:product"}}<h1>{{.}}</h1>{{end}}`)},
+		"views/product.html":    {Data: []byte(`{{define "page:main"}}{{cache "product:42" "1h" "partial:product" .Name "products" "product:42"}}{{end}}`)},
+	}
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		Cache:         cache.NewMemoryStore(),
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+
+	render := func(name string) string {
+		t.Helper()
+		got, err := adapter.RenderToString(httptest.NewRequest("GET", "/", nil), response.NewResponse().Layout("base").Path("product").Data(map[string]any{"Name": name}))
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		return got
+	}
+
+	render("Gopher")
+	if got := render("Ferris"); got != "<h1>Gopher</h1>" {
+		t.Errorf("RenderToString() = %q, want the cached output", got)
+	}
+
+	if err := adapter.InvalidateCacheTags(context.Background(), "product:42"); err != nil {
+		t.Fatalf("InvalidateCacheTags() error = %v", err)
+	}
+
+	if got := render("Ferris"); got != "<h1>Ferris</h1>" {
+		t.Errorf("RenderToString() after InvalidateCacheTags() = %q, want %q", got, "<h1>Ferris</h1>")
+	}
+}
@@ -0,0 +1,170 @@
+package hyperview
+
+import (
+	"html/template"
+	"io/fs"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// devErrorContext is the number of source lines shown before and after the failing line on the developer error page.
+const devErrorContext = 5
+
+// templateErrorLocation matches the template name, line, and (for execution errors) column of a template parse or
+// execution error, e.g. `template: page:main:3:5: executing "page:main" at <.Name>: ...`.
+var templateErrorLocation = regexp.MustCompile(`(?:html/)?template: ?(\S+?):(\d+):(?:(\d+):)?`)
+
+// DevError describes a template error on the developer error page (see TemplateViewAdapterOptions.DevErrors).
+type DevError struct {
+	Error    string       // error message
+	Page     string       // name of the rendered page
+	Template string       // name of the failing template, if known
+	File     string       // path of the file of the failing template, if found
+	Line     int          // failing line, if known
+	Column   int          // failing column, if known
+	Source   []SourceLine // failing line with the surrounding source
+	DataKeys []string     // keys of the view data available to the template
+}
+
+// SourceLine is a line of template source on the developer error page.
+type SourceLine struct {
+	Number  int
+	Text    string
+	Current bool // the failing line
+}
+
+// devErrorTemplate renders the developer error page.
+var devErrorTemplate = template.Must(template.New("dev-error").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Template error</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; }
+pre { background: #f6f8fa; padding: 1rem; overflow-x: auto; }
+.current { background: #ffd7d5; display: block; }
+.number { color: #888; user-select: none; }
+</style>
+</head>
+<body>
+<h1>Template error</h1>
+<pre>{{.Error}}</pre>
+<dl>
+<dt>Page</dt><dd>{{.Page}}</dd>
+{{if .Template}}<dt>Template</dt><dd>{{.Template}}</dd>{{end}}
+{{if .File}}<dt>File</dt><dd>{{.File}}{{if .Line}}:{{.Line}}{{if .Column}}:{{.Column}}{{end}}{{end}}</dd>{{end}}
+</dl>
+{{if .Source}}<h2>Source</h2>
+<pre>{{range .Source}}<span{{if .Current}} class="current"{{end}}><span class="number">{{printf "%4d" .Number}}</span>  {{.Text}}</span>
+{{end}}</pre>{{end}}
+<h2>Data</h2>
+{{if .DataKeys}}<ul>{{range .DataKeys}}<li><code>.{{.}}</code></li>{{end}}</ul>{{else}}<p>No data.</p>{{end}}
+</body>
+</html>
+`))
+
+// renderDevError renders the developer error page for a template error of the page.
+func (a *TemplateAdapter) renderDevError(w http.ResponseWriter, r *http.Request, resp *response.Response, pageName string, err error) {
+	reportRenderError(r, err)
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if execErr := devErrorTemplate.Execute(buf, a.devError(r, resp, pageName, err)); execErr != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = buf.WriteTo(w)
+}
+
+// devError collects the details of a template error of the page: the failing template and line, the surrounding
+// source, and the keys of the view data.
+func (a *TemplateAdapter) devError(r *http.Request, resp *response.Response, pageName string, err error) DevError {
+	devErr := DevError{
+		Error:    err.Error(),
+		Page:     pageName,
+		DataKeys: slices.Sorted(maps.Keys(resp.ViewData(r).Data())),
+	}
+
+	match := templateErrorLocation.FindStringSubmatch(devErr.Error)
+	if match == nil {
+		return devErr
+	}
+	devErr.Template = match[1]
+	devErr.Line, _ = strconv.Atoi(match[2])
+	devErr.Column, _ = strconv.Atoi(match[3])
+
+	file, content, ok := a.templateSource(pageName, devErr.Template)
+	if !ok {
+		return devErr
+	}
+	devErr.File = file
+
+	lines := strings.Split(string(content), "\n")
+	first := max(devErr.Line-devErrorContext, 1)
+	last := min(devErr.Line+devErrorContext, len(lines))
+	for n := first; n <= last; n++ {
+		devErr.Source = append(devErr.Source, SourceLine{
+			Number:  n,
+			Text:    lines[n-1],
+			Current: n == devErr.Line,
+		})
+	}
+
+	return devErr
+}
+
+// templateSource returns the path and content of the file that defines the named template (or is named after it, for
+// parse errors), looking at the page file first, then at the layouts and partials of the page's namespace.
+func (a *TemplateAdapter) templateSource(pageName, name string) (string, []byte, bool) {
+	defines := regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"` + regexp.QuoteMeta(name) + `"`)
+
+	a.mu.RLock()
+	page := a.pages[pageName]
+	candidates := []templateFile{page.templateFile}
+	fileSystems := slices.Collect(maps.Values(a.fileSystemMap))
+	if set, ok := a.sets[page.namespace]; ok {
+		for _, layout := range slices.Sorted(maps.Keys(set.layouts)) {
+			candidates = append(candidates, set.layouts[layout])
+		}
+	}
+	if fsys, ok := a.tenants[page.namespace]; ok {
+		fileSystems = append([]fs.FS{fsys}, fileSystems...)
+	}
+	a.mu.RUnlock()
+
+	for _, fsys := range fileSystems {
+		_ = fs.WalkDir(fsys, constants.PartialsDir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && filepath.Ext(path) == a.extension {
+				candidates = append(candidates, templateFile{fsys: fsys, path: path})
+			}
+			return nil
+		})
+	}
+
+	for _, file := range candidates {
+		if file.fsys == nil {
+			continue
+		}
+		content, err := fs.ReadFile(file.fsys, file.path)
+		if err != nil {
+			continue
+		}
+		if filepath.Base(file.path) == name || defines.Match(content) {
+			return file.path, content, true
+		}
+	}
+
+	return "", nil, false
+}
@@ -3,10 +3,11 @@ package hyperview
 import (
 	"bytes"
 	"fmt"
-	"html/template"
+	"io"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/hypergopher/hyperview/constants"
@@ -14,18 +15,21 @@ import (
 )
 
 func (a *TemplateAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
-	tmpl, ok := a.templates[resp.TemplatePath()]
+	pageName, ok := a.resolvePage(r, resp.TemplatePath())
 	if !ok {
+		if a.onMissing != nil && a.onMissing(w, r, resp.TemplatePath()) {
+			return
+		}
 		a.handleError(w, r, fmt.Errorf("template not found: %s", resp.TemplatePath()))
 		return
 	}
 
-	a.execTemplate(w, r, resp, tmpl)
+	a.execTemplate(w, r, resp, pageName)
 }
 
 func (a *TemplateAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request, resp *response.Response) {
 	path := a.viewsPath(constants.SystemDir, "403")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		a.Render(w, r, resp.Path(path))
 		return
 	}
@@ -34,7 +38,7 @@ func (a *TemplateAdapter) RenderForbidden(w http.ResponseWriter, r *http.Request
 
 func (a *TemplateAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Request, resp *response.Response) {
 	path := a.viewsPath(constants.SystemDir, "503")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		a.Render(w, r, resp.Path(path))
 		return
 	}
@@ -43,7 +47,7 @@ func (a *TemplateAdapter) RenderMaintenance(w http.ResponseWriter, r *http.Reque
 
 func (a *TemplateAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request, resp *response.Response) {
 	path := a.viewsPath(constants.SystemDir, "405")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		a.Render(w, r, resp.Path(path))
 		return
 	}
@@ -52,14 +56,28 @@ func (a *TemplateAdapter) RenderMethodNotAllowed(w http.ResponseWriter, r *http.
 
 func (a *TemplateAdapter) RenderNotFound(w http.ResponseWriter, r *http.Request, resp *response.Response) {
 	path := a.viewsPath(constants.SystemDir, "404")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		a.Render(w, r, resp.Path(path))
 		return
 	}
 	http.Error(w, "Not Found", http.StatusNotFound)
 }
 
+// RenderStatus renders the system page of the status code of the response (e.g. views/system/429), falling back to a
+// plain text error with the status text.
+func (a *TemplateAdapter) RenderStatus(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	status := resp.StatusCode()
+	path := a.viewsPath(constants.SystemDir, strconv.Itoa(status))
+	if _, ok := a.resolvePage(r, path); ok {
+		a.Render(w, r, resp.Path(path))
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
 func (a *TemplateAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, resp *response.Response) {
+	reportRenderError(r, err)
+
 	// Get the stack trace and output to the log
 	a.logger.Error("Server error", slog.String("err", err.Error()))
 	lineErrors := ""
@@ -73,7 +91,7 @@ func (a *TemplateAdapter) RenderSystemError(w http.ResponseWriter, r *http.Reque
 
 	// If there is a template with the name "system/server_error" in the template cache, use it
 	path := a.viewsPath(constants.SystemDir, "500")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		resp.Path(path).
 			Errors(err.Error(), map[string]string{"LineErrors": lineErrors}).
 			StatusError()
@@ -86,7 +104,7 @@ func (a *TemplateAdapter) RenderSystemError(w http.ResponseWriter, r *http.Reque
 
 func (a *TemplateAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Request, resp *response.Response) {
 	path := a.viewsPath(constants.SystemDir, "401")
-	if _, ok := a.templates[path]; ok {
+	if _, ok := a.resolvePage(r, path); ok {
 		a.Render(w, r, resp.Path(path))
 		return
 	}
@@ -96,20 +114,24 @@ func (a *TemplateAdapter) RenderUnauthorized(w http.ResponseWriter, r *http.Requ
 
 func (a *TemplateAdapter) handleError(w http.ResponseWriter, r *http.Request, err error) {
 	if err != nil {
+		reportRenderError(r, err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
-func (a *TemplateAdapter) execTemplate(w http.ResponseWriter, r *http.Request, resp *response.Response, tmpl *template.Template) {
-	// Creating a buffer, so we can capture write errors before we write to the header
-	// Note that layouts are always defined with the same name as the layout file without the extension (e.g. base.html -> base)
-	buf := new(bytes.Buffer)
-	layout := fmt.Sprintf("layout:%s", resp.TemplateLayout())
-	err := tmpl.ExecuteTemplate(buf, layout, resp.ViewData(r).Data())
+func (a *TemplateAdapter) execTemplate(w http.ResponseWriter, r *http.Request, resp *response.Response, pageName string) {
+	// Use a pooled buffer, so we can capture write errors before we write to the header
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	err := a.executeCached(buf, r, resp, pageName)
 	if err != nil {
 		path := a.viewsPath(constants.SystemDir, "server-error")
-		if resp.TemplatePath() == path {
+		if a.devErrors {
+			a.renderDevError(w, r, resp, pageName, err)
+		} else if resp.TemplatePath() == path {
+			reportRenderError(r, err)
 			http.Error(w, fmt.Errorf("error executing template: %w", err).Error(), http.StatusInternalServerError)
 		} else {
 			a.handleError(w, r, fmt.Errorf("error executing template: %w", err))
@@ -121,15 +143,76 @@ func (a *TemplateAdapter) execTemplate(w http.ResponseWriter, r *http.Request, r
 	for key, value := range resp.Headers() {
 		w.Header().Set(key, value)
 	}
+	setCookies(w, resp)
 
-	// Set the status code
-	w.WriteHeader(resp.StatusCode())
+	// Write the status code and the buffer to the response
+	if err := writeOutput(w, r, resp.StatusCode(), buf.Bytes(), a.etags); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
 
-	// Write the buffer to the response
-	_, err = buf.WriteTo(w)
+// executeResponse executes the layout of the response (or only the partial page template for partial responses, or
+// the named fragment if one is set) for the given page and writes the output to wr. If the layout extends other
+// layouts, the page is compiled with the full layout chain and the outermost layout is executed.
+// Note that layouts are always defined with the same name as the layout file without the extension (e.g. base.html -> base)
+func (a *TemplateAdapter) executeResponse(wr io.Writer, r *http.Request, resp *response.Response, pageName string) error {
+	tmpl, err := a.template(pageName)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
 	}
+
+	data := resp.ViewData(r).Data()
+
+	if resp.TemplateFragment() != "" {
+		return tmpl.ExecuteTemplate(wr, resp.TemplateFragment(), data)
+	}
+
+	if resp.IsPartial() {
+		return tmpl.ExecuteTemplate(wr, a.partial, data)
+	}
+
+	chain, err := a.layoutChain(pageName, resp)
+	if err != nil {
+		return err
+	}
+
+	if len(chain) > 1 {
+		tmpl, err = a.chainTemplate(pageName, chain)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tmpl.ExecuteTemplate(wr, fmt.Sprintf("layout:%s", chain[0]), data)
+}
+
+// RenderToWriter renders the response template to the given io.Writer instead of an http.ResponseWriter.
+// Headers and the status code of the response are ignored. This is useful for reusing the same templates for emails,
+// background jobs, and caching layers.
+//
+// The request is passed through to the view data, so templates that use request helpers (e.g. .View.RequestPath) still
+// need a request. Outside an HTTP handler, a synthetic request can be created with http.NewRequest.
+func (a *TemplateAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	pageName, ok := a.resolvePage(r, resp.TemplatePath())
+	if !ok {
+		return fmt.Errorf("template not found: %s", resp.TemplatePath())
+	}
+
+	if err := a.executeCached(wr, r, resp, pageName); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	return nil
+}
+
+// RenderToString renders the response template and returns the output as a string. See RenderToWriter for details.
+func (a *TemplateAdapter) RenderToString(r *http.Request, resp *response.Response) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := a.RenderToWriter(buf, r, resp); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
 }
 
 func (a *TemplateAdapter) viewsPath(path ...string) string {
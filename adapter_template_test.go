@@ -0,0 +1,457 @@
+package hyperview_test
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func testTemplateFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html":  {Data: []byte(`{{define "layout:base"}}<main>{{template "page:main" .}}</main>{{end}}`)},
+		"partials/name.html": {Data: []byte(`{{define "@name"}}<b>{{.}}</b>{{end}}`)},
+		"views/home.html":    {Data: []byte(`{{define "page:main"}}Hello {{template "@name" .Name}}{{end}}`)},
+		"views/users.html":   {Data: []byte(`{{define "page:main"}}<table>{{range .Users}}{{template "row" .}}{{end}}</table>{{end}}{{define "row"}}<tr><td>{{.Name}}</td></tr>{{end}}`)},
+	}
+}
+
+func newTestTemplateAdapter(t *testing.T, fsys fs.FS) *hyperview.TemplateAdapter {
+	t.Helper()
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+	return adapter
+}
+
+func TestTemplateAdapter_RenderToString(t *testing.T) {
+	adapter := newTestTemplateAdapter(t, testTemplateFS())
+	r := httptest.NewRequest("GET", "/", nil)
+
+	got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Gopher"}))
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+
+	want := "<main>Hello <b>Gopher</b></main>"
+	if got != want {
+		t.Errorf("RenderToString() = %q, want %q", got, want)
+	}
+
+	_, err = adapter.RenderToString(r, response.NewResponse().Layout("base").Path("missing"))
+	if err == nil || !strings.Contains(err.Error(), "template not found") {
+		t.Errorf("RenderToString() error = %v, want template not found", err)
+	}
+}
+
+func TestTemplateAdapter_PartialOnly(t *testing.T) {
+	adapter := newTestTemplateAdapter(t, testTemplateFS())
+	r := httptest.NewRequest("GET", "/", nil)
+
+	got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").PartialOnly().Data(map[string]any{"Name": "Gopher"}))
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+
+	want := "Hello <b>Gopher</b>"
+	if got != want {
+		t.Errorf("RenderToString() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateAdapter_Fragment(t *testing.T) {
+	adapter := newTestTemplateAdapter(t, testTemplateFS())
+	r := httptest.NewRequest("GET", "/", nil)
+
+	tests := []struct {
+		name string
+		resp *response.Response
+		want string
+	}{
+		{
+			name: "full page",
+			resp: response.NewResponse().Layout("base").Path("users").Data(map[string]any{"Users": []map[string]string{{"Name": "a"}, {"Name": "b"}}}),
+			want: "<main><table><tr><td>a</td></tr><tr><td>b</td></tr></table></main>",
+		},
+		{
+			name: "fragment",
+			resp: response.NewResponse().Layout("base").Path("views/users").Fragment("row").Data(map[string]any{"Name": "c"}),
+			want: "<tr><td>c</td></tr>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.RenderToString(r, tt.resp)
+			if err != nil {
+				t.Fatalf("RenderToString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateAdapter_LayoutChain(t *testing.T) {
+	fsys := testTemplateFS()
+	fsys["layouts/base.html"] = &fstest.MapFile{Data: []byte(`{{define "layout:base"}}<body>{{block "content" .}}{{template "page:main" .}}{{end}}</body>{{end}}`)}
+	fsys["layouts/admin.html"] = &fstest.MapFile{Data: []byte(`{{/* extends "base" */}}{{define "layout:admin"}}{{end}}{{define "content"}}<nav>Admin</nav>{{template "page:main" .}}{{end}}`)}
+	fsys["layouts/settings.html"] = &fstest.MapFile{Data: []byte(`{{/* extends "admin" */}}{{define "layout:settings"}}{{end}}{{define "page:main"}}<h1>Settings</h1>{{template "settings:main" .}}{{end}}`)}
+	fsys["views/settings.html"] = &fstest.MapFile{Data: []byte(`{{define "settings:main"}}Options{{end}}`)}
+	adapter := newTestTemplateAdapter(t, fsys)
+	r := httptest.NewRequest("GET", "/", nil)
+
+	tests := []struct {
+		name string
+		resp *response.Response
+		want string
+	}{
+		{
+			name: "base layout is not affected by child layouts",
+			resp: response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Gopher"}),
+			want: "<body>Hello <b>Gopher</b></body>",
+		},
+		{
+			name: "extends directive",
+			resp: response.NewResponse().Layout("admin").Path("home").Data(map[string]any{"Name": "Gopher"}),
+			want: "<body><nav>Admin</nav>Hello <b>Gopher</b></body>",
+		},
+		{
+			name: "explicit chain",
+			resp: response.NewResponse().Layouts("base", "admin").Path("home").Data(map[string]any{"Name": "Gopher"}),
+			want: "<body><nav>Admin</nav>Hello <b>Gopher</b></body>",
+		},
+		{
+			name: "nested extends directives",
+			resp: response.NewResponse().Layout("settings").Path("settings"),
+			want: "<body><nav>Admin</nav><h1>Settings</h1>Options</body>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.RenderToString(r, tt.resp)
+			if err != nil {
+				t.Fatalf("RenderToString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateAdapter_Tenants(t *testing.T) {
+	adapter := newTestTemplateAdapter(t, testTemplateFS())
+	err := adapter.AddFS("acme", fstest.MapFS{
+		"partials/name.html": {Data: []byte(`{{define "@name"}}<i>{{.}}</i>{{end}}`)},
+		"views/about.html":   {Data: []byte(`{{define "page:main"}}About {{template "@name" .Name}}{{end}}`)},
+	})
+	if err != nil {
+		t.Fatalf("AddFS() error = %v", err)
+	}
+
+	data := map[string]any{"Name": "Gopher"}
+	root := httptest.NewRequest("GET", "/", nil)
+	acme := root.WithContext(hyperview.ContextWithTenant(root.Context(), "acme"))
+	other := root.WithContext(hyperview.ContextWithTenant(root.Context(), "other"))
+
+	tests := []struct {
+		name    string
+		r       *http.Request
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "root page", r: root, path: "home", want: "<main>Hello <b>Gopher</b></main>"},
+		{name: "tenant falls back to root page", r: acme, path: "home", want: "<main>Hello <b>Gopher</b></main>"},
+		{name: "tenant page with tenant partial", r: acme, path: "about", want: "<main>About <i>Gopher</i></main>"},
+		{name: "explicit tenant page", r: acme, path: "acme:views/about", want: "<main>About <i>Gopher</i></main>"},
+		{name: "tenant page is not visible to root", r: root, path: "about", wantErr: true},
+		{name: "tenant page is not visible to other tenants", r: other, path: "acme:views/about", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.RenderToString(tt.r, response.NewResponse().Layout("base").Path(tt.path).Data(data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderToString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	adapter.RemoveFS("acme")
+	if _, err := adapter.RenderToString(acme, response.NewResponse().Layout("base").Path("about")); err == nil {
+		t.Error("RenderToString() after RemoveFS() error = nil, want template not found")
+	}
+}
+
+func BenchmarkTemplateAdapter_Render(b *testing.B) {
+	users := make([]map[string]string, 100)
+	for i := range users {
+		users[i] = map[string]string{"Name": "Gopher"}
+	}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: testTemplateFS()},
+	})
+	if err := adapter.Init(); err != nil {
+		b.Fatalf("error initializing adapter: %v", err)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			w := httptest.NewRecorder()
+			adapter.Render(w, r, response.NewResponse().Layout("base").Path("users").Data(map[string]any{"Users": users}))
+		}
+	})
+}
+
+func TestTemplateAdapter_InitInvalidatesLayoutChains(t *testing.T) {
+	fsys := testTemplateFS()
+	fsys["layouts/base.html"] = &fstest.MapFile{Data: []byte(`{{define "layout:base"}}<body>{{block "content" .}}{{template "page:main" .}}{{end}}</body>{{end}}`)}
+	fsys["layouts/admin.html"] = &fstest.MapFile{Data: []byte(`{{/* extends "base" */}}{{define "layout:admin"}}{{end}}{{define "content"}}<nav>Admin</nav>{{template "page:main" .}}{{end}}`)}
+	adapter := newTestTemplateAdapter(t, fsys)
+	r := httptest.NewRequest("GET", "/", nil)
+
+	render := func() string {
+		t.Helper()
+		got, err := adapter.RenderToString(r, response.NewResponse().Layout("admin").Path("home").Data(map[string]any{"Name": "Gopher"}))
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		return got
+	}
+
+	if got, want := render(), "<body><nav>Admin</nav>Hello <b>Gopher</b></body>"; got != want {
+		t.Fatalf("RenderToString() = %q, want %q", got, want)
+	}
+
+	// The memoized chain is used until the adapter is reinitialized
+	fsys["layouts/admin.html"].Data = []byte(`{{/* extends "base" */}}{{define "layout:admin"}}{{end}}{{define "content"}}<nav>Console</nav>{{template "page:main" .}}{{end}}`)
+	if got, want := render(), "<body><nav>Admin</nav>Hello <b>Gopher</b></body>"; got != want {
+		t.Errorf("RenderToString() before Init() = %q, want %q", got, want)
+	}
+
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if got, want := render(), "<body><nav>Console</nav>Hello <b>Gopher</b></body>"; got != want {
+		t.Errorf("RenderToString() after Init() = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkTemplateAdapter_RenderLayoutChain(b *testing.B) {
+	fsys := testTemplateFS()
+	fsys["layouts/base.html"] = &fstest.MapFile{Data: []byte(`{{define "layout:base"}}<body>{{block "content" .}}{{template "page:main" .}}{{end}}</body>{{end}}`)}
+	fsys["layouts/admin.html"] = &fstest.MapFile{Data: []byte(`{{/* extends "base" */}}{{define "layout:admin"}}{{end}}{{define "content"}}<nav>Admin</nav>{{template "page:main" .}}{{end}}`)}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	if err := adapter.Init(); err != nil {
+		b.Fatalf("error initializing adapter: %v", err)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		adapter.Render(w, r, response.NewResponse().Layout("admin").Path("home").Data(map[string]any{"Name": "Gopher"}))
+	}
+}
+
+func TestTemplateAdapter_FuncsAreIsolated(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}{{greet .Name}}{{end}}`)},
+	}
+
+	newAdapter := func(greet func(string) string) *hyperview.TemplateAdapter {
+		adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+			FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+			Funcs:         map[string]any{"greet": greet},
+		})
+		if err := adapter.Init(); err != nil {
+			t.Fatalf("error initializing adapter: %v", err)
+		}
+		return adapter
+	}
+
+	english := newAdapter(func(name string) string { return "Hello " + name })
+	spanish := newAdapter(func(name string) string { return "Hola " + name })
+
+	r := httptest.NewRequest("GET", "/", nil)
+	resp := func() *response.Response {
+		return response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Gopher"})
+	}
+
+	for _, tt := range []struct {
+		adapter *hyperview.TemplateAdapter
+		want    string
+	}{
+		{english, "Hello Gopher"},
+		{spanish, "Hola Gopher"},
+	} {
+		got, err := tt.adapter.RenderToString(r, resp())
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("RenderToString() = %q, want %q", got, tt.want)
+		}
+	}
+
+	// An adapter without the function fails to compile the page instead of using another adapter's function
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	if err := adapter.Init(); err == nil {
+		t.Error("Init() error = nil, want an error for the undefined greet function")
+	}
+}
+
+func TestTemplateAdapter_LazyCompile(t *testing.T) {
+	fsys := testTemplateFS()
+	fsys["views/broken.html"] = &fstest.MapFile{Data: []byte(`{{define "page:main"}}{{.Name}`)}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+		LazyCompile:   true,
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("Init() error = %v, want the broken page to be compiled on first render", err)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Gopher"}))
+			if err != nil {
+				t.Errorf("RenderToString() error = %v", err)
+				return
+			}
+			if want := "<main>Hello <b>Gopher</b></main>"; got != want {
+				t.Errorf("RenderToString() = %q, want %q", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("broken")); err == nil {
+		t.Error("RenderToString() error = nil, want a template parse error")
+	}
+
+	if _, err := adapter.RenderToString(r, response.NewResponse().Layout("base").Path("missing")); err == nil || !strings.Contains(err.Error(), "template not found") {
+		t.Errorf("RenderToString() error = %v, want template not found", err)
+	}
+}
+
+func TestTemplateAdapter_OnMissingTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"existing template", "home", http.StatusOK, "Hello"},
+		{"handled", "docs/intro", http.StatusOK, "Catch-all docs/intro"},
+		{"not handled", "missing", http.StatusInternalServerError, "template not found"},
+	}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: testTemplateFS()},
+		OnMissingTemplate: func(w http.ResponseWriter, r *http.Request, path string) bool {
+			if !strings.HasPrefix(path, "views/docs/") {
+				return false
+			}
+			_, _ = fmt.Fprintf(w, "Catch-all %s", strings.TrimPrefix(path, "views/"))
+			return true
+		},
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			adapter.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Layout("base").Path(tt.path))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTemplateAdapter_DevErrors(t *testing.T) {
+	fsys := testTemplateFS()
+	fsys["views/exec.html"] = &fstest.MapFile{Data: []byte("{{define \"page:main\"}}\n<p>{{.Name}}</p>\n<p>{{.Name.First}}</p>\n{{end}}")}
+	fsys["views/parse.html"] = &fstest.MapFile{Data: []byte("{{define \"page:main\"}}\n{{.Name}\n{{end}}")}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"exec error", "exec", []string{"views/exec.html:3:", `class="current"`, "&lt;p&gt;{{.Name.First}}&lt;/p&gt;", "<code>.Name</code>"}},
+		{"parse error", "parse", []string{"views/parse.html:2", "{{.Name}"}},
+	}
+
+	adapter := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+		DevErrors:     true,
+		LazyCompile:   true,
+	})
+	if err := adapter.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			adapter.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Layout("base").Path(tt.path).Data(map[string]any{"Name": "Gopher"}))
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+				t.Errorf("Content-Type = %q, want text/html", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("body = %q, want it to contain %q", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
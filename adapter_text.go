@@ -0,0 +1,217 @@
+package hyperview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/funcs"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// TextTemplateAdapter is an adapter for rendering arbitrary non-HTML output, such as config files, SQL, or Terraform,
+// with the Go text/template package. Unlike the TemplateAdapter, the output is not HTML escaped.
+//
+// Views are the files with the configured extension in the views directories, and are executed as a whole. Files with
+// the same extension in the partials directories are shared by all views, and can be included with
+// {{template "partials/name.conf" .}}.
+type TextTemplateAdapter struct {
+	contentType   string
+	extension     string
+	fileSystemMap map[string]fs.FS
+	funcMap       template.FuncMap
+	logger        *slog.Logger
+	templates     map[string]*template.Template
+	mu            sync.RWMutex // protects the templates
+}
+
+// TextTemplateAdapterOptions are the options for the TextTemplateAdapter.
+type TextTemplateAdapterOptions struct {
+	// ContentType is the Content-Type of the rendered output. Default is "text/plain; charset=utf-8".
+	ContentType string
+	// Extension is the file extension for the templates, e.g. ".conf" (required).
+	Extension string
+	// FileSystemMap is a map of file systems to use for the templates.
+	FileSystemMap map[string]fs.FS
+	// Funcs is a map of functions to add to the template functions.
+	Funcs template.FuncMap
+	// Logger is the logger to use for the adapter.
+	Logger *slog.Logger
+}
+
+// NewTextTemplateAdapter creates a new TextTemplateAdapter.
+//
+// Example, rendering views/nginx.conf for the path "nginx.conf":
+//
+//	err := hv.RegisterAdapter("conf", hyperview.NewTextTemplateAdapter(hyperview.TextTemplateAdapterOptions{
+//		Extension:     ".conf",
+//		FileSystemMap: map[string]fs.FS{constants.RootFSID: templatesFS},
+//	}))
+func NewTextTemplateAdapter(opts TextTemplateAdapterOptions) *TextTemplateAdapter {
+	if opts.ContentType == "" {
+		opts.ContentType = "text/plain; charset=utf-8"
+	}
+
+	return &TextTemplateAdapter{
+		contentType:   opts.ContentType,
+		extension:     opts.Extension,
+		fileSystemMap: opts.FileSystemMap,
+		funcMap:       funcs.Merge(opts.Funcs),
+		logger:        opts.Logger,
+		templates:     make(map[string]*template.Template),
+	}
+}
+
+func (a *TextTemplateAdapter) Init() error {
+	if a.extension == "" {
+		return fmt.Errorf("text template adapter requires an extension")
+	}
+
+	// Parse the partials of all filesystems first, so that they can be used by the views of any filesystem
+	common := template.New("_common_").Funcs(a.funcMap)
+	for _, fsys := range a.fileSystemMap {
+		if err := a.walk(fsys, constants.PartialsDir, func(path string, content []byte) error {
+			_, err := common.New(path).Parse(string(content))
+			return err
+		}); err != nil {
+			return fmt.Errorf("error loading partials. %w", err)
+		}
+	}
+
+	templates := make(map[string]*template.Template)
+	for fsID, fsys := range a.fileSystemMap {
+		prefix := ""
+		if fsID != constants.RootFSID {
+			prefix = fsID + ":"
+		}
+
+		if err := a.walk(fsys, constants.ViewsDir, func(path string, content []byte) error {
+			pageName := prefix + strings.TrimSuffix(path, a.extension)
+			tmpl, err := template.Must(common.Clone()).New(pageName).Parse(string(content))
+			if err != nil {
+				return err
+			}
+			templates[pageName] = tmpl
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	a.mu.Lock()
+	a.templates = templates
+	a.mu.Unlock()
+
+	return nil
+}
+
+// walk calls fn for each file with the adapter extension in the given directory of the filesystem, if it exists.
+func (a *TextTemplateAdapter) walk(fsys fs.FS, dir string, fn func(path string, content []byte) error) error {
+	if _, err := fs.Stat(fsys, dir); err != nil {
+		return nil
+	}
+
+	return fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != a.extension {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(path, content)
+	})
+}
+
+// template returns the cached template for the given path
+func (a *TextTemplateAdapter) template(path string) (*template.Template, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	tmpl, ok := a.templates[path]
+	return tmpl, ok
+}
+
+func (a *TextTemplateAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	buf := new(bytes.Buffer)
+	if err := a.RenderToWriter(buf, r, resp); err != nil {
+		a.RenderSystemError(w, r, err, resp)
+		return
+	}
+
+	// Add any additional headers
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", a.contentType)
+	}
+
+	w.WriteHeader(resp.StatusCode())
+
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RenderToWriter renders the response template to the given io.Writer.
+// Headers and the status code of the response are ignored.
+func (a *TextTemplateAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	tmpl, ok := a.template(resp.TemplatePath())
+	if !ok {
+		return fmt.Errorf("template not found: %s", resp.TemplatePath())
+	}
+
+	if err := tmpl.Execute(wr, resp.ViewData(r).Data()); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+
+	return nil
+}
+
+func (a *TextTemplateAdapter) RenderForbidden(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+func (a *TextTemplateAdapter) RenderMaintenance(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Maintenance", http.StatusServiceUnavailable)
+}
+
+func (a *TextTemplateAdapter) RenderMethodNotAllowed(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+}
+
+func (a *TextTemplateAdapter) RenderNotFound(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Not Found", http.StatusNotFound)
+}
+
+func (a *TextTemplateAdapter) RenderStatus(w http.ResponseWriter, _ *http.Request, resp *response.Response) {
+	http.Error(w, http.StatusText(resp.StatusCode()), resp.StatusCode())
+}
+
+func (a *TextTemplateAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, _ *response.Response) {
+	reportRenderError(r, err)
+	if a.logger != nil {
+		a.logger.Error("Server error", slog.String("err", err.Error()))
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (a *TextTemplateAdapter) RenderUnauthorized(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+}
@@ -0,0 +1,43 @@
+package hyperview_test
+
+import (
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestTextTemplateAdapter_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"partials/upstream.conf": {Data: []byte(`{{define "upstream"}}upstream {{.}};{{end}}`)},
+		"views/nginx.conf":       {Data: []byte("server_name {{.Host}};\n{{template \"upstream\" .Upstream}}\n")},
+	}
+
+	adapter := hyperview.NewTextTemplateAdapter(hyperview.TextTemplateAdapterOptions{
+		Extension:     ".conf",
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("conf", adapter))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("nginx.conf").Data(map[string]any{
+		"Host":     "<example.com>",
+		"Upstream": "app & api",
+	}))
+
+	if got := w.Header().Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/plain; charset=utf-8", got)
+	}
+
+	want := "server_name <example.com>;\nupstream app & api;\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package hyperview
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// YAMLAdapter is an adapter for rendering YAML responses.
+type YAMLAdapter struct{}
+
+// NewYAMLViewAdapter creates a new YAML view adapter.
+func NewYAMLViewAdapter() *YAMLAdapter {
+	return &YAMLAdapter{}
+}
+
+func (v *YAMLAdapter) Init() error {
+	return nil
+}
+
+func (v *YAMLAdapter) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	if resp.StatusCode() == 0 {
+		resp.Status(http.StatusOK)
+	}
+
+	setCookies(w, resp)
+
+	if resp.StatusCode() > 299 {
+		err := YAMLFailure(w, resp.ViewData(r).Data(), "Failure", resp.StatusCode(), resp.HTTPHeader())
+		if err != nil {
+			v.RenderSystemError(w, r, err, resp)
+		}
+		return
+	}
+
+	err := YAMLSuccessWithStatus(w, resp.StatusCode(), resp.ViewData(r).Data(), resp.HTTPHeader())
+	if err != nil {
+		v.RenderSystemError(w, r, err, resp)
+	}
+}
+
+func (v *YAMLAdapter) RenderForbidden(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	err := YAMLFailure(w, nil, "Forbidden", http.StatusForbidden, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (v *YAMLAdapter) RenderMaintenance(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	err := YAMLFailure(w, nil, "Maintenance", http.StatusServiceUnavailable, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (v *YAMLAdapter) RenderMethodNotAllowed(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	err := YAMLFailure(w, nil, "Method not allowed", http.StatusMethodNotAllowed, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (v *YAMLAdapter) RenderNotFound(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	err := YAMLFailure(w, nil, "Not found", http.StatusNotFound, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RenderStatus renders a failure envelope (an error envelope for a 5xx status code) with the status code of the
+// response and the status text.
+func (v *YAMLAdapter) RenderStatus(w http.ResponseWriter, _ *http.Request, resp *response.Response) {
+	status := resp.StatusCode()
+
+	var err error
+	if status >= http.StatusInternalServerError {
+		err = YAMLError(w, http.StatusText(status), status, nil)
+	} else {
+		err = YAMLFailure(w, nil, http.StatusText(status), status, nil)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (v *YAMLAdapter) RenderSystemError(w http.ResponseWriter, r *http.Request, err error, _ *response.Response) {
+	reportRenderError(r, err)
+	e := YAMLError(w, err.Error(), http.StatusInternalServerError, nil)
+	if e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (v *YAMLAdapter) RenderUnauthorized(w http.ResponseWriter, _ *http.Request, _ *response.Response) {
+	err := YAMLFailure(w, nil, "Unauthorized", http.StatusUnauthorized, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RenderToWriter renders the response data as a YAML envelope to the given io.Writer.
+// Headers and the status code of the response are not written.
+func (v *YAMLAdapter) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	envelope := Envelope{
+		Status:  "success",
+		Code:    resp.StatusCode(),
+		Message: "Success",
+		Data:    resp.ViewData(r).Data(),
+	}
+
+	if resp.StatusCode() > 299 {
+		envelope.Status = "fail"
+		envelope.Message = "Failure"
+	}
+
+	out, err := marshalYAML(envelope)
+	if err != nil {
+		return err
+	}
+
+	_, err = wr.Write(out)
+	return err
+}
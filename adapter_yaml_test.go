@@ -0,0 +1,47 @@
+package hyperview_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestYAMLAdapter_Render(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{name: "yaml extension", path: "status.yaml"},
+		{name: "yml extension", path: "status.yml"},
+		{name: "accept header", path: "status", accept: "application/yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			hv.Render(w, r, response.NewResponse().Path(tt.path).Data(map[string]any{"Version": "1.2.3"}))
+
+			if got := w.Header().Get("Content-Type"); got != "application/yaml; charset=UTF-8" {
+				t.Errorf("Content-Type = %q, want application/yaml; charset=UTF-8", got)
+			}
+
+			want := "status: success\nmessage: Success\ndata:\n  Error: \"\"\n  Errors: {}\n  Version: 1.2.3\n  View: {}\ncode: 200\n"
+			if got := w.Body.String(); got != want {
+				t.Errorf("Render() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package hyperview
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// AliasTemplate registers name as an alias of the template path, so that handlers can render a page by a stable
+// logical name while its template moves, e.g. hv.AliasTemplate("home", "marketing/landing-v3") renders
+// views/marketing/landing-v3 for responses with the path "home". Names and paths are relative to the views
+// directory, like Response.Path, and the path can have an extension to select the adapter.
+//
+// Aliases belong to the view service, so they survive Reinit. An alias can point to another alias, but not to
+// itself. Registering a name again replaces its alias.
+func (s *HyperView) AliasTemplate(name, path string) error {
+	if name == "" || path == "" {
+		return fmt.Errorf("template alias needs a name and a path")
+	}
+
+	name = templatePath(name)
+	path = templatePath(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aliases := maps.Clone(s.aliasMap())
+	if aliases == nil {
+		aliases = make(map[string]string, 1)
+	}
+	aliases[name] = path
+
+	// Follow the chain of the new alias to make sure that it ends
+	seen := map[string]bool{name: true}
+	for target, ok := aliases[name]; ok; target, ok = aliases[target] {
+		if seen[target] {
+			return fmt.Errorf("template alias %s of %s is circular", name, path)
+		}
+		seen[target] = true
+	}
+
+	s.aliases.Store(&aliases)
+	return nil
+}
+
+// TemplateAliases returns a copy of the registered template aliases, with the template paths of the aliases and
+// their templates, e.g. views/home: views/marketing/landing-v3.
+func (s *HyperView) TemplateAliases() map[string]string {
+	return maps.Clone(s.aliasMap())
+}
+
+// aliasMap returns the registered template aliases. The map must not be modified, AliasTemplate replaces it instead.
+func (s *HyperView) aliasMap() map[string]string {
+	if aliases := s.aliases.Load(); aliases != nil {
+		return *aliases
+	}
+	return nil
+}
+
+// resolveAlias replaces an aliased template path of the response with the path of its template.
+func (s *HyperView) resolveAlias(resp *response.Response) {
+	aliases := s.aliasMap()
+	if len(aliases) == 0 {
+		return
+	}
+
+	path, ok := aliases[resp.TemplatePath()]
+	if !ok {
+		return
+	}
+	for target, ok := aliases[path]; ok; target, ok = aliases[target] {
+		path = target
+	}
+	resp.Path(path)
+}
+
+// templatePath returns the template path of a response with the path, e.g. views/home for home.
+func templatePath(path string) string {
+	return response.NewResponse().Path(path).TemplatePath()
+}
@@ -0,0 +1,233 @@
+package hyperview
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hypergopher/hyperview/compress"
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// defaultAssetsURL is the URL prefix of the assets served by Mount with the prefix "/".
+const defaultAssetsURL = "/assets/"
+
+// Cache-Control headers of assets.
+const (
+	immutableCacheControl  = "public, max-age=31536000, immutable"
+	revalidateCacheControl = "no-cache"
+)
+
+// precompressedEncodings are the content encodings of precompressed asset variants by their extension, in the order
+// of preference.
+var precompressedEncodings = []struct {
+	encoding string
+	ext      string
+}{
+	{compress.EncodingBrotli, ".br"},
+	{compress.EncodingGzip, ".gz"},
+}
+
+// WithAssetsURL sets the URL prefix of the fingerprinted asset URLs of the assetPath template function (default:
+// "/assets/"), e.g. "/static/assets/" if the assets are mounted under "/static/" (see Mount), or the URL of a CDN
+// that pulls the assets from the site.
+func WithAssetsURL(prefix string) Option {
+	return func(hgo *HyperView) error {
+		hgo.assetsURL = prefix
+		return nil
+	}
+}
+
+// AssetPaths returns the fingerprinted asset paths of the assets directory of the template filesystem, which back
+// the assetPath function of the default template adapter:
+//
+//	<link rel="stylesheet" href="{{assetPath "css/app.css"}}">
+//	// => <link rel="stylesheet" href="/assets/css/app.3f2a9c1b.css">
+//
+// Other adapters can add AssetPaths().Path to their functions.
+func (s *HyperView) AssetPaths() *funcs.AssetPaths {
+	return s.assetPaths
+}
+
+// AssetInliner returns the inliner of the assets directory of the template filesystem, which backs the inlineCSS and
+// inlineJS functions of the default template adapter:
+//
+//	{{inlineCSS .View "css/critical.css"}}
+//	// => <style nonce="r4nd0m">body{margin:0}</style>
+//
+// The nonce is the nonce of the request set by the nonce middleware, if any (see NonceMiddleware).
+func (s *HyperView) AssetInliner() *funcs.AssetInliner {
+	return s.assetInliner
+}
+
+// AssetsHandler returns a handler that serves the files of the filesystem, e.g. an embed.FS, under the URL prefix,
+// e.g. "/static/":
+//
+//   - Responses have a strong ETag of the content and respond with 304 Not Modified when it matches.
+//   - Fingerprinted names (see AssetPaths) are resolved to their files and cached as immutable if the fingerprint
+//     matches the content. Other files, and outdated fingerprints, are revalidated with Cache-Control: no-cache.
+//   - The content type is set from the extension of the file.
+//   - If a file has precompressed variants next to it, e.g. app.css.br and app.css.gz, the variant for the
+//     Accept-Encoding of the request is served instead, with its Content-Encoding.
+//
+// Missing files and directories render the not found page, and methods other than GET and HEAD the method not allowed
+// page. Mount serves the assets directory of the template filesystem with it.
+func (s *HyperView) AssetsHandler(prefix string, fsys fs.FS) http.Handler {
+	return s.newAssetsHandler(prefix, fsys, funcs.NewAssetPaths(fsys, prefix))
+}
+
+// newAssetsHandler returns the assets handler with the asset paths that resolve the fingerprinted names.
+func (s *HyperView) newAssetsHandler(prefix string, fsys fs.FS, paths *funcs.AssetPaths) *assetsHandler {
+	return &assetsHandler{hv: s, prefix: prefix, fsys: fsys, paths: paths}
+}
+
+// assetsHandler serves the files of an asset filesystem.
+type assetsHandler struct {
+	hv       *HyperView
+	prefix   string
+	fsys     fs.FS
+	paths    *funcs.AssetPaths
+	pipeline *assetPipeline // builds the built files of the manifest of the asset paths, if any
+	etags    sync.Map       // strong ETags by assetKey
+}
+
+// assetKey identifies the version of a file, so that the ETags of changed files, e.g. in development, are recomputed.
+type assetKey struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (h *assetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		h.hv.MethodNotAllowedHandler().ServeHTTP(w, r)
+		return
+	}
+
+	name, ok := strings.CutPrefix(r.URL.Path, h.prefix)
+	if !ok {
+		h.hv.NotFoundHandler().ServeHTTP(w, r)
+		return
+	}
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	built := h.pipeline != nil && h.paths.IsBuilt(name)
+	if built && h.pipeline.dev {
+		if err := h.hv.rebuildAssets(r.Context()); err != nil {
+			// The previous build is served, the error is shown in the log
+			h.hv.logger.Error("Error building assets", slog.String("err", err.Error()))
+		}
+	}
+
+	cacheControl := revalidateCacheControl
+	if built && !h.pipeline.dev {
+		cacheControl = immutableCacheControl
+	}
+	if !h.isFile(name) {
+		file, current := h.paths.Resolve(name)
+		if file == "" {
+			h.hv.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+		name = file
+		if current {
+			cacheControl = immutableCacheControl
+		}
+	}
+
+	header := w.Header()
+	header.Set("Cache-Control", cacheControl)
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	served := name
+	if variant, encoding, hasVariants := h.variant(r, name); hasVariants {
+		header.Add("Vary", "Accept-Encoding")
+		if variant != "" {
+			served = variant
+			header.Set("Content-Encoding", encoding)
+		}
+	}
+
+	content, modTime, etag, err := h.open(served)
+	if err != nil {
+		h.hv.NotFoundHandler().ServeHTTP(w, r)
+		return
+	}
+	if closer, ok := content.(io.Closer); ok {
+		defer closer.Close()
+	}
+	header.Set("ETag", etag)
+	http.ServeContent(w, r, name, modTime, content)
+}
+
+// isFile returns true if the name is a file of the filesystem, rather than a directory.
+func (h *assetsHandler) isFile(name string) bool {
+	info, err := fs.Stat(h.fsys, name)
+	return err == nil && !info.IsDir()
+}
+
+// variant returns the precompressed variant of the file for the Accept-Encoding of the request and its encoding, or
+// empty strings if none is accepted, and whether the file has variants, so that the response varies by
+// Accept-Encoding.
+func (h *assetsHandler) variant(r *http.Request, name string) (variant, encoding string, hasVariants bool) {
+	var available []string
+	for _, pc := range precompressedEncodings {
+		if h.isFile(name + pc.ext) {
+			available = append(available, pc.encoding)
+		}
+	}
+	if len(available) == 0 {
+		return "", "", false
+	}
+
+	encoding = compress.Negotiate(r.Header.Get("Accept-Encoding"), available)
+	for _, pc := range precompressedEncodings {
+		if pc.encoding == encoding {
+			return name + pc.ext, encoding, true
+		}
+	}
+	return "", "", true
+}
+
+// open returns the content of the file, its modification time, and its strong ETag, which is computed from the
+// content once per version of the file.
+func (h *assetsHandler) open(name string) (io.ReadSeeker, time.Time, string, error) {
+	f, err := h.fsys.Open(name)
+	if err != nil {
+		return nil, time.Time{}, "", err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, time.Time{}, "", err
+	}
+
+	key := assetKey{name: name, size: info.Size(), modTime: info.ModTime()}
+	if etag, ok := h.etags.Load(key); ok {
+		if seeker, ok := f.(io.ReadSeeker); ok {
+			return seeker, info.ModTime(), etag.(string), nil
+		}
+	}
+
+	data, err := io.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		return nil, time.Time{}, "", err
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	h.etags.Store(key, etag)
+	return bytes.NewReader(data), info.ModTime(), etag, nil
+}
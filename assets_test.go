@@ -0,0 +1,205 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestAssetPath(t *testing.T) {
+	fsys := mountTestFS()
+	fsys["views/home.html"] = &fstest.MapFile{Data: []byte(`{{define "page:main"}}<link rel="stylesheet" href="{{assetPath "css/app.css"}}">{{end}}`)}
+
+	tests := []struct {
+		name string
+		opts []hyperview.Option
+		want string
+	}{
+		{"default URL", nil, `<link rel="stylesheet" href="/assets/css/app.5de625c3.css">`},
+		{"assets URL", []hyperview.Option{hyperview.WithAssetsURL("https://cdn.example.com/assets/")}, `<link rel="stylesheet" href="https://cdn.example.com/assets/css/app.5de625c3.css">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append(tt.opts, hyperview.WithTemplateFS(fsys))...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("home"))
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineAssets(t *testing.T) {
+	fsys := mountTestFS()
+	fsys["views/home.html"] = &fstest.MapFile{Data: []byte(`{{define "page:main"}}{{inlineCSS .View "css/app.css"}}{{end}}`)}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	var nonce string
+	handler := hv.NonceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce = hyperview.Nonce(r)
+		hv.Render(w, r, response.NewResponse().Path("home"))
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if want := `<style nonce="` + nonce + `">body { color: red; }</style>`; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+}
+
+func TestMount_FingerprintedAssets(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(mountTestFS()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	mux := http.NewServeMux()
+	hv.Mount(mux, "/")
+
+	tests := []struct {
+		name             string
+		path             string
+		wantStatus       int
+		wantBody         string
+		wantCacheControl string
+	}{
+		{"current fingerprint", "/assets/css/app.5de625c3.css", http.StatusOK, "color: red", "public, max-age=31536000, immutable"},
+		{"outdated fingerprint", "/assets/css/app.0123abcd.css", http.StatusOK, "color: red", "no-cache"},
+		{"plain name", "/assets/css/app.css", http.StatusOK, "color: red", "no-cache"},
+		{"missing file", "/assets/css/missing.5de625c3.css", http.StatusNotFound, "Page not found", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCacheControl {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCacheControl)
+			}
+			if tt.wantStatus == http.StatusOK && !strings.HasPrefix(w.Header().Get("Content-Type"), "text/css") {
+				t.Errorf("Content-Type = %q, want text/css", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestAssetsHandler(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(mountTestFS()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	static := fstest.MapFS{
+		"js/app.js":       {Data: []byte(`console.log("plain")`)},
+		"js/app.js.br":    {Data: []byte(`brotli`)},
+		"js/app.js.gz":    {Data: []byte(`gzip`)},
+		"data/items.json": {Data: []byte(`[]`)},
+	}
+	handler := hv.AssetsHandler("/static/", static)
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		acceptEncoding   string
+		wantStatus       int
+		wantBody         string
+		wantContentType  string
+		wantEncoding     string
+		wantVary         string
+		wantCacheControl string
+	}{
+		{"plain", "GET", "/static/js/app.js", "", http.StatusOK, `console.log("plain")`, "text/javascript; charset=utf-8", "", "Accept-Encoding", "no-cache"},
+		{"brotli", "GET", "/static/js/app.js", "gzip, br", http.StatusOK, "brotli", "text/javascript; charset=utf-8", "br", "Accept-Encoding", "no-cache"},
+		{"gzip", "GET", "/static/js/app.js", "gzip, br;q=0", http.StatusOK, "gzip", "text/javascript; charset=utf-8", "gzip", "Accept-Encoding", "no-cache"},
+		{"fingerprinted variant", "GET", "/static/js/app.1a37ee27.js", "br", http.StatusOK, "brotli", "text/javascript; charset=utf-8", "br", "Accept-Encoding", "public, max-age=31536000, immutable"},
+		{"without variants", "GET", "/static/data/items.json", "br", http.StatusOK, "[]", "application/json", "", "", "no-cache"},
+		{"missing", "GET", "/static/js/missing.js", "", http.StatusNotFound, "Page not found", "", "", "", ""},
+		{"method not allowed", "POST", "/static/js/app.js", "", http.StatusMethodNotAllowed, "Method not allowed", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := w.Header().Get("Vary"); got != tt.wantVary {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVary)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCacheControl {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCacheControl)
+			}
+		})
+	}
+}
+
+func TestAssetsHandler_ETag(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(mountTestFS()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	static := fstest.MapFS{"css/app.css": {Data: []byte(`body { color: red; }`)}}
+	handler := hv.AssetsHandler("/static/", static)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/static/css/app.css", nil))
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("ETag = %q, want a strong ETag", etag)
+	}
+
+	r := httptest.NewRequest("GET", "/static/css/app.css", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+	}
+
+	static["css/app.css"] = &fstest.MapFile{Data: []byte(`body { color: blue; }`)}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("status = %d, ETag = %q, want 200 and a new ETag after a change", w.Code, w.Header().Get("ETag"))
+	}
+}
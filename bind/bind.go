@@ -0,0 +1,284 @@
+// Package bind decodes query, form, and multipart values into structs.
+//
+// Fields are bound to the values with the name of their form tag, or of the field if it has none, and fields with the
+// tag "-" are skipped. The fields of embedded structs are bound as if they were fields of the outer struct, and the
+// fields of nested structs with the name of the struct field as prefix, e.g. "address.city":
+//
+//	type signup struct {
+//		Email    string                `form:"email"`
+//		Age      int                   `form:"age"`
+//		Birthday time.Time             `form:"birthday"`
+//		Terms    bool                  `form:"terms"`
+//		Tags     []string              `form:"tags"`
+//		Avatar   *multipart.FileHeader `form:"avatar"`
+//		Address  struct {
+//			City string `form:"city"`
+//		} `form:"address"`
+//	}
+//
+// Values are converted to the type of the field: strings, bools (including the "on" of checkboxes), integers, floats,
+// durations, times (RFC 3339 and the formats of date, time, and datetime-local inputs), types implementing
+// encoding.TextUnmarshaler, pointers and slices of them, and uploaded files. Missing and empty values leave the field
+// unchanged. Values that can't be converted are collected as FieldErrors by field name, which can be passed to
+// Response.Errors and Data.AddErrors as is.
+package bind
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxMemory is the maximum number of bytes of a multipart form kept in memory by Request, the rest of the files are
+// stored in temporary files.
+const MaxMemory = 32 << 20
+
+// ErrInvalidTarget is returned if the destination is not a pointer to a struct.
+var ErrInvalidTarget = errors.New("bind: destination must be a non-nil pointer to a struct")
+
+// FieldErrors are the messages of the values that could not be bound, by field name.
+type FieldErrors map[string]string
+
+// Error implements error, listing the messages sorted by field name.
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	msgs := make([]string, len(fields))
+	for i, field := range fields {
+		msgs[i] = field + ": " + e[field]
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Request binds the query, form, and multipart values of the request to the struct dst points to. Form values take
+// precedence over query values with the same name. It returns FieldErrors if values could not be converted.
+func Request(r *http.Request, dst any) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(MaxMemory); err != nil {
+			return fmt.Errorf("bind: error parsing multipart form: %w", err)
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("bind: error parsing form: %w", err)
+	}
+
+	var files map[string][]*multipart.FileHeader
+	if r.MultipartForm != nil {
+		files = r.MultipartForm.File
+	}
+
+	return bind(r.Form, files, dst)
+}
+
+// Values binds the values to the struct dst points to. It returns FieldErrors if values could not be converted.
+func Values(values url.Values, dst any) error {
+	return bind(values, nil, dst)
+}
+
+// bind binds the values and files to the struct dst points to.
+func bind(values url.Values, files map[string][]*multipart.FileHeader, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	b := binder{values: values, files: files, errors: FieldErrors{}}
+	b.bindStruct(v.Elem(), "")
+	if len(b.errors) > 0 {
+		return b.errors
+	}
+	return nil
+}
+
+// binder binds values to the fields of a struct, collecting the errors by field name.
+type binder struct {
+	values url.Values
+	files  map[string][]*multipart.FileHeader
+	errors FieldErrors
+}
+
+var (
+	fileHeaderType      = reflect.TypeFor[*multipart.FileHeader]()
+	fileHeadersType     = reflect.TypeFor[[]*multipart.FileHeader]()
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// bindStruct binds the values to the exported fields of the struct, with the prefix of nested structs.
+func (b *binder) bindStruct(v reflect.Value, prefix string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("form")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		fv := v.Field(i)
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		if field.Anonymous && tag == "" && fv.Kind() == reflect.Struct && !isScalar(fv.Type()) {
+			b.bindStruct(fv, prefix)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name = prefix + name
+		if fv.Kind() == reflect.Struct && !isScalar(fv.Type()) {
+			b.bindStruct(fv, name+".")
+			continue
+		}
+
+		b.bindField(fv, name)
+	}
+}
+
+// bindField binds the values or files of the name to the field.
+func (b *binder) bindField(fv reflect.Value, name string) {
+	switch fv.Type() {
+	case fileHeaderType:
+		if files := b.files[name]; len(files) > 0 {
+			fv.Set(reflect.ValueOf(files[0]))
+		}
+		return
+	case fileHeadersType:
+		if files := b.files[name]; len(files) > 0 {
+			fv.Set(reflect.ValueOf(files))
+		}
+		return
+	}
+
+	values, ok := b.values[name]
+	if !ok || len(values) == 0 {
+		return
+	}
+
+	if fv.Kind() == reflect.Slice && !isScalar(fv.Type()) {
+		slice := reflect.MakeSlice(fv.Type(), 0, len(values))
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if err := setValue(elem, value); err != nil {
+				b.errors[name] = err.Error()
+				return
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		fv.Set(slice)
+		return
+	}
+
+	if values[0] == "" && fv.Kind() != reflect.String {
+		return
+	}
+	if err := setValue(fv, values[0]); err != nil {
+		b.errors[name] = err.Error()
+	}
+}
+
+// isScalar returns true if values of the type are converted from a single string, e.g. time.Time and []byte.
+func isScalar(t reflect.Type) bool {
+	return t == timeType || reflect.PointerTo(t).Implements(textUnmarshalerType) ||
+		(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// timeLayouts are the layouts of times, in the formats of RFC 3339 and the date, time, and datetime-local inputs.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", time.DateOnly, "15:04:05", "15:04"}
+
+// setValue converts the value to the type of the field and sets it.
+func setValue(fv reflect.Value, value string) error {
+	if fv.Kind() == reflect.Pointer {
+		elem := reflect.New(fv.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+
+	switch fv.Type() {
+	case timeType:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				fv.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return errors.New("must be a valid date or time")
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("must be a valid duration")
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	if unmarshaler, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return errors.New("is invalid")
+		}
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		if value == "on" {
+			fv.SetBool(true)
+			return nil
+		}
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		fv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		fv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be a positive whole number")
+		}
+		fv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), fv.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		fv.SetFloat(v)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported type %s", fv.Type())
+		}
+		fv.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+
+	return nil
+}
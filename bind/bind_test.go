@@ -0,0 +1,180 @@
+package bind_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/bind"
+)
+
+type Audit struct {
+	Note string `form:"note"`
+}
+
+type signup struct {
+	Audit
+	Email      string        `form:"email"`
+	Age        int           `form:"age"`
+	Score      float64       `form:"score"`
+	Terms      bool          `form:"terms"`
+	Newsletter *bool         `form:"newsletter"`
+	Birthday   time.Time     `form:"birthday"`
+	Timeout    time.Duration `form:"timeout"`
+	Tags       []string      `form:"tags"`
+	Ratings    []int         `form:"ratings"`
+	IP         netip.Addr    `form:"ip"`
+	Nickname   *string       `form:"nickname"`
+	Untagged   string
+	Skipped    string `form:"-"`
+	Address    struct {
+		City string `form:"city"`
+	} `form:"address"`
+	internal string
+}
+
+func TestValues(t *testing.T) {
+	values := url.Values{
+		"email":        {"ada@example.com"},
+		"age":          {" 36 "},
+		"score":        {"9.5"},
+		"terms":        {"on"},
+		"newsletter":   {"false"},
+		"birthday":     {"1815-12-10"},
+		"timeout":      {"1m30s"},
+		"tags":         {"math", "", "poetry"},
+		"ratings":      {"4", "5"},
+		"ip":           {"192.0.2.1"},
+		"nickname":     {"ada"},
+		"Untagged":     {"value"},
+		"Skipped":      {"value"},
+		"-":            {"value"},
+		"address.city": {"London"},
+		"note":         {"embedded"},
+		"internal":     {"value"},
+	}
+
+	var got signup
+	if err := bind.Values(values, &got); err != nil {
+		t.Fatalf("Values() error = %v", err)
+	}
+
+	newsletter, nickname := false, "ada"
+	want := signup{
+		Audit:      Audit{Note: "embedded"},
+		Email:      "ada@example.com",
+		Age:        36,
+		Score:      9.5,
+		Terms:      true,
+		Newsletter: &newsletter,
+		Birthday:   time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC),
+		Timeout:    90 * time.Second,
+		Tags:       []string{"math", "poetry"},
+		Ratings:    []int{4, 5},
+		IP:         netip.MustParseAddr("192.0.2.1"),
+		Nickname:   &nickname,
+		Untagged:   "value",
+	}
+	want.Address.City = "London"
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %+v, want %+v", got, want)
+	}
+}
+
+func TestValues_FieldErrors(t *testing.T) {
+	values := url.Values{
+		"email":    {"ada@example.com"},
+		"age":      {"thirty"},
+		"score":    {"high"},
+		"terms":    {"maybe"},
+		"birthday": {"yesterday"},
+		"ratings":  {"4", "five"},
+		"ip":       {"not an ip"},
+	}
+
+	dst := signup{Age: 1}
+	err := bind.Values(values, &dst)
+
+	var fieldErrs bind.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("Values() error = %v, want FieldErrors", err)
+	}
+
+	want := bind.FieldErrors{
+		"age":      "must be a whole number",
+		"score":    "must be a number",
+		"terms":    "must be true or false",
+		"birthday": "must be a valid date or time",
+		"ratings":  "must be a whole number",
+		"ip":       "is invalid",
+	}
+	if !reflect.DeepEqual(fieldErrs, want) {
+		t.Errorf("FieldErrors = %v, want %v", fieldErrs, want)
+	}
+	if dst.Email != "ada@example.com" || dst.Age != 1 {
+		t.Errorf("Values() = %+v, want valid fields bound and invalid fields unchanged", dst)
+	}
+	if !strings.HasPrefix(err.Error(), "age: must be a whole number; birthday:") {
+		t.Errorf("Error() = %q, want messages sorted by field", err.Error())
+	}
+}
+
+func TestValues_InvalidTarget(t *testing.T) {
+	var s signup
+	for _, dst := range []any{s, (*signup)(nil), new(string), nil} {
+		if err := bind.Values(url.Values{}, dst); !errors.Is(err, bind.ErrInvalidTarget) {
+			t.Errorf("Values(%T) error = %v, want ErrInvalidTarget", dst, err)
+		}
+	}
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("form", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/signup?email=query@example.com&age=20", strings.NewReader("email=form@example.com"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var got signup
+		if err := bind.Request(r, &got); err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		if got.Email != "form@example.com" || got.Age != 20 {
+			t.Errorf("Request() = %q, %d, want form value and query value", got.Email, got.Age)
+		}
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		_ = mw.WriteField("email", "ada@example.com")
+		fw, _ := mw.CreateFormFile("avatar", "ada.png")
+		_, _ = fw.Write([]byte("png"))
+		fw, _ = mw.CreateFormFile("attachments", "a.txt")
+		_, _ = fw.Write([]byte("a"))
+		fw, _ = mw.CreateFormFile("attachments", "b.txt")
+		_, _ = fw.Write([]byte("b"))
+		_ = mw.Close()
+
+		r := httptest.NewRequest("POST", "/profile", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		var got struct {
+			Email       string                  `form:"email"`
+			Avatar      *multipart.FileHeader   `form:"avatar"`
+			Attachments []*multipart.FileHeader `form:"attachments"`
+		}
+		if err := bind.Request(r, &got); err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		if got.Email != "ada@example.com" || got.Avatar == nil || got.Avatar.Filename != "ada.png" || len(got.Attachments) != 2 {
+			t.Errorf("Request() = %+v, want email, avatar, and 2 attachments", got)
+		}
+	})
+}
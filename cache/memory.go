@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pruneInterval is the minimum interval between removals of expired entries from a MemoryStore.
+const pruneInterval = time.Minute
+
+// MemoryStore is an in-memory Store for a single instance. Expired entries are removed when they are read and
+// periodically when new entries are stored.
+type MemoryStore struct {
+	entries   map[string]memoryEntry
+	tags      map[string]map[string]struct{} // keys by tag
+	lastPrune time.Time
+	now       func() time.Time
+	mu        sync.RWMutex // protects the entries and tags
+}
+
+// memoryEntry is a value of a MemoryStore.
+type memoryEntry struct {
+	value   []byte
+	expires time.Time // zero if the entry doesn't expire
+	tags    []string
+}
+
+// NewMemoryStore creates a new in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		tags:    make(map[string]map[string]struct{}),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value stored under the key, and false if the key is not found or expired.
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	if entry.expired(s.now()) {
+		s.mu.Lock()
+		if entry, ok := s.entries[key]; ok && entry.expired(s.now()) {
+			s.delete(key)
+		}
+		s.mu.Unlock()
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set stores the value under the key, tagged with the tags. A ttl of 0 or less stores the value until it is deleted.
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	now := s.now()
+	entry := memoryEntry{value: value, tags: tags}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastPrune) >= pruneInterval {
+		for k, e := range s.entries {
+			if e.expired(now) {
+				s.delete(k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	s.delete(key)
+	s.entries[key] = entry
+	for _, tag := range tags {
+		if s.tags[tag] == nil {
+			s.tags[tag] = make(map[string]struct{})
+		}
+		s.tags[tag][key] = struct{}{}
+	}
+
+	return nil
+}
+
+// Delete removes the values stored under the keys.
+func (s *MemoryStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		s.delete(key)
+	}
+	return nil
+}
+
+// DeleteTags removes the values tagged with any of the tags.
+func (s *MemoryStore) DeleteTags(_ context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.delete(key)
+		}
+	}
+	return nil
+}
+
+// delete removes the entry of the key and its tags. The caller must hold the write lock.
+func (s *MemoryStore) delete(key string) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	delete(s.entries, key)
+	for _, tag := range entry.tags {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
+
+// expired returns true if the entry is expired at the given time.
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
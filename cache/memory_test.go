@@ -0,0 +1,93 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/cache"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryStore()
+
+	if err := store.Set(ctx, "forever", []byte("a"), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set(ctx, "short", []byte("b"), 10*time.Millisecond); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		wait   time.Duration
+		want   string
+		wantOK bool
+	}{
+		{name: "without ttl", key: "forever", want: "a", wantOK: true},
+		{name: "before expiry", key: "short", want: "b", wantOK: true},
+		{name: "missing", key: "missing"},
+		{name: "after expiry", key: "short", wait: 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			time.Sleep(tt.wait)
+
+			got, ok, err := store.Get(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if ok != tt.wantOK || string(got) != tt.want {
+				t.Errorf("Get() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if err := store.Delete(ctx, "forever"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, "forever"); ok {
+		t.Error("Get() after Delete() found the key")
+	}
+}
+
+func TestMemoryStore_DeleteTags(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemoryStore()
+
+	entries := []struct {
+		key  string
+		tags []string
+	}{
+		{key: "product:1", tags: []string{"products", "product:1"}},
+		{key: "product:2", tags: []string{"products", "product:2"}},
+		{key: "home", tags: []string{"pages"}},
+	}
+	for _, e := range entries {
+		if err := store.Set(ctx, e.key, []byte(e.key), 0, e.tags...); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	if err := store.DeleteTags(ctx, "product:1"); err != nil {
+		t.Fatalf("DeleteTags() error = %v", err)
+	}
+	assertStored(t, store, map[string]bool{"product:1": false, "product:2": true, "home": true})
+
+	if err := store.DeleteTags(ctx, "products"); err != nil {
+		t.Fatalf("DeleteTags() error = %v", err)
+	}
+	assertStored(t, store, map[string]bool{"product:1": false, "product:2": false, "home": true})
+}
+
+func assertStored(t *testing.T, store cache.Store, want map[string]bool) {
+	t.Helper()
+	for key, wantOK := range want {
+		if _, ok, _ := store.Get(context.Background(), key); ok != wantOK {
+			t.Errorf("Get(%q) found = %v, want %v", key, ok, wantOK)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package cache provides the storage for rendered output, such as cached template fragments and pages.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store stores rendered output by key. Implementations must be safe for concurrent use, so that stores backed by
+// e.g. Redis or memcached can be shared by multiple instances of an application.
+type Store interface {
+	// Get returns the value stored under the key, and false if the key is not found or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value under the key, tagged with the tags. A ttl of 0 or less stores the value until it is
+	// deleted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+	// Delete removes the values stored under the keys.
+	Delete(ctx context.Context, keys ...string) error
+	// DeleteTags removes the values tagged with any of the tags.
+	DeleteTags(ctx context.Context, tags ...string) error
+}
@@ -0,0 +1,265 @@
+package hyperview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hypergopher/hyperview/cache"
+	"github.com/hypergopher/hyperview/response"
+)
+
+const (
+	// defaultMaxCachedBodySize is the default maximum size of a cached response body.
+	defaultMaxCachedBodySize = 1 << 20
+	// pageCacheKeyPrefix prefixes the keys of cached pages in the store.
+	pageCacheKeyPrefix = "page:"
+	// pageCachePathTag prefixes the tag of cached pages with their path.
+	pageCachePathTag = "path:"
+)
+
+// CacheHandler is an http middleware that caches complete responses, so that pages are only rendered again when the
+// cached response expires or is invalidated. Responses are cached by method, host, path and query, and the values of
+// the selected request headers (see WithCacheVary).
+//
+// Only successful (200) responses to GET and HEAD requests are cached, and only if the Cache-Control header of the
+// response allows it: responses with a no-store, no-cache, or private directive are never cached, and responses are
+// cached for their s-maxage or max-age, or the default TTL (see WithCacheTTL). Responses that set cookies, vary on
+// all headers, stream events, or exceed the maximum body size are not cached either. Responses are tagged with the
+// tags of their Surrogate-Key header (see Response.CacheTags) for invalidation with InvalidateTags.
+type CacheHandler struct {
+	store       cache.Store
+	ttl         time.Duration
+	vary        []string
+	maxBodySize int
+	metrics     Metrics
+}
+
+// CacheHandlerOption is a function that configures the CacheHandler.
+type CacheHandlerOption func(*CacheHandler)
+
+// WithCacheTTL sets the TTL of responses without an s-maxage or max-age directive. By default, such responses are
+// not cached.
+func WithCacheTTL(ttl time.Duration) CacheHandlerOption {
+	return func(c *CacheHandler) {
+		c.ttl = ttl
+	}
+}
+
+// WithCacheVary sets the request headers whose values are part of the cache key, because the response depends on
+// them (e.g. Accept-Language). Default is HX-Request, as HTMX requests may be rendered without the layout.
+func WithCacheVary(headers ...string) CacheHandlerOption {
+	return func(c *CacheHandler) {
+		c.vary = headers
+	}
+}
+
+// WithCacheMaxBodySize sets the maximum size of a cached response body in bytes. Default is 1 MiB.
+func WithCacheMaxBodySize(size int) CacheHandlerOption {
+	return func(c *CacheHandler) {
+		c.maxBodySize = size
+	}
+}
+
+// WithCacheMetrics sets the metrics that observe the cache lookups (hits and misses) of the handler.
+func WithCacheMetrics(metrics Metrics) CacheHandlerOption {
+	return func(c *CacheHandler) {
+		c.metrics = metrics
+	}
+}
+
+// NewCacheHandler creates a new CacheHandler that stores responses in the store.
+func NewCacheHandler(store cache.Store, opts ...CacheHandlerOption) *CacheHandler {
+	c := &CacheHandler{
+		store:       store,
+		vary:        []string{"HX-Request"},
+		maxBodySize: defaultMaxCachedBodySize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// cachedResponse is a response stored by the CacheHandler.
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// Handler returns the middleware that serves cached responses, and caches the responses of the next handler.
+func (c *CacheHandler) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := c.Key(r)
+		if value, ok, err := c.store.Get(r.Context(), key); err == nil && ok {
+			var cached cachedResponse
+			if err := json.Unmarshal(value, &cached); err == nil {
+				for k, v := range cached.Header {
+					w.Header()[k] = v
+				}
+				c.observe(true)
+				w.Header().Set("X-Cache", "HIT")
+				w.WriteHeader(cached.Status)
+				_, _ = w.Write(cached.Body)
+				return
+			}
+		}
+
+		rec := &cacheRecorder{ResponseWriter: w, status: http.StatusOK, maxBodySize: c.maxBodySize}
+		c.observe(false)
+		w.Header().Set("X-Cache", "MISS")
+		next.ServeHTTP(rec, r)
+
+		ttl, ok := c.cacheTTL(rec)
+		if !ok {
+			return
+		}
+
+		header := rec.header.Clone()
+		header.Del("X-Cache")
+		value, err := json.Marshal(cachedResponse{Status: rec.status, Header: header, Body: rec.body.Bytes()})
+		if err != nil {
+			return
+		}
+
+		tags := append(strings.Fields(header.Get(response.SurrogateKeyHeader)), pageCachePathTag+r.URL.Path)
+		_ = c.store.Set(r.Context(), key, value, ttl, tags...)
+	})
+}
+
+// Key returns the cache key of the request.
+func (c *CacheHandler) Key(r *http.Request) string {
+	var b strings.Builder
+	b.WriteString(pageCacheKeyPrefix)
+	b.WriteString(r.Method)
+	b.WriteString(" ")
+	b.WriteString(r.Host)
+	b.WriteString(r.URL.RequestURI())
+	for _, name := range c.vary {
+		b.WriteString("|")
+		b.WriteString(strings.ToLower(name))
+		b.WriteString("=")
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// Invalidate removes the cached responses with the keys (see Key).
+func (c *CacheHandler) Invalidate(ctx context.Context, keys ...string) error {
+	return c.store.Delete(ctx, keys...)
+}
+
+// InvalidatePath removes the cached responses for the paths (e.g. "/products/42"), for all hosts, queries, and
+// varying headers.
+func (c *CacheHandler) InvalidatePath(ctx context.Context, paths ...string) error {
+	tags := make([]string, len(paths))
+	for i, path := range paths {
+		tags[i] = pageCachePathTag + path
+	}
+	return c.store.DeleteTags(ctx, tags...)
+}
+
+// InvalidateTags removes the cached responses tagged with any of the tags.
+func (c *CacheHandler) InvalidateTags(ctx context.Context, tags ...string) error {
+	return c.store.DeleteTags(ctx, tags...)
+}
+
+// observe reports a cache lookup to the metrics, if any.
+func (c *CacheHandler) observe(hit bool) {
+	if c.metrics != nil {
+		c.metrics.ObserveCache(hit)
+	}
+}
+
+// cacheTTL returns the TTL of the recorded response, and false if it must not be cached.
+func (c *CacheHandler) cacheTTL(rec *cacheRecorder) (time.Duration, bool) {
+	if rec.status != http.StatusOK || rec.overflow || rec.header == nil {
+		return 0, false
+	}
+
+	if rec.header.Get("Set-Cookie") != "" || rec.header.Get("Vary") == "*" ||
+		strings.HasPrefix(rec.header.Get("Content-Type"), "text/event-stream") {
+		return 0, false
+	}
+
+	directives := parseCacheControl(rec.header.Get("Cache-Control"))
+	for _, directive := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[directive]; ok {
+			return 0, false
+		}
+	}
+
+	for _, directive := range []string{"s-maxage", "max-age"} {
+		if value, ok := directives[directive]; ok {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds <= 0 {
+				return 0, false
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	return c.ttl, c.ttl > 0
+}
+
+// parseCacheControl returns the directives of a Cache-Control header with their values, if any.
+func parseCacheControl(header string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return directives
+}
+
+// cacheRecorder writes a response through to the client, and records it for the CacheHandler.
+type cacheRecorder struct {
+	http.ResponseWriter
+	status      int
+	header      http.Header // snapshot of the headers when they were written
+	body        bytes.Buffer
+	maxBodySize int
+	overflow    bool // the body exceeds the maximum size
+}
+
+func (rec *cacheRecorder) WriteHeader(status int) {
+	if rec.header == nil {
+		rec.status = status
+		rec.header = rec.ResponseWriter.Header().Clone()
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *cacheRecorder) Write(p []byte) (int, error) {
+	if rec.header == nil {
+		rec.WriteHeader(http.StatusOK)
+	}
+
+	if !rec.overflow {
+		if rec.body.Len()+len(p) > rec.maxBodySize {
+			rec.overflow = true
+			rec.body.Reset()
+		} else {
+			rec.body.Write(p)
+		}
+	}
+
+	return rec.ResponseWriter.Write(p)
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (rec *cacheRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
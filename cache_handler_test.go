@@ -0,0 +1,143 @@
+package hyperview_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/cache"
+)
+
+// countingHandler responds with the number of times it was called, with the given headers.
+func countingHandler(header http.Header) (http.Handler, *int) {
+	calls := 0
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		_, _ = fmt.Fprintf(w, "call %d", calls)
+	}), &calls
+}
+
+func TestCacheHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []hyperview.CacheHandlerOption
+		header    http.Header
+		method    string
+		wantCalls int
+	}{
+		{name: "max-age", header: http.Header{"Cache-Control": {"public, max-age=60"}}, wantCalls: 1},
+		{name: "s-maxage", header: http.Header{"Cache-Control": {"s-maxage=60"}}, wantCalls: 1},
+		{name: "default ttl", opts: []hyperview.CacheHandlerOption{hyperview.WithCacheTTL(time.Minute)}, wantCalls: 1},
+		{name: "no ttl", wantCalls: 2},
+		{name: "no-store", header: http.Header{"Cache-Control": {"no-store"}}, wantCalls: 2},
+		{name: "private", header: http.Header{"Cache-Control": {"private, max-age=60"}}, wantCalls: 2},
+		{name: "set-cookie", header: http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"session=1"}}, wantCalls: 2},
+		{name: "post", header: http.Header{"Cache-Control": {"max-age=60"}}, method: http.MethodPost, wantCalls: 2},
+		{name: "body too large", opts: []hyperview.CacheHandlerOption{hyperview.WithCacheMaxBodySize(3)}, header: http.Header{"Cache-Control": {"max-age=60"}}, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, calls := countingHandler(tt.header)
+			handler := hyperview.NewCacheHandler(cache.NewMemoryStore(), tt.opts...).Handler(next)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			var bodies []string
+			for range 2 {
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, httptest.NewRequest(method, "/products?page=1", nil))
+				bodies = append(bodies, w.Body.String())
+			}
+
+			if *calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", *calls, tt.wantCalls)
+			}
+			if tt.wantCalls == 1 && bodies[1] != "call 1" {
+				t.Errorf("cached body = %q, want %q", bodies[1], "call 1")
+			}
+		})
+	}
+}
+
+func TestCacheHandler_Vary(t *testing.T) {
+	next, calls := countingHandler(http.Header{"Cache-Control": {"max-age=60"}})
+	handler := hyperview.NewCacheHandler(cache.NewMemoryStore(), hyperview.WithCacheVary("Accept-Language")).Handler(next)
+
+	for _, lang := range []string{"en", "de", "en"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", lang)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestCacheHandler_Invalidate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		invalidate func(c *hyperview.CacheHandler, r *http.Request) error
+	}{
+		{
+			name: "key",
+			invalidate: func(c *hyperview.CacheHandler, r *http.Request) error {
+				return c.Invalidate(ctx, c.Key(r))
+			},
+		},
+		{
+			name: "path",
+			invalidate: func(c *hyperview.CacheHandler, r *http.Request) error {
+				return c.InvalidatePath(ctx, "/products/42")
+			},
+		},
+		{
+			name: "tag",
+			invalidate: func(c *hyperview.CacheHandler, r *http.Request) error {
+				return c.InvalidateTags(ctx, "product:42")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, calls := countingHandler(http.Header{"Cache-Control": {"max-age=60"}, "Surrogate-Key": {"products product:42"}})
+			c := hyperview.NewCacheHandler(cache.NewMemoryStore())
+			handler := c.Handler(next)
+			r := httptest.NewRequest(http.MethodGet, "/products/42", nil)
+
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got := w.Header().Get("X-Cache"); got != "HIT" {
+				t.Errorf("X-Cache = %q, want HIT", got)
+			}
+
+			if err := tt.invalidate(c, r); err != nil {
+				t.Fatalf("invalidate error = %v", err)
+			}
+
+			w = httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if *calls != 2 {
+				t.Errorf("calls = %d, want 2", *calls)
+			}
+			if got := w.Header().Get("X-Cache"); got != "MISS" {
+				t.Errorf("X-Cache = %q, want MISS", got)
+			}
+		})
+	}
+}
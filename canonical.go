@@ -0,0 +1,69 @@
+package hyperview
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/request"
+)
+
+// WithCanonicalURL sets the canonical scheme and host of the site, e.g. "https://example.com". Templates build the
+// canonical URL of the page from it with .View.CanonicalURL, and the canonical host middleware redirects requests for
+// other hosts and schemes to it (see CanonicalHostMiddleware). It returns an error if the URL has no scheme or host.
+func WithCanonicalURL(baseURL string) Option {
+	return func(hgo *HyperView) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid canonical URL %q: %w", baseURL, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid canonical URL %q: missing scheme or host", baseURL)
+		}
+
+		hgo.canonical = &url.URL{Scheme: strings.ToLower(u.Scheme), Host: strings.ToLower(u.Host)}
+		return nil
+	}
+}
+
+// CanonicalHostMiddleware returns a middleware that redirects requests for another host or scheme than the canonical
+// URL (see WithCanonicalURL) to the canonical URL with the same path and query, e.g. http://www.example.com/about to
+// https://example.com/about. GET and HEAD requests are redirected with 301 Moved Permanently, other requests with 308
+// Permanent Redirect, which keeps the method and body. The host and scheme are read with request.SchemeHostPort, so
+// the X-Forwarded headers of a proxy are respected. Without a canonical URL, requests are passed through.
+func (s *HyperView) CanonicalHostMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.canonical == nil || s.isCanonical(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		status := http.StatusPermanentRedirect
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+
+		http.Redirect(w, r, s.canonical.String()+r.URL.RequestURI(), status)
+	})
+}
+
+// isCanonical returns true if the request is for the canonical scheme and host.
+func (s *HyperView) isCanonical(r *http.Request) bool {
+	scheme, host, port := request.SchemeHostPort(r)
+	if !strings.EqualFold(scheme, s.canonical.Scheme) || !strings.EqualFold(host, s.canonical.Hostname()) {
+		return false
+	}
+	return s.canonical.Port() == "" || port == s.canonical.Port()
+}
+
+// withCanonical stores the canonical base URL in the request context, if one is configured.
+func (s *HyperView) withCanonical(r *http.Request) *http.Request {
+	if s.canonical == nil {
+		return r
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), constants.CanonicalURLContextKey, s.canonical.String()))
+}
@@ -0,0 +1,99 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestCanonicalHostMiddleware(t *testing.T) {
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fstest.MapFS{}),
+		hyperview.WithCanonicalURL("https://Example.com"),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	handler := hv.CanonicalHostMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		proto        string
+		wantStatus   int
+		wantLocation string
+	}{
+		{"canonical", "GET", "https://example.com/about", "", http.StatusOK, ""},
+		{"canonical behind proxy", "GET", "http://example.com/about", "https", http.StatusOK, ""},
+		{"www", "GET", "https://www.example.com/about?page=2", "", http.StatusMovedPermanently, "https://example.com/about?page=2"},
+		{"http", "GET", "http://example.com/", "", http.StatusMovedPermanently, "https://example.com/"},
+		{"post", "POST", "http://www.example.com/login", "", http.StatusPermanentRedirect, "https://example.com/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.proto != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestWithCanonicalURL_Invalid(t *testing.T) {
+	for _, baseURL := range []string{"example.com", "/about", "://example.com"} {
+		if _, err := hyperview.NewHyperView(hyperview.WithCanonicalURL(baseURL)); err == nil {
+			t.Errorf("WithCanonicalURL(%q) error = nil, want error", baseURL)
+		}
+	}
+}
+
+func TestData_CanonicalURL(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/about.html":  {Data: []byte(`{{define "page:main"}}{{.View.CanonicalURL}}{{end}}`)},
+	}
+
+	tests := []struct {
+		name string
+		opts []hyperview.Option
+		want string
+	}{
+		{"canonical url", []hyperview.Option{hyperview.WithCanonicalURL("https://example.com")}, "https://example.com/about%20us"},
+		{"request url", nil, "http://www.example.com:8080/about%20us"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append(tt.opts, hyperview.WithTemplateFS(fsys))...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://www.example.com:8080/about%20us?sort=name", nil)
+			hv.Render(w, r, response.NewResponse().Path("about"))
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("CanonicalURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// Package compress compresses responses with Brotli or gzip, negotiated from the Accept-Encoding header of the
+// request. HyperView adapters render into a buffer and write the output at once, so the middleware buffers the start
+// of the body until it reaches the minimum size and only then decides whether to compress it, based on the final
+// status code and headers of the response:
+//
+//	handler := compress.Middleware()(mux)
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content encodings of compressed responses.
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// DefaultMinSize is the default minimum size of compressed response bodies in bytes. Smaller bodies are not worth
+// the overhead of compression.
+const DefaultMinSize = 1024
+
+// DefaultContentTypes are the media types that are compressed by default. Types ending with "/" are prefixes, such
+// as "text/" for all text types. Server-sent events (text/event-stream) are never compressed, so that events are not
+// held back by the compressor.
+var DefaultContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/problem+json",
+	"application/javascript",
+	"application/xml",
+	"application/yaml",
+	"application/x-protobuf",
+	"image/svg+xml",
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	minSize      int
+	contentTypes []string
+	gzipLevel    int
+	brotliLevel  int
+	encodings    []string
+}
+
+// WithMinSize sets the minimum size of compressed response bodies in bytes. Default is DefaultMinSize.
+func WithMinSize(size int) Option {
+	return func(c *config) {
+		c.minSize = size
+	}
+}
+
+// WithContentTypes sets the media types that are compressed, replacing DefaultContentTypes. Types ending with "/"
+// are prefixes.
+func WithContentTypes(types ...string) Option {
+	return func(c *config) {
+		c.contentTypes = types
+	}
+}
+
+// WithGzipLevel sets the gzip compression level (see compress/gzip). Default is gzip.DefaultCompression.
+func WithGzipLevel(level int) Option {
+	return func(c *config) {
+		c.gzipLevel = level
+	}
+}
+
+// WithBrotliLevel sets the Brotli compression level, from 0 to 11. Default is 4, which compresses better than gzip
+// at a similar speed.
+func WithBrotliLevel(level int) Option {
+	return func(c *config) {
+		c.brotliLevel = level
+	}
+}
+
+// WithEncodings sets the supported encodings in the order of preference for clients that accept several encodings
+// with the same quality. Default is EncodingBrotli, then EncodingGzip.
+func WithEncodings(encodings ...string) Option {
+	return func(c *config) {
+		c.encodings = encodings
+	}
+}
+
+// Middleware returns a middleware that compresses responses with the encoding accepted by the request. Responses
+// are not compressed if they are smaller than the minimum size, have a media type that is not allowed, are already
+// encoded, or are partial (206) or bodiless (204, 304, HEAD) responses. Compressed responses get a weak ETag, since
+// they are a different representation than the uncompressed response.
+func Middleware(opts ...Option) func(http.Handler) http.Handler {
+	c := &config{
+		minSize:      DefaultMinSize,
+		contentTypes: DefaultContentTypes,
+		gzipLevel:    gzip.DefaultCompression,
+		brotliLevel:  4,
+		encodings:    []string{EncodingBrotli, EncodingGzip},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	gzipPool := &sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, c.gzipLevel)
+		return w
+	}}
+	brotliPool := &sync.Pool{New: func() any {
+		return brotli.NewWriterLevel(io.Discard, c.brotliLevel)
+	}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cw := &compressWriter{
+				ResponseWriter: w,
+				config:         c,
+				encoding:       Negotiate(r.Header.Get("Accept-Encoding"), c.encodings),
+				head:           r.Method == http.MethodHead,
+				gzipPool:       gzipPool,
+				brotliPool:     brotliPool,
+			}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// compressWriter buffers the start of the response body until it reaches the minimum size, and then writes the
+// response either compressed or as is.
+type compressWriter struct {
+	http.ResponseWriter
+	config     *config
+	encoding   string // negotiated encoding, empty if the request accepts none
+	head       bool
+	status     int
+	buf        []byte
+	decided    bool
+	encoder    io.WriteCloser
+	gzipPool   *sync.Pool
+	brotliPool *sync.Pool
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided || cw.status != 0 {
+		return
+	}
+	// Informational responses are written as is
+	if status >= 100 && status < 200 {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	cw.status = status
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.config.minSize {
+			return len(p), nil
+		}
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush writes the buffered body, so that streamed responses are not held back, and flushes the encoder and the
+// underlying ResponseWriter.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		_ = cw.decide()
+	}
+
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// decide writes the header, compressed if the response qualifies, and the buffered body.
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+	header := cw.Header()
+
+	if header.Get("Content-Type") == "" && len(cw.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+
+	compressible := cw.compressible(header)
+	if compressible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	if compressible && cw.encoding != "" && len(cw.buf) >= cw.config.minSize {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", cw.encoding)
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		switch cw.encoding {
+		case EncodingBrotli:
+			bw := cw.brotliPool.Get().(*brotli.Writer)
+			bw.Reset(cw.ResponseWriter)
+			cw.encoder = &pooledEncoder{WriteCloser: bw, pool: cw.brotliPool}
+		default:
+			gw := cw.gzipPool.Get().(*gzip.Writer)
+			gw.Reset(cw.ResponseWriter)
+			cw.encoder = &pooledEncoder{WriteCloser: gw, pool: cw.gzipPool}
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if cw.encoder != nil {
+		_, err := cw.encoder.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// compressible returns true if the response can be compressed, regardless of the size and the accepted encodings.
+func (cw *compressWriter) compressible(header http.Header) bool {
+	switch cw.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	if cw.head || header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length < cw.config.minSize {
+		return false
+	}
+
+	mediaType, _, _ := strings.Cut(header.Get("Content-Type"), ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" || mediaType == "text/event-stream" {
+		return false
+	}
+	for _, allowed := range cw.config.contentTypes {
+		if mediaType == allowed || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// close writes a body that stayed below the minimum size, and closes the encoder.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 && len(cw.buf) == 0 {
+			// The handler wrote nothing; net/http writes the default response
+			return
+		}
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		_ = cw.decide()
+	}
+
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+	}
+}
+
+// pooledEncoder returns the encoder to its pool when it is closed.
+type pooledEncoder struct {
+	io.WriteCloser
+	pool *sync.Pool
+}
+
+func (e *pooledEncoder) Flush() error {
+	if flusher, ok := e.WriteCloser.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.WriteCloser.Close()
+	e.pool.Put(e.WriteCloser)
+	return err
+}
+
+// Negotiate returns the supported encoding with the highest quality in the Accept-Encoding header, preferring the
+// encodings in the order of the supported encodings, or an empty string if none is accepted, e.g. to select a
+// precompressed file.
+func Negotiate(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		qualities[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range supported {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
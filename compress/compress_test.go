@@ -0,0 +1,216 @@
+package compress_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/hypergopher/hyperview/compress"
+)
+
+func TestMiddleware(t *testing.T) {
+	large := strings.Repeat("<p>Hello, World!</p>", 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		method         string
+		handler        http.HandlerFunc
+		wantEncoding   string
+		wantVary       string
+		wantETag       string
+		wantStatus     int
+	}{
+		{
+			name:           "gzip",
+			acceptEncoding: "gzip, deflate",
+			handler:        writeBody("text/html; charset=utf-8", large),
+			wantEncoding:   "gzip",
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:           "brotli preferred",
+			acceptEncoding: "gzip, deflate, br",
+			handler:        writeBody("text/html; charset=utf-8", large),
+			wantEncoding:   "br",
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:           "quality",
+			acceptEncoding: "br;q=0.5, gzip",
+			handler:        writeBody("application/json", large),
+			wantEncoding:   "gzip",
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:           "refused",
+			acceptEncoding: "br;q=0, gzip;q=0",
+			handler:        writeBody("text/html", large),
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:     "not accepted",
+			handler:  writeBody("text/html", large),
+			wantVary: "Accept-Encoding",
+		},
+		{
+			name:           "below minimum size",
+			acceptEncoding: "gzip",
+			handler:        writeBody("text/html", "<p>small</p>"),
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:           "content type not allowed",
+			acceptEncoding: "gzip",
+			handler:        writeBody("image/png", large),
+		},
+		{
+			name:           "event stream",
+			acceptEncoding: "gzip",
+			handler:        writeBody("text/event-stream", large),
+		},
+		{
+			name:           "sniffed content type",
+			acceptEncoding: "gzip",
+			handler:        writeBody("", "<!DOCTYPE html>"+large),
+			wantEncoding:   "gzip",
+			wantVary:       "Accept-Encoding",
+		},
+		{
+			name:           "weak etag",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"abc"`)
+				writeBody("text/html", large)(w, r)
+			},
+			wantEncoding: "gzip",
+			wantVary:     "Accept-Encoding",
+			wantETag:     `W/"abc"`,
+		},
+		{
+			name:           "already encoded",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "zstd")
+				writeBody("text/html", large)(w, r)
+			},
+			wantEncoding: "zstd",
+		},
+		{
+			name:           "partial content",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Header().Set("Content-Range", "bytes 0-1999/4000")
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = io.WriteString(w, large)
+			},
+			wantStatus: http.StatusPartialContent,
+		},
+		{
+			name:           "no content",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			compress.Middleware()(tt.handler).ServeHTTP(w, r)
+
+			wantStatus := tt.wantStatus
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			if w.Code != wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, wantStatus)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := w.Header().Get("Vary"); got != tt.wantVary {
+				t.Errorf("Vary = %q, want %q", got, tt.wantVary)
+			}
+			if tt.wantETag != "" && w.Header().Get("ETag") != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", w.Header().Get("ETag"), tt.wantETag)
+			}
+
+			if wantStatus == http.StatusNoContent {
+				return
+			}
+			if got := decode(t, w.Header().Get("Content-Encoding"), w.Body); !strings.HasSuffix(got, "<p>Hello, World!</p>") && got != "<p>small</p>" {
+				t.Errorf("body = %q, want the written body", got)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Options(t *testing.T) {
+	handler := compress.Middleware(
+		compress.WithMinSize(10),
+		compress.WithContentTypes("image/png"),
+		compress.WithEncodings(compress.EncodingGzip),
+		compress.WithGzipLevel(gzip.BestSpeed),
+	)(writeBody("image/png", strings.Repeat("x", 20)))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br, gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("Content-Encoding = %q, want gzip", got)
+	}
+	if got := decode(t, "gzip", w.Body); got != strings.Repeat("x", 20) {
+		t.Errorf("body = %q, want the written body", got)
+	}
+}
+
+func writeBody(contentType, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		// Write in chunks, like streamed output
+		for i := 0; i < len(body); i += 100 {
+			_, _ = io.WriteString(w, body[i:min(i+100, len(body))])
+		}
+	}
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	t.Helper()
+
+	var reader io.Reader
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+		reader = gr
+	case "br":
+		reader = brotli.NewReader(body)
+	default:
+		reader = body
+	}
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("error decoding body: %v", err)
+	}
+	return string(b)
+}
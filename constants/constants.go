@@ -3,7 +3,12 @@ package constants
 type ContextKey string
 
 const (
-	NonceContextKey ContextKey = "HyperViewNonce"
+	NonceContextKey        ContextKey = "HyperViewNonce"
+	TenantContextKey       ContextKey = "HyperViewTenant"
+	SessionContextKey      ContextKey = "HyperViewSession"
+	LocaleContextKey       ContextKey = "HyperViewLocale"
+	CanonicalURLContextKey ContextKey = "HyperViewCanonicalURL"
+	LocationContextKey     ContextKey = "HyperViewLocation"
 )
 
 const (
@@ -12,4 +17,6 @@ const (
 	PartialsDir = "partials"
 	LayoutsDir  = "layouts"
 	SystemDir   = "system"
+	AssetsDir   = "assets"
+	IconsDir    = "icons"
 )
@@ -0,0 +1,208 @@
+package hyperview
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hypergopher/hyperview/constants"
+)
+
+// CSP sources of Content-Security-Policy directives.
+const (
+	CSPSelf           = "'self'"
+	CSPNone           = "'none'"
+	CSPUnsafeInline   = "'unsafe-inline'"
+	CSPUnsafeEval     = "'unsafe-eval'"
+	CSPStrictDynamic  = "'strict-dynamic'"
+	CSPReportSample   = "'report-sample'"
+	CSPWasmUnsafeEval = "'wasm-unsafe-eval'"
+
+	// CSPNonce is replaced with the nonce of the request ('nonce-<nonce>') when the policy is written by the nonce
+	// middleware (see NonceMiddleware).
+	CSPNonce = "'nonce'"
+)
+
+// cspDirective is a directive of a Content-Security-Policy with its sources.
+type cspDirective struct {
+	name    string
+	sources []string
+}
+
+// CSP builds a Content-Security-Policy header. Directives are written in the order they are first added:
+//
+//	policy := hyperview.NewCSP().
+//		DefaultSrc(hyperview.CSPSelf).
+//		ScriptSrc(hyperview.CSPSelf, hyperview.CSPNonce).
+//		StyleSrc(hyperview.CSPSelf, hyperview.CSPNonce).
+//		ImgSrc(hyperview.CSPSelf, "data:")
+type CSP struct {
+	directives []cspDirective
+	reportOnly bool
+}
+
+// NewCSP creates a new, empty Content-Security-Policy.
+func NewCSP() *CSP {
+	return &CSP{}
+}
+
+// Directive adds the sources to the directive (e.g. "worker-src"). Sources of a directive that was already added are
+// appended to it, and directives without sources (e.g. "upgrade-insecure-requests") are written without a value.
+func (p *CSP) Directive(name string, sources ...string) *CSP {
+	for i := range p.directives {
+		if p.directives[i].name == name {
+			p.directives[i].sources = append(p.directives[i].sources, sources...)
+			return p
+		}
+	}
+	p.directives = append(p.directives, cspDirective{name: name, sources: sources})
+	return p
+}
+
+// DefaultSrc adds sources to the default-src directive, the fallback of the other fetch directives.
+func (p *CSP) DefaultSrc(sources ...string) *CSP {
+	return p.Directive("default-src", sources...)
+}
+
+// ScriptSrc adds sources to the script-src directive.
+func (p *CSP) ScriptSrc(sources ...string) *CSP {
+	return p.Directive("script-src", sources...)
+}
+
+// StyleSrc adds sources to the style-src directive.
+func (p *CSP) StyleSrc(sources ...string) *CSP {
+	return p.Directive("style-src", sources...)
+}
+
+// ImgSrc adds sources to the img-src directive.
+func (p *CSP) ImgSrc(sources ...string) *CSP {
+	return p.Directive("img-src", sources...)
+}
+
+// FontSrc adds sources to the font-src directive.
+func (p *CSP) FontSrc(sources ...string) *CSP {
+	return p.Directive("font-src", sources...)
+}
+
+// ConnectSrc adds sources to the connect-src directive, which restricts fetch, XHR, WebSocket, and EventSource
+// connections, such as HTMX requests and server-sent events.
+func (p *CSP) ConnectSrc(sources ...string) *CSP {
+	return p.Directive("connect-src", sources...)
+}
+
+// FrameSrc adds sources to the frame-src directive.
+func (p *CSP) FrameSrc(sources ...string) *CSP {
+	return p.Directive("frame-src", sources...)
+}
+
+// ObjectSrc adds sources to the object-src directive.
+func (p *CSP) ObjectSrc(sources ...string) *CSP {
+	return p.Directive("object-src", sources...)
+}
+
+// BaseURI adds sources to the base-uri directive.
+func (p *CSP) BaseURI(sources ...string) *CSP {
+	return p.Directive("base-uri", sources...)
+}
+
+// FormAction adds sources to the form-action directive.
+func (p *CSP) FormAction(sources ...string) *CSP {
+	return p.Directive("form-action", sources...)
+}
+
+// FrameAncestors adds sources to the frame-ancestors directive, which restricts the pages that may embed the page.
+func (p *CSP) FrameAncestors(sources ...string) *CSP {
+	return p.Directive("frame-ancestors", sources...)
+}
+
+// ReportTo sets the report-to directive to the reporting endpoint group.
+func (p *CSP) ReportTo(group string) *CSP {
+	return p.Directive("report-to", group)
+}
+
+// ReportOnly writes the policy as a Content-Security-Policy-Report-Only header, which reports violations without
+// enforcing the policy.
+func (p *CSP) ReportOnly() *CSP {
+	p.reportOnly = true
+	return p
+}
+
+// HeaderName returns the name of the header of the policy: Content-Security-Policy, or
+// Content-Security-Policy-Report-Only for report-only policies.
+func (p *CSP) HeaderName() string {
+	if p.reportOnly {
+		return "Content-Security-Policy-Report-Only"
+	}
+	return "Content-Security-Policy"
+}
+
+// String returns the policy with the CSPNonce sources replaced with the nonce. If the nonce is empty, the CSPNonce
+// sources are omitted.
+func (p *CSP) String(nonce string) string {
+	var b strings.Builder
+	for _, d := range p.directives {
+		if b.Len() > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(d.name)
+		for _, source := range d.sources {
+			if source == CSPNonce {
+				if nonce == "" {
+					continue
+				}
+				source = "'nonce-" + nonce + "'"
+			}
+			b.WriteByte(' ')
+			b.WriteString(source)
+		}
+	}
+	return b.String()
+}
+
+// WithCSP sets the Content-Security-Policy written by the nonce middleware (see NonceMiddleware), with the nonce of
+// each request in place of the CSPNonce sources.
+func WithCSP(policy *CSP) Option {
+	return func(hgo *HyperView) error {
+		hgo.csp = policy
+		return nil
+	}
+}
+
+// NonceMiddleware returns a middleware that generates a random nonce for each request and stores it in the request
+// context, where templates read it with .View.Nonce (e.g. <script nonce="{{.View.Nonce}}">) and .View.HTMXNonce. If a
+// policy is set with WithCSP, the middleware also writes the Content-Security-Policy header with the nonce.
+func (s *HyperView) NonceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce, err := newNonce()
+		if err != nil {
+			s.RenderSystemErrorAs(w, r, requestAdapterKey(r), err)
+			return
+		}
+
+		if s.csp != nil {
+			w.Header().Set(s.csp.HeaderName(), s.csp.String(nonce))
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), constants.NonceContextKey, nonce)))
+	})
+}
+
+// Nonce returns the nonce of the request set by the nonce middleware (see NonceMiddleware), or an empty string if
+// there is none.
+func Nonce(r *http.Request) string {
+	nonce, _ := r.Context().Value(constants.NonceContextKey).(string)
+	return nonce
+}
+
+// newNonce returns a nonce of 16 random bytes, encoded with the URL-safe base64 alphabet so that it needs no escaping
+// in HTML attributes.
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
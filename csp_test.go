@@ -0,0 +1,115 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestCSP_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *hyperview.CSP
+		nonce  string
+		want   string
+	}{
+		{
+			name:   "empty",
+			policy: hyperview.NewCSP(),
+			want:   "",
+		},
+		{
+			name: "directives",
+			policy: hyperview.NewCSP().
+				DefaultSrc(hyperview.CSPSelf).
+				ImgSrc(hyperview.CSPSelf, "data:").
+				FrameAncestors(hyperview.CSPNone),
+			want: "default-src 'self'; img-src 'self' data:; frame-ancestors 'none'",
+		},
+		{
+			name:   "nonce",
+			policy: hyperview.NewCSP().ScriptSrc(hyperview.CSPSelf, hyperview.CSPNonce).StyleSrc(hyperview.CSPNonce),
+			nonce:  "abc",
+			want:   "script-src 'self' 'nonce-abc'; style-src 'nonce-abc'",
+		},
+		{
+			name:   "no nonce",
+			policy: hyperview.NewCSP().ScriptSrc(hyperview.CSPSelf, hyperview.CSPNonce),
+			want:   "script-src 'self'",
+		},
+		{
+			name:   "appended sources and valueless directive",
+			policy: hyperview.NewCSP().ScriptSrc(hyperview.CSPSelf).Directive("upgrade-insecure-requests").ScriptSrc("https://cdn.example.com"),
+			want:   "script-src 'self' https://cdn.example.com; upgrade-insecure-requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.String(tt.nonce); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonceMiddleware(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}<script nonce="{{.View.Nonce}}"></script>{{end}}`)},
+	}
+
+	policy := hyperview.NewCSP().DefaultSrc(hyperview.CSPSelf).ScriptSrc(hyperview.CSPSelf, hyperview.CSPNonce)
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithCSP(policy))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	var nonces []string
+	handler := hv.NonceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, hyperview.Nonce(r))
+		hv.Render(w, r, response.NewResponse().Path("home"))
+	}))
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		nonce := nonces[len(nonces)-1]
+		if nonce == "" {
+			t.Fatal("Nonce() is empty")
+		}
+		if want := "default-src 'self'; script-src 'self' 'nonce-" + nonce + "'"; w.Header().Get("Content-Security-Policy") != want {
+			t.Errorf("Content-Security-Policy = %q, want %q", w.Header().Get("Content-Security-Policy"), want)
+		}
+		if want := `<script nonce="` + nonce + `">`; !strings.Contains(w.Body.String(), want) {
+			t.Errorf("body = %q, want it to contain %q", w.Body.String(), want)
+		}
+	}
+
+	if nonces[0] == nonces[1] {
+		t.Errorf("nonces = %v, want a different nonce per request", nonces)
+	}
+}
+
+func TestNonceMiddleware_ReportOnly(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithCSP(hyperview.NewCSP().DefaultSrc(hyperview.CSPSelf).ReportOnly()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	hv.NonceMiddleware(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if got := w.Header().Get("Content-Security-Policy-Report-Only"); got != "default-src 'self'" {
+		t.Errorf("Content-Security-Policy-Report-Only = %q, want %q", got, "default-src 'self'")
+	}
+	if got := w.Header().Get("Content-Security-Policy"); got != "" {
+		t.Errorf("Content-Security-Policy = %q, want none", got)
+	}
+}
@@ -0,0 +1,138 @@
+package hyperview
+
+import (
+	"fmt"
+	"html/template"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+// DebugInfo is the information served by the debug handler (see DebugHandler).
+type DebugInfo struct {
+	Adapters []DebugAdapter    `json:"adapters"`
+	Tenants  []string          `json:"tenants,omitempty"`
+	Aliases  map[string]string `json:"aliases,omitempty"`
+}
+
+// DebugAdapter describes a registered adapter and, for adapters that list their templates (e.g. the TemplateAdapter),
+// its page templates.
+type DebugAdapter struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Templates []TemplateInfo `json:"templates,omitempty"`
+}
+
+// templateLister is implemented by adapters that list their page templates.
+type templateLister interface {
+	Templates() []TemplateInfo
+}
+
+// debugTemplate renders the debug information as an HTML page.
+var debugTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>HyperView</title></head>
+<body>
+<h1>HyperView</h1>
+{{if .Tenants}}<p>Tenants: {{range $i, $t := .Tenants}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+{{if .Aliases}}
+<h2>Aliases</h2>
+<table>
+<thead><tr><th>Alias</th><th>Path</th></tr></thead>
+<tbody>
+{{range $alias, $path := .Aliases}}<tr><td>{{$alias}}</td><td>{{$path}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+{{range .Adapters}}
+<h2>{{.Name}} <small>{{.Type}}</small></h2>
+{{if .Templates}}
+<table>
+<thead><tr><th>Name</th><th>Filesystem</th><th>Path</th><th>Layouts</th><th>Templates</th></tr></thead>
+<tbody>
+{{range .Templates}}<tr>
+<td>{{.Name}}</td>
+<td>{{.FileSystem}}</td>
+<td>{{.Path}}</td>
+<td>{{range $i, $l := .Layouts}}{{if $i}}, {{end}}{{$l}}{{end}}</td>
+<td>{{if .Compiled}}{{range $i, $t := .Templates}}{{if $i}}, {{end}}{{$t}}{{end}}{{else}}<em>not compiled</em>{{end}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// WithDebugHandler enables the debug handler (see DebugHandler). The handler exposes the names and paths of all
+// templates, so it should only be enabled in development or served behind authentication.
+func WithDebugHandler() Option {
+	return func(hgo *HyperView) error {
+		hgo.debug = true
+		return nil
+	}
+}
+
+// DebugHandler returns an http.Handler that lists the registered adapters, tenants, and template aliases, and the
+// page templates of each template adapter with their filesystem, layouts, and defined templates. The list is served as
+// HTML, or as JSON for requests that prefer application/json or have a format=json query parameter.
+//
+// The handler responds with 404 Not Found unless the debug handler is enabled with WithDebugHandler.
+func (s *HyperView) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.debug {
+			http.NotFound(w, r)
+			return
+		}
+
+		info := s.debugInfo()
+
+		if r.URL.Query().Get("format") == "json" || request.PreferredMediaType(r) == "application/json" {
+			if err := JSONWithHeaders(w, http.StatusOK, info); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		if err := debugTemplate.Execute(buf, info); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = buf.WriteTo(w)
+	})
+}
+
+// debugInfo collects the debug information of the registered adapters and tenants.
+func (s *HyperView) debugInfo() DebugInfo {
+	adapters := s.adapterMap()
+
+	info := DebugInfo{
+		Adapters: make([]DebugAdapter, 0, len(adapters)),
+		Aliases:  s.TemplateAliases(),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(adapters)) {
+		adapter := DebugAdapter{
+			Name: name,
+			Type: fmt.Sprintf("%T", adapters[name]),
+		}
+		if lister, ok := adapters[name].(templateLister); ok {
+			adapter.Templates = lister.Templates()
+		}
+		info.Adapters = append(info.Adapters, adapter)
+	}
+
+	s.mu.Lock()
+	info.Tenants = slices.Sorted(maps.Keys(s.tenants))
+	s.mu.Unlock()
+
+	return info
+}
@@ -0,0 +1,107 @@
+package hyperview_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
+)
+
+func TestDebugHandler(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":  {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"partials/name.html": {Data: []byte(`{{define "@name"}}<b>{{.}}</b>{{end}}`)},
+		"views/home.html":    {Data: []byte(`{{define "page:main"}}Hello {{template "@name" .Name}}{{end}}`)},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+		if err != nil {
+			t.Fatalf("NewHyperView() error = %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		hv.DebugHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithDebugHandler())
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	if err := hv.RegisterTenantFS("acme", fstest.MapFS{
+		"views/home.html": {Data: []byte(`{{define "page:main"}}Acme{{end}}`)},
+	}); err != nil {
+		t.Fatalf("RegisterTenantFS() error = %v", err)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		hv.DebugHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug?format=json", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+
+		var info hyperview.DebugInfo
+		if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+			t.Fatalf("error decoding debug info: %v", err)
+		}
+
+		if len(info.Tenants) != 1 || info.Tenants[0] != "acme" {
+			t.Errorf("Tenants = %v, want [acme]", info.Tenants)
+		}
+
+		var html *hyperview.DebugAdapter
+		for i, adapter := range info.Adapters {
+			if adapter.Name == "html" {
+				html = &info.Adapters[i]
+			}
+		}
+		if html == nil {
+			t.Fatal("html adapter not listed")
+		}
+		if html.Type != "*hyperview.TemplateAdapter" {
+			t.Errorf("Type = %q, want *hyperview.TemplateAdapter", html.Type)
+		}
+
+		want := []hyperview.TemplateInfo{
+			{Name: "acme:views/home", FileSystem: "acme", Path: "views/home.html"},
+			{Name: "views/home", FileSystem: constants.RootFSID, Path: "views/home.html"},
+		}
+		if len(html.Templates) != len(want) {
+			t.Fatalf("Templates = %+v, want %d templates", html.Templates, len(want))
+		}
+		for i, tmpl := range html.Templates {
+			if tmpl.Name != want[i].Name || tmpl.FileSystem != want[i].FileSystem || tmpl.Path != want[i].Path {
+				t.Errorf("Templates[%d] = %s (%s, %s), want %s (%s, %s)", i, tmpl.Name, tmpl.FileSystem, tmpl.Path, want[i].Name, want[i].FileSystem, want[i].Path)
+			}
+			if !tmpl.Compiled || !slices.Contains(tmpl.Templates, "@name") || !slices.Contains(tmpl.Templates, "page:main") {
+				t.Errorf("Templates[%d].Templates = %v, want the page and its partials", i, tmpl.Templates)
+			}
+			if !slices.Contains(tmpl.Layouts, "base") {
+				t.Errorf("Templates[%d].Layouts = %v, want base", i, tmpl.Layouts)
+			}
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		hv.DebugHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug", nil))
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("Content-Type = %q, want text/html", ct)
+		}
+		for _, want := range []string{"<td>acme:views/home</td>", "*hyperview.TemplateAdapter", "Tenants: acme"} {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("body does not contain %q", want)
+			}
+		}
+	})
+}
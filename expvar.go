@@ -0,0 +1,102 @@
+package hyperview
+
+import (
+	"expvar"
+	"slices"
+	"sync"
+	"time"
+)
+
+// expvarName is the name of the expvar map with the runtime stats of HyperView.
+const expvarName = "hyperview"
+
+// expvarStats are the runtime stats published via expvar by the HyperView instances with WithExpvar. The stats are
+// published once, so they are shared by all instances.
+var expvarStats struct {
+	once         sync.Once
+	mu           sync.Mutex
+	instances    []*HyperView   // instances whose templates are counted
+	renders      *expvar.Int    // renders of all instances
+	renderErrors *expvar.Int    // failed renders of all instances
+	adapters     *expvar.Map    // renders by adapter
+	reinits      *expvar.Int    // successful reinitializations of all instances
+	lastReinit   *expvar.String // time of the last successful reinitialization (RFC 3339)
+}
+
+// WithExpvar publishes runtime stats via expvar under the "hyperview" name, which are served as JSON by the
+// expvar handler (/debug/vars): the number of renders (in total, failed, and by adapter), the number of page templates,
+// and the number and time of the last Reinit. The stats of all instances with WithExpvar are combined.
+func WithExpvar() Option {
+	return func(hgo *HyperView) error {
+		hgo.expvar = true
+		return nil
+	}
+}
+
+// publishExpvar publishes the expvar stats, if they are not published yet, and adds the instance to the stats.
+func publishExpvar(s *HyperView) {
+	stats := &expvarStats
+	stats.once.Do(func() {
+		stats.renders = new(expvar.Int)
+		stats.renderErrors = new(expvar.Int)
+		stats.adapters = new(expvar.Map).Init()
+		stats.reinits = new(expvar.Int)
+		stats.lastReinit = new(expvar.String)
+
+		m := expvar.NewMap(expvarName)
+		m.Set("renders", stats.renders)
+		m.Set("render_errors", stats.renderErrors)
+		m.Set("renders_by_adapter", stats.adapters)
+		m.Set("templates", expvar.Func(countExpvarTemplates))
+		m.Set("reinits", stats.reinits)
+		m.Set("last_reinit", stats.lastReinit)
+	})
+
+	stats.mu.Lock()
+	stats.instances = append(stats.instances, s)
+	stats.mu.Unlock()
+}
+
+// unpublishExpvar removes the instance from the expvar stats. The stats themselves stay published, as expvar does not
+// support removing them.
+func unpublishExpvar(s *HyperView) {
+	stats := &expvarStats
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.instances = slices.DeleteFunc(stats.instances, func(instance *HyperView) bool {
+		return instance == s
+	})
+}
+
+// countExpvarTemplates returns the number of page templates of the template adapters of all instances.
+func countExpvarTemplates() any {
+	stats := &expvarStats
+	stats.mu.Lock()
+	instances := slices.Clone(stats.instances)
+	stats.mu.Unlock()
+
+	count := 0
+	for _, s := range instances {
+		for _, adapter := range s.adapterMap() {
+			if lister, ok := adapter.(templateLister); ok {
+				count += len(lister.Templates())
+			}
+		}
+	}
+	return count
+}
+
+// expvarRender counts a render in the expvar stats.
+func expvarRender(adapter string, err error) {
+	expvarStats.renders.Add(1)
+	expvarStats.adapters.Add(adapter, 1)
+	if err != nil {
+		expvarStats.renderErrors.Add(1)
+	}
+}
+
+// expvarReinit records a successful reinitialization in the expvar stats.
+func expvarReinit(t time.Time) {
+	expvarStats.reinits.Add(1)
+	expvarStats.lastReinit.Set(t.Format(time.RFC3339))
+}
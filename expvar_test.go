@@ -0,0 +1,86 @@
+package hyperview_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+type expvarStats struct {
+	Renders          int            `json:"renders"`
+	RenderErrors     int            `json:"render_errors"`
+	RendersByAdapter map[string]int `json:"renders_by_adapter"`
+	Templates        int            `json:"templates"`
+	Reinits          int            `json:"reinits"`
+	LastReinit       string         `json:"last_reinit"`
+}
+
+func readExpvarStats(t *testing.T) expvarStats {
+	t.Helper()
+	v := expvar.Get("hyperview")
+	if v == nil {
+		t.Fatal("hyperview expvar is not published")
+	}
+
+	var stats expvarStats
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatalf("error decoding expvar stats: %v", err)
+	}
+	return stats
+}
+
+func TestWithExpvar(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello{{end}}`)},
+		"views/about.html":  {Data: []byte(`{{define "page:main"}}About{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithExpvar())
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	before := readExpvarStats(t)
+
+	hv.Render(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("views/home"))
+	hv.Render(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("views/missing"))
+	hv.RenderAs(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), "json", response.NewResponse().Data(map[string]any{"ok": true}))
+	if err := hv.Reinit(); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+
+	after := readExpvarStats(t)
+
+	if got := after.Renders - before.Renders; got != 3 {
+		t.Errorf("renders = +%d, want +3", got)
+	}
+	if got := after.RenderErrors - before.RenderErrors; got != 1 {
+		t.Errorf("render_errors = +%d, want +1", got)
+	}
+	if got := after.RendersByAdapter["html"] - before.RendersByAdapter["html"]; got != 2 {
+		t.Errorf("renders_by_adapter[html] = +%d, want +2", got)
+	}
+	if got := after.Reinits - before.Reinits; got != 1 {
+		t.Errorf("reinits = +%d, want +1", got)
+	}
+	if after.LastReinit == "" {
+		t.Error("last_reinit is empty")
+	}
+	if after.Templates < 2 {
+		t.Errorf("templates = %d, want at least 2", after.Templates)
+	}
+
+	// Closed instances are no longer counted
+	if err := hv.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := readExpvarStats(t).Templates; got != after.Templates-2 {
+		t.Errorf("templates after Close() = %d, want %d", got, after.Templates-2)
+	}
+}
@@ -0,0 +1,162 @@
+package hyperview
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// formFlashKey is the flash key of the submitted values and errors of a failed form submission.
+const formFlashKey = "hyperview_form"
+
+// flashCookieMaxAge is the lifetime of flash cookies in seconds. Flash values are read by the next request, so they
+// only have to outlive the redirect.
+const flashCookieMaxAge = 300
+
+// FlashStore stores values for the next request of the client, e.g. in the session or in a cookie.
+type FlashStore interface {
+	// SetFlash stores the value under the key for the next request.
+	SetFlash(w http.ResponseWriter, r *http.Request, key string, value []byte) error
+	// PopFlash returns the value stored under the key and removes it, or nil if there is none.
+	PopFlash(w http.ResponseWriter, r *http.Request, key string) ([]byte, error)
+}
+
+// CookieFlashStore is a FlashStore that stores flash values in short-lived cookies. It is the default flash store.
+// The cookies are not signed, so flash values must not be trusted; they are rendered back to the same client only.
+type CookieFlashStore struct {
+	// Secure restricts the cookies to HTTPS requests.
+	Secure bool
+}
+
+// SetFlash stores the value in a cookie named after the key.
+func (c CookieFlashStore) SetFlash(w http.ResponseWriter, _ *http.Request, key string, value []byte) error {
+	cookie := c.cookie(key)
+	cookie.Value = base64.RawURLEncoding.EncodeToString(value)
+	cookie.MaxAge = flashCookieMaxAge
+	if err := cookie.Valid(); err != nil {
+		return fmt.Errorf("invalid flash cookie: %w", err)
+	}
+
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+// PopFlash returns the value of the cookie named after the key, and deletes the cookie.
+func (c CookieFlashStore) PopFlash(w http.ResponseWriter, r *http.Request, key string) ([]byte, error) {
+	stored, err := r.Cookie(key)
+	if err != nil {
+		return nil, nil
+	}
+
+	cookie := c.cookie(key)
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+
+	value, err := base64.RawURLEncoding.DecodeString(stored.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flash cookie: %w", err)
+	}
+	return value, nil
+}
+
+// cookie returns the flash cookie for the key, without a value.
+func (c CookieFlashStore) cookie(key string) *http.Cookie {
+	return &http.Cookie{
+		Name:     key,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   c.Secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// WithFlashStore sets the store of the values passed to the next request, such as the submitted values and errors of
+// RedirectWithErrors. Default is a CookieFlashStore.
+func WithFlashStore(store FlashStore) Option {
+	return func(hgo *HyperView) error {
+		hgo.flash = store
+		return nil
+	}
+}
+
+// flashStore returns the configured flash store, or the default CookieFlashStore.
+func (s *HyperView) flashStore() FlashStore {
+	if s.flash != nil {
+		return s.flash
+	}
+	return CookieFlashStore{}
+}
+
+// formFlash is a failed form submission, stored for the render after the redirect.
+type formFlash struct {
+	Input  map[string]string `json:"input,omitempty"`
+	Error  string            `json:"error,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// RedirectWithErrors implements the Post/Redirect/Get pattern for failed form submissions: it stores the submitted
+// form values and the errors in the flash store and redirects to the url of the form (see Redirect). The next GET
+// render re-hydrates them, so the form can show the errors with .Error and .Errors and the submitted values with
+// .View.Old. Fields with "password" in their name are not stored.
+//
+// The submitted values are read from r.PostForm, which is parsed if needed; multipart forms must be parsed by the
+// handler.
+func (s *HyperView) RedirectWithErrors(w http.ResponseWriter, r *http.Request, url, msg string, fieldErrors map[string]string) {
+	if r.PostForm == nil {
+		_ = r.ParseForm()
+	}
+
+	flash := formFlash{Input: make(map[string]string), Error: msg, Errors: fieldErrors}
+	for field, values := range r.PostForm {
+		if len(values) == 0 || strings.Contains(strings.ToLower(field), "password") {
+			continue
+		}
+		flash.Input[field] = values[0]
+	}
+
+	value, err := json.Marshal(flash)
+	if err == nil {
+		err = s.flashStore().SetFlash(w, r, formFlashKey, value)
+	}
+	if err != nil {
+		s.logger.Error("Error storing form flash", slog.String("err", err.Error()))
+	}
+
+	s.Redirect(w, r, url)
+}
+
+// withFormFlash re-hydrates the submitted values and errors stored by RedirectWithErrors into the response of a GET
+// request, unless the response has errors of its own.
+func (s *HyperView) withFormFlash(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	if r.Method != http.MethodGet {
+		return
+	}
+
+	value, err := s.flashStore().PopFlash(w, r, formFlashKey)
+	if err != nil {
+		s.logger.Error("Error reading form flash", slog.String("err", err.Error()))
+		return
+	}
+	if value == nil {
+		return
+	}
+
+	var flash formFlash
+	if err := json.Unmarshal(value, &flash); err != nil {
+		s.logger.Error("Error decoding form flash", slog.String("err", err.Error()))
+		return
+	}
+
+	data := resp.ViewData(r)
+	if !data.HasError() && !data.HasErrors() {
+		data.AddErrors(flash.Error, flash.Errors)
+	}
+	if len(data.OldInput()) == 0 && len(flash.Input) > 0 {
+		resp.OldInput(flash.Input)
+	}
+}
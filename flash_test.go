@@ -0,0 +1,71 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestRedirectWithErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/signup.html": {Data: []byte(`{{define "page:main"}}{{.Error}}|{{.Errors.email}}|{{.View.Old "email"}}|{{.View.Old "password"}}{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base"))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	form := url.Values{"email": {"gopher@example"}, "password": {"secret"}}
+	r := httptest.NewRequest("POST", "/signup", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	hv.RedirectWithErrors(w, r, "/signup", "Please fix the errors", map[string]string{"email": "Invalid email"})
+
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/signup" {
+		t.Fatalf("redirect = %d %q, want 302 to /signup", w.Code, w.Header().Get("Location"))
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("cookies = %v, want a flash cookie", cookies)
+	}
+
+	tests := []struct {
+		name     string
+		cookies  []*http.Cookie
+		wantBody string
+	}{
+		{"after redirect", cookies, "Please fix the errors|Invalid email|gopher@example|"},
+		{"without flash", nil, "|||"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/signup", nil)
+			for _, cookie := range tt.cookies {
+				r.AddCookie(cookie)
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path("signup"))
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			if len(tt.cookies) > 0 {
+				if deleted := w.Result().Cookies(); len(deleted) != 1 || deleted[0].MaxAge >= 0 {
+					t.Errorf("cookies = %v, want the flash cookie deleted", deleted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Package forms builds the fields of a form from the fields of a struct, as the data of the form control functions of
+// the funcs package (InputAttrs, SelectAttrs, and the like), so that a whole form can be rendered with one loop.
+//
+// The fields are configured with struct tags:
+//
+//	type signupForm struct {
+//		Email    string `form:"email" label:"Email address" validate:"required,email"`
+//		Password string `form:"password" control:"password" hint:"At least 8 characters" validate:"required,min=8"`
+//		Plan     string `form:"plan" control:"radio" options:"free,pro"`
+//		Country  string `form:"country" control:"select"`
+//		Bio      string `form:"bio" control:"textarea" placeholder:"Tell us about yourself"`
+//		Terms    bool   `form:"terms" label:"I accept the terms"`
+//	}
+//
+// The tags are:
+//
+//   - form: the name and ID of the field, as for binding the submitted form (see the bind package), or "-" to skip
+//     the field. Defaults to the name of the struct field.
+//   - label: the label. Defaults to the humanized name of the struct field (see funcs.Humanize).
+//   - hint and placeholder: the hint and placeholder of the control, and accept: the accepted types of file inputs.
+//   - control: "select", "radio", "textarea", "checkbox", "file", or the type of an input, e.g. "password". Defaults
+//     to a checkbox for bools, a number input for numbers, a date input for times, a file input for uploaded files,
+//     and a text input otherwise, with the type "email" or "url" for fields validated as such.
+//   - options: the comma-separated options of selects and radio groups, e.g. "free,pro". Options can also be set
+//     with WithOptions, or come from a oneof validation rule.
+//   - validate: the validation rules of go-playground/validator, which are converted into the HTML attributes
+//     required, minlength, maxlength, min, max, and pattern where possible.
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// Controls of fields.
+const (
+	ControlInput    = "input"
+	ControlSelect   = "select"
+	ControlTextarea = "textarea"
+	ControlCheckbox = "checkbox"
+	ControlRadio    = "radio"
+	ControlFile     = "file"
+)
+
+// Field is a field of a form.
+type Field struct {
+	Name    string         // name and ID of the field
+	Control string         // control of the field, e.g. ControlInput
+	Attrs   map[string]any // data of the control, as returned by the funcs of the control, e.g. funcs.InputAttrs
+}
+
+// Option configures Build.
+type Option func(*config)
+
+type config struct {
+	errors  map[string]string
+	options map[string]any
+}
+
+// WithErrors sets the errors of the fields by name, e.g. the field errors of the view data (see response.Data.Errors).
+func WithErrors(errors map[string]string) Option {
+	return func(c *config) {
+		c.errors = errors
+	}
+}
+
+// WithOptions sets the options of the select or radio group with the name, as a slice of strings, a map of values to
+// labels, or a slice of funcs.SelectOption (see funcs.SelectAttrs), e.g. for options loaded from a database.
+func WithOptions(name string, options any) Option {
+	return func(c *config) {
+		c.options[name] = options
+	}
+}
+
+// ErrInvalidModel is returned if the model is not a struct or a pointer to a struct.
+var ErrInvalidModel = errors.New("forms: model must be a struct or a pointer to a struct")
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	fileHeaderType  = reflect.TypeFor[*multipart.FileHeader]()
+	fileHeadersType = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// Build returns the fields of the exported fields of the model, a struct or a pointer to a struct, with the values of
+// the model as their values. The fields are rendered by a template with a partial for each control:
+//
+//	{{range .Form}}
+//		{{if eq .Control "select"}}{{template "partial:select" .Attrs}}
+//		{{else if eq .Control "radio"}}{{template "partial:radio" .Attrs}}
+//		{{else if eq .Control "textarea"}}{{template "partial:textarea" .Attrs}}
+//		{{else if eq .Control "checkbox"}}{{template "partial:checkbox" .Attrs}}
+//		{{else}}{{template "partial:input" .Attrs}}{{end}}
+//	{{end}}
+func Build(model any, opts ...Option) ([]Field, error) {
+	cfg := config{options: make(map[string]any)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, ErrInvalidModel
+	}
+
+	return cfg.fields(v)
+}
+
+// fields returns the fields of the struct, including the fields of embedded structs.
+func (c *config) fields(v reflect.Value) ([]Field, error) {
+	var fields []Field
+
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+
+		if sf.Anonymous && tag == "" && sf.Type.Kind() == reflect.Struct {
+			embedded, err := c.fields(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		field, err := c.field(sf, v.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// field returns the field of the struct field with its value.
+func (c *config) field(sf reflect.StructField, value reflect.Value) (Field, error) {
+	name, _, _ := strings.Cut(sf.Tag.Get("form"), ",")
+	if name == "" {
+		name = sf.Name
+	}
+
+	label, ok := sf.Tag.Lookup("label")
+	if !ok {
+		label = funcs.Humanize(sf.Name)
+	}
+
+	rules := parseRules(sf.Tag.Get("validate"))
+	control, inputType := controlOf(sf, rules)
+
+	attrs := []any{"label", label, "error", c.errors[name]}
+	if hint := sf.Tag.Get("hint"); hint != "" {
+		attrs = append(attrs, "hint", hint)
+	}
+	if placeholder := sf.Tag.Get("placeholder"); placeholder != "" {
+		attrs = append(attrs, "placeholder", placeholder)
+	}
+	attrs = append(attrs, ruleAttrs(rules, sf.Type, control)...)
+
+	var data map[string]any
+	var err error
+	switch control {
+	case ControlSelect, ControlRadio:
+		options, ok := c.options[name]
+		if !ok {
+			options = staticOptions(sf, rules)
+		}
+		attrs = append(attrs, "selected", selectedValue(value))
+		if control == ControlSelect {
+			if value.Kind() == reflect.Slice {
+				attrs = append(attrs, "multiple", "true")
+			}
+			data, err = funcs.SelectAttrs(name, options, attrs...)
+		} else {
+			data, err = funcs.RadioGroupAttrs(name, options, attrs...)
+		}
+	case ControlTextarea:
+		data, err = funcs.TextareaAttrs(name, append(attrs, "value", formatValue(value))...)
+	case ControlCheckbox:
+		data, err = funcs.CheckboxAttrs(name, append(attrs, "checked", value.Kind() == reflect.Bool && value.Bool())...)
+	case ControlFile:
+		if sf.Type == fileHeadersType {
+			attrs = append(attrs, "multiple", "true")
+		}
+		if accept := sf.Tag.Get("accept"); accept != "" {
+			attrs = append(attrs, "accept", accept)
+		}
+		data, err = funcs.FileAttrs(name, attrs...)
+	default:
+		data, err = funcs.InputAttrs(name, append(attrs, "type", inputType, "value", formatValue(value))...)
+	}
+	if err != nil {
+		return Field{}, fmt.Errorf("forms: field %s: %w", sf.Name, err)
+	}
+
+	return Field{Name: name, Control: control, Attrs: data}, nil
+}
+
+// controlOf returns the control of the struct field and the type of inputs.
+func controlOf(sf reflect.StructField, rules map[string]string) (control, inputType string) {
+	switch tag := sf.Tag.Get("control"); tag {
+	case ControlSelect, ControlRadio, ControlTextarea, ControlCheckbox, ControlFile:
+		return tag, ""
+	case "":
+	default:
+		return ControlInput, tag
+	}
+
+	if _, ok := sf.Tag.Lookup("options"); ok {
+		return ControlSelect, ""
+	}
+	if _, ok := rules["oneof"]; ok {
+		return ControlSelect, ""
+	}
+
+	t := sf.Type
+	if t.Kind() == reflect.Pointer && t != fileHeaderType {
+		t = t.Elem()
+	}
+	switch {
+	case t == fileHeaderType || t == fileHeadersType:
+		return ControlFile, ""
+	case t == timeType:
+		return ControlInput, "date"
+	case t.Kind() == reflect.Bool:
+		return ControlCheckbox, ""
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+		return ControlInput, "number"
+	}
+
+	if _, ok := rules["email"]; ok {
+		return ControlInput, "email"
+	}
+	if _, ok := rules["url"]; ok {
+		return ControlInput, "url"
+	}
+	return ControlInput, "text"
+}
+
+// parseRules returns the parameters of the validation rules by name, e.g. {"required": "", "min": "8"}.
+func parseRules(validate string) map[string]string {
+	rules := make(map[string]string)
+	for _, rule := range strings.Split(validate, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name != "" {
+			rules[name] = param
+		}
+	}
+	return rules
+}
+
+// ruleAttrs returns the HTML attributes of the validation rules, with minlength and maxlength for strings and min
+// and max for numbers.
+func ruleAttrs(rules map[string]string, t reflect.Type, control string) []any {
+	var attrs []any
+	if _, ok := rules["required"]; ok && control != ControlCheckbox {
+		attrs = append(attrs, "required", "required")
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	numeric := t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64
+
+	for _, rule := range []string{"min", "max", "gte", "lte", "len"} {
+		param, ok := rules[rule]
+		if !ok || param == "" {
+			continue
+		}
+		switch {
+		case numeric && (rule == "min" || rule == "gte"):
+			attrs = append(attrs, "min", param)
+		case numeric && (rule == "max" || rule == "lte"):
+			attrs = append(attrs, "max", param)
+		case t.Kind() == reflect.String && rule == "min":
+			attrs = append(attrs, "minlength", param)
+		case t.Kind() == reflect.String && rule == "max":
+			attrs = append(attrs, "maxlength", param)
+		case t.Kind() == reflect.String && rule == "len":
+			attrs = append(attrs, "minlength", param, "maxlength", param)
+		}
+	}
+
+	if _, ok := rules["numeric"]; ok && t.Kind() == reflect.String {
+		attrs = append(attrs, "pattern", "[0-9]*", "inputmode", "numeric")
+	}
+
+	return attrs
+}
+
+// staticOptions returns the options of the options tag or of the oneof rule.
+func staticOptions(sf reflect.StructField, rules map[string]string) []string {
+	if options, ok := sf.Tag.Lookup("options"); ok {
+		return strings.Split(options, ",")
+	}
+	if oneof, ok := rules["oneof"]; ok {
+		return strings.Fields(oneof)
+	}
+	return nil
+}
+
+// selectedValue returns the selected value of a select or radio group, or the selected values of a slice.
+func selectedValue(v reflect.Value) any {
+	if v.Kind() == reflect.Slice {
+		values := make([]string, v.Len())
+		for i := range v.Len() {
+			values[i] = formatValue(v.Index(i))
+		}
+		return values
+	}
+	return formatValue(v)
+}
+
+// formatValue returns the value of a control for the value of a field.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.DateOnly)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package forms_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/hypergopher/hyperview/forms"
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+type Timestamps struct {
+	Updated time.Time `form:"updated" control:"hidden"`
+}
+
+type signupForm struct {
+	Timestamps
+	Email     string                `form:"email" label:"Email address" validate:"required,email"`
+	Password  string                `form:"password" control:"password" hint:"At least 8 characters" validate:"required,min=8,max=64"`
+	FirstName string                `validate:"max=50" placeholder:"Ada"`
+	Age       int                   `form:"age" validate:"gte=18,lte=130"`
+	Birthday  time.Time             `form:"birthday"`
+	Plan      string                `form:"plan" control:"radio" options:"free,pro"`
+	Size      string                `form:"size" validate:"oneof=s m l"`
+	Country   string                `form:"country" control:"select"`
+	Languages []string              `form:"languages" control:"select" options:"de,en,fr"`
+	Bio       string                `form:"bio" control:"textarea"`
+	Terms     bool                  `form:"terms" label:"I accept the terms" validate:"required"`
+	Avatar    *multipart.FileHeader `form:"avatar" accept:"image/*"`
+	Internal  string                `form:"-"`
+	secret    string
+}
+
+func TestBuild(t *testing.T) {
+	model := &signupForm{
+		Timestamps: Timestamps{Updated: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+		Email:      "ada@example.com",
+		Age:        36,
+		Plan:       "pro",
+		Country:    "uk",
+		Languages:  []string{"en", "fr"},
+		Bio:        "Mathematician",
+		Terms:      true,
+	}
+
+	fields, err := forms.Build(model,
+		forms.WithErrors(map[string]string{"email": "Email address is taken"}),
+		forms.WithOptions("country", map[string]string{"uk": "United Kingdom", "de": "Germany"}),
+	)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var names, controls []string
+	byName := make(map[string]forms.Field)
+	for _, field := range fields {
+		names = append(names, field.Name)
+		controls = append(controls, field.Control)
+		byName[field.Name] = field
+	}
+
+	wantNames := []string{"updated", "email", "password", "FirstName", "age", "birthday", "plan", "size", "country", "languages", "bio", "terms", "avatar"}
+	wantControls := []string{"input", "input", "input", "input", "input", "input", "radio", "select", "select", "select", "textarea", "checkbox", "file"}
+	if !reflect.DeepEqual(names, wantNames) || !reflect.DeepEqual(controls, wantControls) {
+		t.Fatalf("Build() names = %v, controls = %v, want %v, %v", names, controls, wantNames, wantControls)
+	}
+
+	tests := []struct {
+		field string
+		key   string
+		want  any
+	}{
+		{"updated", "Type", "hidden"},
+		{"updated", "Attributes", map[string]string{"value": "2025-12-31"}},
+		{"email", "Type", "email"},
+		{"email", "Label", "Email address"},
+		{"email", "Error", "Email address is taken"},
+		{"email", "Attributes", map[string]string{"required": "required", "value": "ada@example.com"}},
+		{"password", "Type", "password"},
+		{"password", "Hint", "At least 8 characters"},
+		{"password", "Attributes", map[string]string{"required": "required", "minlength": "8", "maxlength": "64", "value": ""}},
+		{"FirstName", "Label", "First name"},
+		{"FirstName", "Attributes", map[string]string{"maxlength": "50", "placeholder": "Ada", "value": ""}},
+		{"age", "Type", "number"},
+		{"age", "Attributes", map[string]string{"min": "18", "max": "130", "value": "36"}},
+		{"birthday", "Type", "date"},
+		{"plan", "Options", []funcs.SelectOption{{Value: "free", Label: "free"}, {Value: "pro", Label: "pro", Selected: true}}},
+		{"size", "Options", []funcs.SelectOption{{Value: "s", Label: "s"}, {Value: "m", Label: "m"}, {Value: "l", Label: "l"}}},
+		{"country", "Options", []funcs.SelectOption{{Value: "de", Label: "Germany"}, {Value: "uk", Label: "United Kingdom", Selected: true}}},
+		{"languages", "Multiple", true},
+		{"languages", "Options", []funcs.SelectOption{{Value: "de", Label: "de"}, {Value: "en", Label: "en", Selected: true}, {Value: "fr", Label: "fr", Selected: true}}},
+		{"bio", "Value", "Mathematician"},
+		{"terms", "Checked", true},
+		{"terms", "Attributes", map[string]string{}},
+		{"avatar", "Accept", "image/*"},
+		{"avatar", "Multiple", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"/"+tt.key, func(t *testing.T) {
+			if got := byName[tt.field].Attrs[tt.key]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Attrs[%q] = %#v, want %#v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuild_Template(t *testing.T) {
+	fields, err := forms.Build(struct {
+		Name  string `form:"name" validate:"required"`
+		Color string `form:"color" options:"red,blue"`
+	}{Color: "blue"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tmpl := template.Must(template.New("form").Parse(
+		`{{define "partial:input"}}<input name="{{.NameID}}" type="{{.Type}}">{{end}}` +
+			`{{define "partial:select"}}<select name="{{.NameID}}">{{range .Options}}<option{{if .Selected}} selected{{end}}>{{.Value}}</option>{{end}}</select>{{end}}` +
+			`{{range .}}{{if eq .Control "select"}}{{template "partial:select" .Attrs}}{{else}}{{template "partial:input" .Attrs}}{{end}}{{end}}`,
+	))
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, fields); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := `<input name="name" type="text"><select name="color"><option>red</option><option selected>blue</option></select>`
+	if got := sb.String(); got != want {
+		t.Errorf("form = %q, want %q", got, want)
+	}
+}
+
+func TestBuild_InvalidModel(t *testing.T) {
+	for _, model := range []any{nil, "text", (*signupForm)(nil)} {
+		if _, err := forms.Build(model); !errors.Is(err, forms.ErrInvalidModel) {
+			t.Errorf("Build(%T) error = %v, want ErrInvalidModel", model, err)
+		}
+	}
+}
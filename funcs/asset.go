@@ -0,0 +1,257 @@
+package funcs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"maps"
+	"path"
+	"strings"
+	"sync"
+)
+
+// fingerprintLen is the number of hex characters of the content hash in fingerprinted asset names.
+const fingerprintLen = 8
+
+// ErrAssetNotFound is returned by AssetPaths.Path for names that are not files of the asset filesystem.
+var ErrAssetNotFound = errors.New("asset not found")
+
+// AssetPaths returns the URLs of the files of an asset filesystem with a hash of their content in the name, e.g.
+// /assets/css/app.3f2a9c1b.css for css/app.css, so that browsers can cache them forever and still get new versions
+// as soon as their content changes. The hashes are computed once per file and cached until Reset.
+//
+// Resolve maps the fingerprinted names back to the files, for the handler that serves them (see
+// hyperview.HyperView.Mount). AssetPaths is safe for concurrent use.
+type AssetPaths struct {
+	fsys     fs.FS
+	prefix   string
+	mu       sync.RWMutex
+	hashes   map[string]string // content hashes by file name
+	manifest map[string]string // built files by logical name
+	built    map[string]bool   // built files of the manifest
+}
+
+// NewAssetPaths returns the asset paths of the files of the filesystem, served under the URL prefix, e.g. "/assets/"
+// or the URL of a CDN.
+func NewAssetPaths(fsys fs.FS, prefix string) *AssetPaths {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &AssetPaths{fsys: fsys, prefix: prefix, hashes: make(map[string]string)}
+}
+
+// Path returns the fingerprinted URL of the asset, e.g. for the template function assetPath:
+//
+//	<link rel="stylesheet" href="{{assetPath "css/app.css"}}">
+//	// => <link rel="stylesheet" href="/assets/css/app.3f2a9c1b.css">
+//
+// It returns ErrAssetNotFound if the asset is not a file of the filesystem.
+func (a *AssetPaths) Path(name string) (string, error) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+
+	a.mu.RLock()
+	file, ok := a.manifest[name]
+	a.mu.RUnlock()
+	if ok {
+		return a.prefix + file, nil
+	}
+
+	hash, err := a.hash(name)
+	if err != nil {
+		return "", err
+	}
+	return a.prefix + fingerprinted(name, hash), nil
+}
+
+// SetManifest sets the manifest of built assets, e.g. of an asset pipeline, which maps logical names to the names of
+// their built files, e.g. "js/app.js" to "js/app-3F2A9C1B.js". Path returns the URL of the built file for the names of
+// the manifest, without another fingerprint, and the content hash of other files as before. A nil manifest removes
+// the manifest.
+func (a *AssetPaths) SetManifest(manifest map[string]string) {
+	built := make(map[string]bool, len(manifest))
+	for _, file := range manifest {
+		built[strings.TrimPrefix(path.Clean("/"+file), "/")] = true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.manifest = maps.Clone(manifest)
+	a.built = built
+}
+
+// IsBuilt returns true if the file is a built file of the manifest (see SetManifest).
+func (a *AssetPaths) IsBuilt(file string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.built[file]
+}
+
+// Resolve returns the file name of a fingerprinted name, e.g. css/app.css for css/app.3f2a9c1b.css, and whether the
+// fingerprint matches the current content of the file. It returns an empty name if the name is not fingerprinted or
+// its file does not exist. Names with an outdated fingerprint, e.g. of pages rendered before a deploy, resolve to the
+// current file, which must not be cached as immutable.
+func (a *AssetPaths) Resolve(name string) (file string, current bool) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	fingerprint := path.Ext(stem)
+	file = strings.TrimSuffix(stem, fingerprint) + ext
+	if !isFingerprint(fingerprint) {
+		// Files without an extension have the fingerprint as their extension
+		fingerprint, file = ext, stem
+		if !isFingerprint(fingerprint) {
+			return "", false
+		}
+	}
+
+	hash, err := a.hash(file)
+	if err != nil {
+		return "", false
+	}
+	return file, hash == fingerprint[1:]
+}
+
+// Reset clears the cached hashes, e.g. after the assets changed in development. The manifest is kept.
+func (a *AssetPaths) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	clear(a.hashes)
+}
+
+// hash returns the cached content hash of the file, computing it on first use.
+func (a *AssetPaths) hash(name string) (string, error) {
+	a.mu.RLock()
+	hash, ok := a.hashes[name]
+	a.mu.RUnlock()
+	if ok {
+		return hash, nil
+	}
+
+	if a.fsys == nil {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	content, err := fs.ReadFile(a.fsys, name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	sum := sha256.Sum256(content)
+	hash = hex.EncodeToString(sum[:])[:fingerprintLen]
+
+	a.mu.Lock()
+	a.hashes[name] = hash
+	a.mu.Unlock()
+	return hash, nil
+}
+
+// AssetInliner renders the content of small assets of an asset filesystem, such as critical CSS, inline in style and
+// script elements with the CSP nonce of the request, which saves a request for each of them. The contents are read
+// once per file and cached until Reset. AssetInliner is safe for concurrent use.
+type AssetInliner struct {
+	fsys     fs.FS
+	mu       sync.RWMutex
+	contents map[string]string // contents by file name
+}
+
+// NewAssetInliner returns an inliner of the files of the filesystem.
+func NewAssetInliner(fsys fs.FS) *AssetInliner {
+	return &AssetInliner{fsys: fsys, contents: make(map[string]string)}
+}
+
+// CSS returns a style element with the content of the asset and the nonce, given as a noncer such as the view data
+// or as a string, e.g. for the template function inlineCSS:
+//
+//	{{inlineCSS .View "css/critical.css"}}
+//	// => <style nonce="r4nd0m">body{margin:0}</style>
+//
+// It returns ErrAssetNotFound if the asset is not a file of the filesystem.
+func (a *AssetInliner) CSS(nonce any, name string) (template.HTML, error) {
+	return a.inline("style", nonce, name)
+}
+
+// JS returns a script element with the content of the asset and the nonce, like CSS, e.g. for the template function
+// inlineJS:
+//
+//	{{inlineJS .View "js/theme.js"}}
+//	// => <script nonce="r4nd0m">document.documentElement.dataset.theme = localStorage.theme</script>
+func (a *AssetInliner) JS(nonce any, name string) (template.HTML, error) {
+	return a.inline("script", nonce, name)
+}
+
+// Reset clears the cached contents, e.g. after the assets changed in development.
+func (a *AssetInliner) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	clear(a.contents)
+}
+
+// inline returns the element with the content of the asset and the nonce.
+func (a *AssetInliner) inline(element string, nonce any, name string) (template.HTML, error) {
+	value, err := nonceValue(nonce)
+	if err != nil {
+		return "", fmt.Errorf("inline %s: %w", element, err)
+	}
+
+	content, err := a.content(strings.TrimPrefix(path.Clean("/"+name), "/"))
+	if err != nil {
+		return "", err
+	}
+	// The content is not escaped, so it must not end the element early
+	if strings.Contains(strings.ToLower(content), "</"+element) {
+		return "", fmt.Errorf("asset %s contains </%s and can't be inlined", name, element)
+	}
+
+	attrs := ""
+	if value != "" {
+		attrs = ` nonce="` + html.EscapeString(value) + `"`
+	}
+	return template.HTML("<" + element + attrs + ">" + content + "</" + element + ">"), nil
+}
+
+// content returns the cached content of the file, reading it on first use.
+func (a *AssetInliner) content(name string) (string, error) {
+	a.mu.RLock()
+	content, ok := a.contents[name]
+	a.mu.RUnlock()
+	if ok {
+		return content, nil
+	}
+
+	if a.fsys == nil {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	data, err := fs.ReadFile(a.fsys, name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	content = string(data)
+
+	a.mu.Lock()
+	a.contents[name] = content
+	a.mu.Unlock()
+	return content, nil
+}
+
+// fingerprinted returns the name with the hash before its extension, e.g. css/app.3f2a9c1b.css.
+func fingerprinted(name, hash string) string {
+	ext := path.Ext(name)
+	if ext == "" {
+		return name + "." + hash
+	}
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// isFingerprint returns true if the extension is a dot followed by a content hash.
+func isFingerprint(ext string) bool {
+	if len(ext) != fingerprintLen+1 {
+		return false
+	}
+	for _, c := range ext[1:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
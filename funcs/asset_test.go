@@ -0,0 +1,175 @@
+package funcs_test
+
+import (
+	"errors"
+	"html/template"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func assetTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"css/app.css": {Data: []byte(`body { color: red; }`)},
+		"robots":      {Data: []byte(`body { color: red; }`)},
+	}
+}
+
+func TestAssetPaths_Path(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		asset   string
+		want    string
+		wantErr bool
+	}{
+		{"fingerprinted", "/assets/", "css/app.css", "/assets/css/app.5de625c3.css", false},
+		{"leading slash", "/assets/", "/css/app.css", "/assets/css/app.5de625c3.css", false},
+		{"prefix without slash", "https://cdn.example.com/assets", "css/app.css", "https://cdn.example.com/assets/css/app.5de625c3.css", false},
+		{"no extension", "/assets/", "robots", "/assets/robots.5de625c3", false},
+		{"missing", "/assets/", "css/missing.css", "", true},
+		{"directory", "/assets/", "css", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.NewAssetPaths(assetTestFS(), tt.prefix).Path(tt.asset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Path() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, funcs.ErrAssetNotFound) {
+				t.Errorf("Path() error = %v, want ErrAssetNotFound", err)
+			}
+			if got != tt.want {
+				t.Errorf("Path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssetPaths_Resolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		asset       string
+		wantFile    string
+		wantCurrent bool
+	}{
+		{"current", "css/app.5de625c3.css", "css/app.css", true},
+		{"outdated", "css/app.0123abcd.css", "css/app.css", false},
+		{"no extension", "robots.5de625c3", "robots", true},
+		{"not fingerprinted", "css/app.css", "", false},
+		{"not a hash", "css/app.min.css", "", false},
+		{"missing file", "css/other.5de625c3.css", "", false},
+	}
+
+	assets := funcs.NewAssetPaths(assetTestFS(), "/assets/")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, current := assets.Resolve(tt.asset)
+			if file != tt.wantFile || current != tt.wantCurrent {
+				t.Errorf("Resolve() = %q, %v, want %q, %v", file, current, tt.wantFile, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestAssetPaths_Reset(t *testing.T) {
+	fsys := assetTestFS()
+	assets := funcs.NewAssetPaths(fsys, "/assets/")
+	before, _ := assets.Path("css/app.css")
+
+	fsys["css/app.css"] = &fstest.MapFile{Data: []byte(`body { color: blue; }`)}
+	if cached, _ := assets.Path("css/app.css"); cached != before {
+		t.Errorf("Path() = %q, want the cached %q", cached, before)
+	}
+
+	assets.Reset()
+	if after, _ := assets.Path("css/app.css"); after == before {
+		t.Errorf("Path() after Reset() = %q, want a new fingerprint", after)
+	}
+}
+
+type nonceView string
+
+func (v nonceView) Nonce() string { return string(v) }
+
+func TestAssetInliner(t *testing.T) {
+	fsys := fstest.MapFS{
+		"css/critical.css": {Data: []byte(`body{margin:0}`)},
+		"js/theme.js":      {Data: []byte(`document.body.dataset.theme = "dark"`)},
+		"js/unsafe.js":     {Data: []byte(`document.write("</SCRIPT>")`)},
+	}
+
+	tests := []struct {
+		name    string
+		inline  func(a *funcs.AssetInliner) (template.HTML, error)
+		want    template.HTML
+		wantErr bool
+	}{
+		{
+			name:   "css with nonce string",
+			inline: func(a *funcs.AssetInliner) (template.HTML, error) { return a.CSS("abc", "css/critical.css") },
+			want:   `<style nonce="abc">body{margin:0}</style>`,
+		},
+		{
+			name:   "js with noncer",
+			inline: func(a *funcs.AssetInliner) (template.HTML, error) { return a.JS(nonceView(`a"b`), "/js/theme.js") },
+			want:   `<script nonce="a&#34;b">document.body.dataset.theme = "dark"</script>`,
+		},
+		{
+			name:   "without nonce",
+			inline: func(a *funcs.AssetInliner) (template.HTML, error) { return a.CSS("", "css/critical.css") },
+			want:   `<style>body{margin:0}</style>`,
+		},
+		{
+			name:    "missing",
+			inline:  func(a *funcs.AssetInliner) (template.HTML, error) { return a.JS("abc", "js/missing.js") },
+			wantErr: true,
+		},
+		{
+			name:    "closing tag",
+			inline:  func(a *funcs.AssetInliner) (template.HTML, error) { return a.JS("abc", "js/unsafe.js") },
+			wantErr: true,
+		},
+		{
+			name:    "invalid nonce",
+			inline:  func(a *funcs.AssetInliner) (template.HTML, error) { return a.CSS(42, "css/critical.css") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.inline(funcs.NewAssetInliner(fsys))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("inline error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("inline = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssetPaths_Manifest(t *testing.T) {
+	assets := funcs.NewAssetPaths(assetTestFS(), "/assets/")
+	manifest := map[string]string{"js/app.js": "dist/app-3F2A9C1B.js"}
+	assets.SetManifest(manifest)
+	manifest["js/app.js"] = "changed.js"
+
+	if got, err := assets.Path("js/app.js"); err != nil || got != "/assets/dist/app-3F2A9C1B.js" {
+		t.Errorf("Path() = %q, %v, want the built file", got, err)
+	}
+	if got, _ := assets.Path("css/app.css"); got != "/assets/css/app.5de625c3.css" {
+		t.Errorf("Path() = %q, want the fingerprinted file", got)
+	}
+	if !assets.IsBuilt("dist/app-3F2A9C1B.js") || assets.IsBuilt("css/app.css") {
+		t.Error("IsBuilt() is wrong for the built and other files")
+	}
+
+	assets.SetManifest(nil)
+	if _, err := assets.Path("js/app.js"); !errors.Is(err, funcs.ErrAssetNotFound) {
+		t.Errorf("Path() error = %v, want ErrAssetNotFound without a manifest", err)
+	}
+}
@@ -1,5 +1,7 @@
 package funcs
 
+import "reflect"
+
 func YesNo(b bool) string {
 	if b {
 		return "Yes"
@@ -7,3 +9,56 @@ func YesNo(b bool) string {
 
 	return "No"
 }
+
+// Default returns the value, or the fallback if the value is empty (see IsEmpty). The fallback comes first so that the
+// value can be piped:
+//
+//	{{.Name | default "Anonymous"}}
+func Default(fallback, value any) any {
+	if IsEmpty(value) {
+		return fallback
+	}
+	return value
+}
+
+// Coalesce returns the first of the values that is not empty (see IsEmpty), or nil if all are empty:
+//
+//	{{coalesce .Nickname .Name "Anonymous"}}
+func Coalesce(values ...any) any {
+	for _, value := range values {
+		if !IsEmpty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// Ternary returns ifTrue if the condition is true, and ifFalse otherwise. The condition comes last so that it can be
+// piped:
+//
+//	{{.Active | ternary "on" "off"}}
+func Ternary(ifTrue, ifFalse any, condition bool) any {
+	if condition {
+		return ifTrue
+	}
+	return ifFalse
+}
+
+// IsEmpty returns true if the value is nil, false, zero, an empty string, slice, or map, or a nil pointer, like the
+// values for which an if action of a template is false.
+func IsEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface, reflect.Func:
+		return v.IsNil()
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,80 @@
+package funcs_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestIsEmpty(t *testing.T) {
+	var nilMap map[string]any
+	var nilPtr *int
+	zero := 0
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"nil", nil, true},
+		{"false", false, true},
+		{"true", true, false},
+		{"zero", 0, true},
+		{"number", 1.5, false},
+		{"empty string", "", true},
+		{"string", "a", false},
+		{"empty slice", []string{}, true},
+		{"slice", []int{0}, false},
+		{"nil map", nilMap, true},
+		{"nil pointer", nilPtr, true},
+		{"pointer to zero", &zero, false},
+		{"zero struct", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := funcs.IsEmpty(tt.value); got != tt.want {
+				t.Errorf("IsEmpty(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultCoalesceTernary(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		data map[string]any
+		want string
+	}{
+		{"default with value", `{{.Name | default "Anonymous"}}`, map[string]any{"Name": "Ada"}, "Ada"},
+		{"default with empty value", `{{.Name | default "Anonymous"}}`, map[string]any{"Name": ""}, "Anonymous"},
+		{"default with missing value", `{{.Name | default "Anonymous"}}`, map[string]any{}, "Anonymous"},
+		{"default with zero", `{{.Count | default 10}}`, map[string]any{"Count": 0}, "10"},
+		{"coalesce", `{{coalesce .Nickname .Name "Anonymous"}}`, map[string]any{"Nickname": "", "Name": "Ada"}, "Ada"},
+		{"coalesce fallback", `{{coalesce .Nickname .Name "Anonymous"}}`, map[string]any{}, "Anonymous"},
+		{"coalesce all empty", `{{coalesce .Nickname ""}}`, map[string]any{}, "<no value>"},
+		{"ternary true", `{{.Active | ternary "on" "off"}}`, map[string]any{"Active": true}, "on"},
+		{"ternary false", `{{ternary "on" "off" .Active}}`, map[string]any{"Active": false}, "off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(funcs.Base()).Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var sb strings.Builder
+			if err := tmpl.Execute(&sb, tt.data); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package funcs
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// TitleCase capitalizes the first letter of each word and lowercases the other letters with the Unicode casing rules,
+// e.g. "Élan Vital" for "élan VITAL". Unlike the deprecated strings.Title, it lowercases the other letters, handles
+// special cases such as the Greek final sigma, and doesn't capitalize letters after apostrophes.
+func TitleCase(s string) string {
+	return cases.Title(language.Und).String(s)
+}
+
+// CamelCase converts a string to camel case, e.g. "userId" for "user_id", "User ID", or "UserID".
+func CamelCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = capitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase converts a string to Pascal case, e.g. "UserId" for "user_id" or "user id".
+func PascalCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts a string to snake case, e.g. "user_id" for "UserID", "userId", or "User ID".
+func SnakeCase(s string) string {
+	return joinLower(splitWords(s), "_")
+}
+
+// KebabCase converts a string to kebab case, e.g. "user-id" for "UserID", "userId", or "User ID".
+func KebabCase(s string) string {
+	return joinLower(splitWords(s), "-")
+}
+
+// splitWords splits a string into words at characters other than letters and digits, and at changes from lower to
+// upper case, e.g. "HTTP", "Server", "Id" for "HTTPServer_id". Acronyms are kept as one word.
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// A new word starts at an upper case letter after a lower case letter or digit ("userId"), or at the
+			// last upper case letter of an acronym followed by a lower case letter ("HTTPServer")
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// capitalize returns the word with its first letter in upper case and the other letters in lower case.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToTitle(r)) + strings.ToLower(word[size:])
+}
+
+// joinLower returns the words in lower case, joined by the separator.
+func joinLower(words []string, sep string) string {
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, sep)
+}
@@ -0,0 +1,66 @@
+package funcs_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"hello world", "Hello World"},
+		{"élan VITAL", "Élan Vital"},
+		{"o'neil's book", "O'neil's Book"},
+		{"ΟΔΟΣ ΑΘΗΝΑΣ", "Οδος Αθηνας"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := funcs.TitleCase(tt.s); got != tt.want {
+				t.Errorf("TitleCase(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		s      string
+		camel  string
+		pascal string
+		snake  string
+		kebab  string
+	}{
+		{"user_id", "userId", "UserId", "user_id", "user-id"},
+		{"User ID", "userId", "UserId", "user_id", "user-id"},
+		{"UserID", "userId", "UserId", "user_id", "user-id"},
+		{"userId", "userId", "UserId", "user_id", "user-id"},
+		{"HTTPServer", "httpServer", "HttpServer", "http_server", "http-server"},
+		{"first-name", "firstName", "FirstName", "first_name", "first-name"},
+		{"  leading and trailing  ", "leadingAndTrailing", "LeadingAndTrailing", "leading_and_trailing", "leading-and-trailing"},
+		{"version2Beta", "version2Beta", "Version2Beta", "version2_beta", "version2-beta"},
+		{"größe_änderung", "größeÄnderung", "GrößeÄnderung", "größe_änderung", "größe-änderung"},
+		{"", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := funcs.CamelCase(tt.s); got != tt.camel {
+				t.Errorf("CamelCase(%q) = %q, want %q", tt.s, got, tt.camel)
+			}
+			if got := funcs.PascalCase(tt.s); got != tt.pascal {
+				t.Errorf("PascalCase(%q) = %q, want %q", tt.s, got, tt.pascal)
+			}
+			if got := funcs.SnakeCase(tt.s); got != tt.snake {
+				t.Errorf("SnakeCase(%q) = %q, want %q", tt.s, got, tt.snake)
+			}
+			if got := funcs.KebabCase(tt.s); got != tt.kebab {
+				t.Errorf("KebabCase(%q) = %q, want %q", tt.s, got, tt.kebab)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package funcs
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
 
 func attrsToMap(data map[string]any, specialAttrs map[string]string, attrs ...any) (map[string]any, error) {
 	attributes := make(map[string]string)
@@ -82,3 +86,248 @@ func InputAttrs(nameID string, attrs ...any) (map[string]any, error) {
 
 	return data, nil
 }
+
+// SelectOption is an option of a select or radio group (see SelectAttrs and RadioGroupAttrs).
+type SelectOption struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// SelectAttrs prepares the data for rendering a select field in a separate template, like InputAttrs.
+// options are the options of the select as a slice of strings (used as value and label), a map of values to labels
+// (sorted by label), or a slice of SelectOption.
+// Use a "selected" attribute to set the selected value, or a slice of strings for the selected values of a select
+// with a "multiple" attribute set to "true".
+// Use a "label", "hint", and "error" attribute like for InputAttrs, and a "placeholder" attribute to add an empty
+// first option with the placeholder as its label.
+func SelectAttrs(nameID string, options any, attrs ...any) (map[string]any, error) {
+	if len(attrs)%2 != 0 {
+		return nil, fmt.Errorf("SelectAttrs expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	selected, attrs := extractAttr(attrs, "selected")
+	opts, err := selectOptions(options, selectedValues(selected))
+	if err != nil {
+		return nil, fmt.Errorf("[SelectAttrs] %w", err)
+	}
+
+	data := map[string]any{
+		"NameID":      nameID,
+		"Error":       "",
+		"Hint":        "",
+		"Label":       "",
+		"Multiple":    "",
+		"Placeholder": "",
+		"Options":     opts,
+	}
+
+	specialAttrs := map[string]string{
+		"error":       "Error",
+		"hint":        "Hint",
+		"label":       "Label",
+		"multiple":    "Multiple",
+		"placeholder": "Placeholder",
+	}
+
+	data, err = attrsToMap(data, specialAttrs, attrs...)
+	if err != nil {
+		return nil, err
+	}
+	data["Multiple"] = data["Multiple"] == "true"
+
+	return data, nil
+}
+
+// TextareaAttrs prepares the data for rendering a textarea field in a separate template, like InputAttrs.
+// Use a "value" attribute to set the text of the textarea, and a "rows" attribute to set the number of rows
+// (default: "3").
+// Use a "label", "hint", and "error" attribute like for InputAttrs.
+func TextareaAttrs(nameID string, attrs ...any) (map[string]any, error) {
+	if len(attrs)%2 != 0 {
+		return nil, fmt.Errorf("TextareaAttrs expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	data := map[string]any{
+		"NameID": nameID,
+		"Error":  "",
+		"Hint":   "",
+		"Label":  "",
+		"Rows":   "3",
+		"Value":  "",
+	}
+
+	specialAttrs := map[string]string{
+		"error": "Error",
+		"hint":  "Hint",
+		"label": "Label",
+		"rows":  "Rows",
+		"value": "Value",
+	}
+
+	return attrsToMap(data, specialAttrs, attrs...)
+}
+
+// CheckboxAttrs prepares the data for rendering a checkbox in a separate template, like InputAttrs.
+// Use a "checked" attribute set to true or "true" to check the checkbox, and a "value" attribute to set the
+// submitted value (default: "on").
+// Use a "label", "hint", and "error" attribute like for InputAttrs.
+func CheckboxAttrs(nameID string, attrs ...any) (map[string]any, error) {
+	if len(attrs)%2 != 0 {
+		return nil, fmt.Errorf("CheckboxAttrs expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	checked, attrs := extractAttr(attrs, "checked")
+
+	data := map[string]any{
+		"NameID": nameID,
+		"Error":  "",
+		"Hint":   "",
+		"Label":  "",
+		"Value":  "on",
+	}
+
+	specialAttrs := map[string]string{
+		"error": "Error",
+		"hint":  "Hint",
+		"label": "Label",
+		"value": "Value",
+	}
+
+	data, err := attrsToMap(data, specialAttrs, attrs...)
+	if err != nil {
+		return nil, err
+	}
+	data["Checked"] = checked == true || checked == "true"
+
+	return data, nil
+}
+
+// RadioGroupAttrs prepares the data for rendering a group of radio buttons in a separate template, like InputAttrs.
+// options are the options of the group like for SelectAttrs.
+// Use a "selected" attribute to set the checked value.
+// Use a "label", "hint", and "error" attribute like for InputAttrs, where the label is the legend of the group.
+func RadioGroupAttrs(nameID string, options any, attrs ...any) (map[string]any, error) {
+	if len(attrs)%2 != 0 {
+		return nil, fmt.Errorf("RadioGroupAttrs expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	selected, attrs := extractAttr(attrs, "selected")
+	opts, err := selectOptions(options, selectedValues(selected))
+	if err != nil {
+		return nil, fmt.Errorf("[RadioGroupAttrs] %w", err)
+	}
+
+	data := map[string]any{
+		"NameID":  nameID,
+		"Error":   "",
+		"Hint":    "",
+		"Label":   "",
+		"Options": opts,
+	}
+
+	specialAttrs := map[string]string{
+		"error": "Error",
+		"hint":  "Hint",
+		"label": "Label",
+	}
+
+	return attrsToMap(data, specialAttrs, attrs...)
+}
+
+// FileAttrs prepares the data for rendering a file input in a separate template, like InputAttrs.
+// Use an "accept" attribute to set the accepted file types, e.g. "image/*,.pdf", and a "multiple" attribute set to
+// "true" to accept more than one file.
+// Use a "label", "hint", and "error" attribute like for InputAttrs.
+// The form must be sent with enctype="multipart/form-data".
+func FileAttrs(nameID string, attrs ...any) (map[string]any, error) {
+	if len(attrs)%2 != 0 {
+		return nil, fmt.Errorf("FileAttrs expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	data := map[string]any{
+		"NameID":   nameID,
+		"Accept":   "",
+		"Error":    "",
+		"Hint":     "",
+		"Label":    "",
+		"Multiple": "",
+	}
+
+	specialAttrs := map[string]string{
+		"accept":   "Accept",
+		"error":    "Error",
+		"hint":     "Hint",
+		"label":    "Label",
+		"multiple": "Multiple",
+	}
+
+	data, err := attrsToMap(data, specialAttrs, attrs...)
+	if err != nil {
+		return nil, err
+	}
+	data["Multiple"] = data["Multiple"] == "true"
+
+	return data, nil
+}
+
+// extractAttr removes the attribute with the key from the key/value pairs and returns its value, for attributes that
+// are not strings.
+func extractAttr(attrs []any, key string) (any, []any) {
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if k, ok := attrs[i].(string); ok && k == key {
+			value := attrs[i+1]
+			rest := append(append([]any{}, attrs[:i]...), attrs[i+2:]...)
+			return value, rest
+		}
+	}
+	return nil, attrs
+}
+
+// selectedValues returns the selected values of a "selected" attribute, given as a string or a slice of strings.
+func selectedValues(selected any) map[string]bool {
+	values := make(map[string]bool)
+	switch v := selected.(type) {
+	case string:
+		values[v] = true
+	case []string:
+		for _, s := range v {
+			values[s] = true
+		}
+	case []any:
+		for _, s := range v {
+			values[fmt.Sprint(s)] = true
+		}
+	}
+	return values
+}
+
+// selectOptions returns the options of a select or radio group, with the selected values selected.
+func selectOptions(options any, selected map[string]bool) ([]SelectOption, error) {
+	var opts []SelectOption
+	switch v := options.(type) {
+	case nil:
+	case []SelectOption:
+		opts = slices.Clone(v)
+	case []string:
+		for _, s := range v {
+			opts = append(opts, SelectOption{Value: s, Label: s})
+		}
+	case map[string]string:
+		for value, label := range v {
+			opts = append(opts, SelectOption{Value: value, Label: label})
+		}
+		slices.SortFunc(opts, func(a, b SelectOption) int {
+			return cmp.Or(cmp.Compare(a.Label, b.Label), cmp.Compare(a.Value, b.Value))
+		})
+	default:
+		return nil, fmt.Errorf("unsupported options type %T", options)
+	}
+
+	if len(selected) > 0 {
+		for i := range opts {
+			opts[i].Selected = selected[opts[i].Value]
+		}
+	}
+	return opts, nil
+}
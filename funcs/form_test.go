@@ -0,0 +1,134 @@
+package funcs_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestSelectAttrs(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      any
+		attrs        []any
+		wantOptions  []funcs.SelectOption
+		wantMultiple bool
+	}{
+		{
+			name:    "strings",
+			options: []string{"S", "M", "L"},
+			attrs:   []any{"selected", "M", "label", "Size"},
+			wantOptions: []funcs.SelectOption{
+				{Value: "S", Label: "S"}, {Value: "M", Label: "M", Selected: true}, {Value: "L", Label: "L"},
+			},
+		},
+		{
+			name:    "map sorted by label",
+			options: map[string]string{"de": "German", "en": "English", "fr": "French"},
+			attrs:   []any{"selected", []string{"de", "fr"}, "multiple", "true"},
+			wantOptions: []funcs.SelectOption{
+				{Value: "en", Label: "English"}, {Value: "fr", Label: "French", Selected: true}, {Value: "de", Label: "German", Selected: true},
+			},
+			wantMultiple: true,
+		},
+		{
+			name:        "select options",
+			options:     []funcs.SelectOption{{Value: "1", Label: "One", Selected: true}, {Value: "2", Label: "Two"}},
+			wantOptions: []funcs.SelectOption{{Value: "1", Label: "One", Selected: true}, {Value: "2", Label: "Two"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := funcs.SelectAttrs("field", tt.options, append(tt.attrs, "class", "select", "data-x", "y")...)
+			if err != nil {
+				t.Fatalf("SelectAttrs() error = %v", err)
+			}
+			if got := data["Options"]; !reflect.DeepEqual(got, tt.wantOptions) {
+				t.Errorf("Options = %+v, want %+v", got, tt.wantOptions)
+			}
+			if got := data["Multiple"]; got != tt.wantMultiple {
+				t.Errorf("Multiple = %v, want %v", got, tt.wantMultiple)
+			}
+			if data["NameID"] != "field" || data["Class"] != "select" {
+				t.Errorf("NameID, Class = %v, %v, want field, select", data["NameID"], data["Class"])
+			}
+			if got := data["Attributes"]; !reflect.DeepEqual(got, map[string]string{"data-x": "y"}) {
+				t.Errorf("Attributes = %v, want data-x", got)
+			}
+		})
+	}
+
+	if _, err := funcs.SelectAttrs("field", 42); err == nil {
+		t.Error("SelectAttrs() with unsupported options error = nil, want error")
+	}
+	if _, err := funcs.SelectAttrs("field", nil, "label"); err == nil {
+		t.Error("SelectAttrs() with odd attributes error = nil, want error")
+	}
+}
+
+func TestTextareaAttrs(t *testing.T) {
+	data, err := funcs.TextareaAttrs("bio", "label", "Bio", "value", "Hello", "error", "Too long", "maxlength", "500")
+	if err != nil {
+		t.Fatalf("TextareaAttrs() error = %v", err)
+	}
+
+	want := map[string]any{
+		"NameID": "bio", "Label": "Bio", "Value": "Hello", "Error": "Too long", "Hint": "", "Rows": "3",
+		"Class": "", "Hyperscript": "", "Attributes": map[string]string{"maxlength": "500"},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("TextareaAttrs() = %v, want %v", data, want)
+	}
+}
+
+func TestCheckboxAttrs(t *testing.T) {
+	tests := []struct {
+		name        string
+		attrs       []any
+		wantChecked bool
+		wantValue   string
+	}{
+		{"unchecked", []any{"label", "Subscribe"}, false, "on"},
+		{"checked bool", []any{"checked", true}, true, "on"},
+		{"checked string", []any{"checked", "true", "value", "yes"}, true, "yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := funcs.CheckboxAttrs("newsletter", tt.attrs...)
+			if err != nil {
+				t.Fatalf("CheckboxAttrs() error = %v", err)
+			}
+			if data["Checked"] != tt.wantChecked || data["Value"] != tt.wantValue {
+				t.Errorf("Checked, Value = %v, %v, want %v, %v", data["Checked"], data["Value"], tt.wantChecked, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestRadioGroupAttrs(t *testing.T) {
+	data, err := funcs.RadioGroupAttrs("plan", []string{"free", "pro"}, "selected", "pro", "label", "Plan")
+	if err != nil {
+		t.Fatalf("RadioGroupAttrs() error = %v", err)
+	}
+
+	want := []funcs.SelectOption{{Value: "free", Label: "free"}, {Value: "pro", Label: "pro", Selected: true}}
+	if got := data["Options"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Options = %+v, want %+v", got, want)
+	}
+	if data["Label"] != "Plan" {
+		t.Errorf("Label = %v, want Plan", data["Label"])
+	}
+}
+
+func TestFileAttrs(t *testing.T) {
+	data, err := funcs.FileAttrs("photos", "accept", "image/*", "multiple", "true", "label", "Photos")
+	if err != nil {
+		t.Fatalf("FileAttrs() error = %v", err)
+	}
+	if data["Accept"] != "image/*" || data["Multiple"] != true || data["Label"] != "Photos" {
+		t.Errorf("FileAttrs() = %v, want accept, multiple, and label", data)
+	}
+}
@@ -6,55 +6,154 @@ import (
 	"time"
 )
 
-var FuncMap = template.FuncMap{
+// builtins are the functions available in all templates. The map is never modified after initialization, adapters
+// get their own copy with Base or Merge.
+var builtins = template.FuncMap{
 	// Boolean
-	"yesno": YesNo,
+	"coalesce": Coalesce,
+	"default":  Default,
+	"ternary":  Ternary,
+	"yesno":    YesNo,
 
 	// Forms
-	"inputAttrs": InputAttrs,
+	"checkboxAttrs":   CheckboxAttrs,
+	"fileAttrs":       FileAttrs,
+	"inputAttrs":      InputAttrs,
+	"radioGroupAttrs": RadioGroupAttrs,
+	"selectAttrs":     SelectAttrs,
+	"textareaAttrs":   TextareaAttrs,
 
 	// HTML
-	"safeHTML": safeHTML,
-	"safeAttr": safeAttr,
-	"safeCSS":  safeCSS,
-	"safeJS":   safeJS,
-	"safeURL":  safeURL,
+	"cspMeta":      CSPMeta,
+	"jsonify":      Jsonify,
+	"nonceAttr":    NonceAttr,
+	"safeHTML":     safeHTML,
+	"safeAttr":     safeAttr,
+	"safeCSS":      safeCSS,
+	"safeJS":       safeJS,
+	"safeURL":      safeURL,
+	"sanitizeHTML": SanitizeHTML,
+
+	// Images
+	"picture":   Picture,
+	"sizesAttr": SizesAttr,
+	"srcset":    Srcset,
+
+	// Locale
+	"formatCurrency": FormatCurrency,
+	"formatDate":     FormatDate,
+	"formatNumber":   FormatNumber,
+	"formatPercent":  FormatPercent,
 
 	// Maps
 	"classMap": ClassMap,
+	"dict":     Dict,
+	"merge":    MergeMaps,
 
 	// Math
 	"isEven": isEven,
 	"isOdd":  isOdd,
 
 	// Numbers
-	"int": toInt64,
+	"comma":         Comma,
+	"compactNumber": CompactNumber,
+	"formatFloat":   FormatFloat,
+	"humanizeBytes": HumanizeBytes,
+	"int":           toInt64,
+	"ordinal":       Ordinal,
+	"roman":         Roman,
+
+	// Pagination
+	"pageLinks": PageLinks,
+	"pageRange": PageRange,
+
+	// Random
+	"randomInt":    RandomInt,
+	"randomString": RandomString,
+	"uuid":         UUID,
 
 	// Slices
-	"slice": slice,
+	"append":  Append,
+	"chunk":   Chunk,
+	"list":    List,
+	"prepend": Prepend,
+	"seq":     Seq,
+	"slice":   slice,
+	"times":   Times,
 
 	// Strings
-	"contains":   strings.Contains,
-	"hasPrefix":  strings.HasPrefix,
-	"hasSuffix":  strings.HasSuffix,
-	"humanize":   Humanize,
-	"isBlank":    IsBlank,
-	"join":       strings.Join,
-	"lower":      strings.ToLower,
-	"notBlank":   NotBlank,
-	"pluralize":  Pluralize,
-	"replaceAll": strings.ReplaceAll,
-	"replace":    strings.Replace,
-	"slugify":    Slugify,
-	"split":      strings.Split,
-	"trim":       strings.TrimSpace,
-	"trimPrefix": strings.TrimPrefix,
-	"trimSuffix": strings.TrimSuffix,
-	"truncate":   Truncate,
-	"upper":      strings.ToUpper,
+	"camelCase":     CamelCase,
+	"contains":      strings.Contains,
+	"hasPrefix":     strings.HasPrefix,
+	"hasSuffix":     strings.HasSuffix,
+	"humanize":      Humanize,
+	"isBlank":       IsBlank,
+	"join":          strings.Join,
+	"kebabCase":     KebabCase,
+	"lower":         strings.ToLower,
+	"notBlank":      NotBlank,
+	"pascalCase":    PascalCase,
+	"pluralize":     Pluralize,
+	"replaceAll":    strings.ReplaceAll,
+	"replace":       strings.Replace,
+	"slugify":       Slugify,
+	"snakeCase":     SnakeCase,
+	"split":         strings.Split,
+	"titleCase":     TitleCase,
+	"trim":          strings.TrimSpace,
+	"trimPrefix":    strings.TrimPrefix,
+	"trimSuffix":    strings.TrimSuffix,
+	"truncate":      Truncate,
+	"truncateHTML":  TruncateHTML,
+	"truncateWords": TruncateWords,
+	"upper":         strings.ToUpper,
 
 	// Time
-	"now":   time.Now,
-	"since": time.Since,
-	"until": time.Until,
+	"dateFormat":    DateFormat,
+	"humanDuration": HumanDuration,
+	"inTZ":          InTZ,
+	"isoDate":       ISODate,
+	"now":           time.Now,
+	"rfc3339":       RFC3339,
+	"since":         time.Since,
+	"timeAgo":       TimeAgo,
+	"until":         time.Until,
+
+	// URLs
+	"urlSetPage":      URLSetPage,
+	"urlWithQuery":    URLWithQuery,
+	"urlWithoutParam": URLWithoutParam,
+}
+
+// FuncMap is a copy of the built-in template functions, made at initialization. Modifying it doesn't affect the
+// functions of the adapters.
+//
+// Deprecated: Use Base for a copy of the built-in functions, or Merge to add functions to them.
+var FuncMap = Base()
+
+// Base returns a copy of the built-in template functions. The copy is owned by the caller and can be modified without
+// affecting other adapters.
+func Base() template.FuncMap {
+	return Merge()
+}
+
+// Merge returns a new function map with the built-in template functions and the functions of the given maps. Later
+// maps override functions of earlier maps and the built-ins with the same name. None of the maps are modified.
+func Merge(maps ...map[string]any) template.FuncMap {
+	size := len(builtins)
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	funcMap := make(template.FuncMap, size)
+	for k, v := range builtins {
+		funcMap[k] = v
+	}
+	for _, m := range maps {
+		for k, v := range m {
+			funcMap[k] = v
+		}
+	}
+
+	return funcMap
 }
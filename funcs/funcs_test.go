@@ -0,0 +1,45 @@
+package funcs_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestMerge(t *testing.T) {
+	shout := func(s string) string { return s + "!" }
+	whisper := func(s string) string { return s + "..." }
+
+	merged := funcs.Merge(map[string]any{"shout": shout, "upper": shout}, map[string]any{"shout": whisper})
+
+	if _, ok := merged["lower"]; !ok {
+		t.Error("Merge() is missing the built-in lower function")
+	}
+	if got := merged["shout"].(func(string) string)("hi"); got != "hi..." {
+		t.Errorf("shout = %q, want the function of the last map", got)
+	}
+	if got := merged["upper"].(func(string) string)("hi"); got != "hi!" {
+		t.Errorf("upper = %q, want the function overriding the built-in", got)
+	}
+
+	// The built-ins are not modified by merging or by changes to the copies
+	merged["extra"] = shout
+	base := funcs.Base()
+	for _, name := range []string{"shout", "extra"} {
+		if _, ok := base[name]; ok {
+			t.Errorf("Base() contains %q", name)
+		}
+	}
+	if _, ok := base["upper"].(func(string) string); !ok {
+		t.Fatal("Base() upper is not a func(string) string")
+	}
+	if got := base["upper"].(func(string) string)("hi"); got != "HI" {
+		t.Errorf("Base() upper = %q, want %q", got, "HI")
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	if len(funcs.FuncMap) != len(funcs.Base()) {
+		t.Errorf("len(FuncMap) = %d, want the %d built-in functions", len(funcs.FuncMap), len(funcs.Base()))
+	}
+}
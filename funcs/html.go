@@ -1,10 +1,13 @@
 package funcs
 
 import (
+	"encoding/json"
+	"fmt"
+	"html"
 	"html/template"
-)
 
-var pathCache = make(map[string]string)
+	"github.com/hypergopher/hyperview/sanitize"
+)
 
 func safeHTML(s string) template.HTML {
 	return template.HTML(s)
@@ -25,3 +28,107 @@ func safeJS(s string) template.JS {
 func safeURL(s string) template.URL {
 	return template.URL(s)
 }
+
+// Jsonify marshals a value as JSON that is safe to embed in a script element or a JavaScript expression, e.g. to pass
+// server data to Alpine.js components or htmx configs. The characters <, >, and & are escaped as \u003c, \u003e, and
+// \u0026, so the JSON can't close the script element with </script> or open an HTML comment, and U+2028 and U+2029
+// are escaped because they end lines in older JavaScript engines:
+//
+//	<script type="application/json" id="cart">{{jsonify .Cart}}</script>
+//	<div x-data="{{jsonify .Filters}}">
+func Jsonify(value any) (template.JS, error) {
+	// json.Marshal escapes <, >, &, U+2028, and U+2029 in strings.
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return template.JS(b), nil
+}
+
+// noncer is implemented by values that know the CSP nonce of the request, such as response.Data.
+type noncer interface {
+	Nonce() string
+}
+
+// htmxNoncer is implemented by values that know the htmx config with the CSP nonce of the request, such as
+// response.Data.
+type htmxNoncer interface {
+	HTMXNonce() string
+}
+
+// nonceValue returns the nonce of a noncer, such as the view data, or of a string.
+func nonceValue(nonce any) (string, error) {
+	switch v := nonce.(type) {
+	case noncer:
+		return v.Nonce(), nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("expected a nonce, got %T", nonce)
+}
+
+// NonceAttr returns the nonce attribute of the CSP nonce, given as a noncer such as the view data or as a string, for
+// script and style elements, or nothing if the nonce is empty:
+//
+//	<script {{nonceAttr .View}} src="/assets/js/app.js"></script>
+//	// => <script nonce="r4nd0m" src="/assets/js/app.js"></script>
+func NonceAttr(nonce any) (template.HTMLAttr, error) {
+	value, err := nonceValue(nonce)
+	if err != nil || value == "" {
+		return "", err
+	}
+	return template.HTMLAttr(`nonce="` + html.EscapeString(value) + `"`), nil
+}
+
+// CSPMeta returns the htmx-config meta tag with the CSP nonce, given as the view data or as a string, so that htmx
+// runs the inline scripts of swapped content with the nonce and doesn't add its indicator styles inline, which a
+// strict style-src would block:
+//
+//	<head>
+//		{{cspMeta .View}}
+//	</head>
+//	// => <meta name="htmx-config" content="{&#34;includeIndicatorStyles&#34;:false,&#34;inlineScriptNonce&#34;: &#34;r4nd0m&#34;}">
+func CSPMeta(nonce any) (template.HTML, error) {
+	var config string
+	if v, ok := nonce.(htmxNoncer); ok {
+		config = v.HTMXNonce()
+	} else {
+		value, err := nonceValue(nonce)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(map[string]any{"includeIndicatorStyles": false, "inlineScriptNonce": value})
+		if err != nil {
+			return "", err
+		}
+		config = string(b)
+	}
+	return template.HTML(`<meta name="htmx-config" content="` + html.EscapeString(config) + `">`), nil
+}
+
+// Sanitizer removes unsafe markup from untrusted HTML, such as a *sanitize.Policy or a bluemonday policy.
+type Sanitizer interface {
+	Sanitize(s string) string
+}
+
+// defaultSanitizer is the policy of the built-in sanitizeHTML function.
+var defaultSanitizer Sanitizer = sanitize.UGCPolicy()
+
+// SanitizeHTML removes unsafe markup from untrusted HTML with the policy for user-generated content (see
+// sanitize.UGCPolicy), and returns it as trusted HTML, so that content such as comments can be rendered without
+// safeHTML:
+//
+//	{{.Comment.Body | sanitizeHTML}}
+//
+// Use SanitizeHTMLFunc, or hyperview.WithSanitizer for the default template adapter, for another policy.
+func SanitizeHTML(s string) template.HTML {
+	return template.HTML(defaultSanitizer.Sanitize(s))
+}
+
+// SanitizeHTMLFunc returns a sanitizeHTML function that sanitizes HTML with the sanitizer, to be added to the function
+// map of an adapter.
+func SanitizeHTMLFunc(sanitizer Sanitizer) func(s string) template.HTML {
+	return func(s string) template.HTML {
+		return template.HTML(sanitizer.Sanitize(s))
+	}
+}
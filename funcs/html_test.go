@@ -0,0 +1,154 @@
+package funcs_test
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestJsonify(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  template.JS
+	}{
+		{"map", map[string]any{"count": 2, "name": "Ada"}, `{"count":2,"name":"Ada"}`},
+		{"script end tag", "</script><script>alert(1)</script>", `"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"`},
+		{"html comment", "<!-- & -->", `"\u003c!-- \u0026 --\u003e"`},
+		{"line separators", "a\u2028b\u2029c", `"a\u2028b\u2029c"`},
+		{"nil", nil, `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Jsonify(tt.value)
+			if err != nil {
+				t.Fatalf("Jsonify() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Jsonify() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := funcs.Jsonify(func() {}); err == nil {
+		t.Error("Jsonify(func) error = nil, want an error")
+	}
+}
+
+func TestJsonifyInTemplates(t *testing.T) {
+	const src = `<script type="application/json">{{jsonify .}}</script>` +
+		`<script>const data = {{jsonify .}};</script>` +
+		`<div x-data="{{jsonify .}}"></div>`
+
+	tmpl, err := template.New("test").Funcs(template.FuncMap(funcs.Base())).Parse(src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	data := map[string]string{"q": `</script>"'&`}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	out := sb.String()
+	if strings.Count(out, "</script>") != 2 {
+		t.Fatalf("output = %s, want the data not to close the script elements", out)
+	}
+
+	// The JSON in the script elements is unchanged, and decodes to the data.
+	for _, prefix := range []string{`<script type="application/json">`, `const data = `} {
+		_, rest, _ := strings.Cut(out, prefix)
+		end := strings.IndexAny(rest, ";<")
+		var decoded map[string]string
+		if err := json.Unmarshal([]byte(rest[:end]), &decoded); err != nil || decoded["q"] != data["q"] {
+			t.Errorf("JSON after %q = %s, want the data (error: %v)", prefix, rest[:end], err)
+		}
+	}
+
+	if !strings.Contains(out, `x-data="{&#34;q&#34;:&#34;\u003c/script\u003e\&#34;&#39;\u0026&#34;}"`) {
+		t.Errorf("output = %s, want the attribute value HTML-escaped", out)
+	}
+}
+
+type upperSanitizer struct{}
+
+func (upperSanitizer) Sanitize(s string) string { return strings.ToUpper(s) }
+
+func TestSanitizeHTML(t *testing.T) {
+	got := funcs.SanitizeHTML(`<p onclick="x()">Hi <script>alert(1)</script><a href="javascript:x">there</a></p>`)
+	if want := template.HTML(`<p>Hi <a>there</a></p>`); got != want {
+		t.Errorf("SanitizeHTML() = %q, want %q", got, want)
+	}
+
+	if got := funcs.SanitizeHTMLFunc(upperSanitizer{})("<b>hi</b>"); got != "<B>HI</B>" {
+		t.Errorf("SanitizeHTMLFunc() = %q, want the output of the sanitizer", got)
+	}
+}
+
+type htmxView string
+
+func (v htmxView) Nonce() string { return string(v) }
+
+func (v htmxView) HTMXNonce() string {
+	return `{"includeIndicatorStyles":false,"inlineScriptNonce": "` + string(v) + `"}`
+}
+
+func TestNonceAttrInTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		nonce   any
+		want    string
+		wantErr bool
+	}{
+		{"view", htmxView("r4nd0m"), `<script nonce="r4nd0m" src="/app.js"></script>`, false},
+		{"string", `a"b`, `<script nonce="a&#34;b" src="/app.js"></script>`, false},
+		{"empty", "", `<script  src="/app.js"></script>`, false},
+		{"invalid", 42, "", true},
+	}
+
+	tmpl := template.Must(template.New("page").Funcs(template.FuncMap{"nonceAttr": funcs.NonceAttr}).
+		Parse(`<script {{nonceAttr .}} src="/app.js"></script>`))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := tmpl.Execute(&sb, tt.nonce)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && sb.String() != tt.want {
+				t.Errorf("Execute() = %q, want %q", sb.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCSPMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		nonce   any
+		want    template.HTML
+		wantErr bool
+	}{
+		{"view", htmxView("r4nd0m"), `<meta name="htmx-config" content="{&#34;includeIndicatorStyles&#34;:false,&#34;inlineScriptNonce&#34;: &#34;r4nd0m&#34;}">`, false},
+		{"string", "r4nd0m", `<meta name="htmx-config" content="{&#34;includeIndicatorStyles&#34;:false,&#34;inlineScriptNonce&#34;:&#34;r4nd0m&#34;}">`, false},
+		{"invalid", 42, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.CSPMeta(tt.nonce)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CSPMeta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CSPMeta() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package funcs
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrIconNotFound is returned by Icons.Icon for names that are not SVG files of the icons filesystem.
+var ErrIconNotFound = errors.New("icon not found")
+
+// Icons renders the SVG files of an icons filesystem as icons, either inline or as references to the symbols of a
+// sprite of all icons (see Sprite). The files are read and parsed once and cached until Reset. Icons is safe for
+// concurrent use.
+type Icons struct {
+	fsys   fs.FS
+	sprite bool
+	mu     sync.RWMutex
+	icons  map[string]*svgIcon // parsed icons by name
+	cached template.HTML       // rendered sprite, if any
+}
+
+// svgIcon is a parsed SVG file.
+type svgIcon struct {
+	attrs   []svgAttr // attributes of the svg element
+	content string    // content of the svg element
+}
+
+type svgAttr struct {
+	name  string
+	value string
+}
+
+// NewIcons returns the icons of the SVG files of the filesystem, e.g. icons/close.svg for the icon "close". With
+// sprite, icons reference the symbols of the sprite instead of repeating the paths of the SVG files, which must then
+// be rendered once on the page (see Sprite).
+func NewIcons(fsys fs.FS, sprite bool) *Icons {
+	return &Icons{fsys: fsys, sprite: sprite, icons: make(map[string]*svgIcon)}
+}
+
+// Icon returns the svg element of the icon, e.g. for the template function icon, with the attributes of the SVG file
+// and the attributes given as key/value pairs:
+//
+//	{{icon "close" "class" "text-red-500" "size" 16}}
+//	// => <svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" class="icon text-red-500" width="16" height="16" aria-hidden="true">...</svg>
+//
+// Classes are added to the classes of the file, "size" sets the width and the height, and a nil or false value
+// removes an attribute. Icons are hidden from assistive technologies with aria-hidden, unless they get an aria-label,
+// aria-labelledby, or role attribute. It returns ErrIconNotFound if the icon is not an SVG file of the filesystem.
+func (i *Icons) Icon(name string, attrs ...any) (template.HTML, error) {
+	if len(attrs)%2 != 0 {
+		return "", fmt.Errorf("Icon expects attributes as key/value pairs, received odd number of arguments")
+	}
+
+	name = iconName(name)
+	icon, err := i.icon(name)
+	if err != nil {
+		return "", err
+	}
+
+	merged := slices.DeleteFunc(slices.Clone(icon.attrs), func(a svgAttr) bool { return a.name == "id" })
+	for j := 0; j < len(attrs); j += 2 {
+		key, ok := attrs[j].(string)
+		if !ok || !isAttrName(key) {
+			return "", fmt.Errorf("Icon attribute at position %d is not a valid attribute name", j)
+		}
+		switch value := attrs[j+1]; {
+		case value == nil || value == false:
+			merged = slices.DeleteFunc(merged, func(a svgAttr) bool { return a.name == key })
+		case key == "size":
+			merged = setAttr(merged, "width", fmt.Sprint(value))
+			merged = setAttr(merged, "height", fmt.Sprint(value))
+		case key == "class":
+			classes := strings.TrimSpace(attrValue(merged, "class") + " " + fmt.Sprint(value))
+			merged = setAttr(merged, "class", classes)
+		default:
+			merged = setAttr(merged, key, fmt.Sprint(value))
+		}
+	}
+	if !slices.ContainsFunc(merged, func(a svgAttr) bool {
+		return a.name == "aria-label" || a.name == "aria-labelledby" || a.name == "role" || a.name == "aria-hidden"
+	}) {
+		merged = append(merged, svgAttr{name: "aria-hidden", value: "true"})
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<svg")
+	writeSVGAttrs(&sb, merged)
+	sb.WriteString(">")
+	if i.sprite {
+		sb.WriteString(`<use href="#` + symbolID(name) + `"></use>`)
+	} else {
+		sb.WriteString(icon.content)
+	}
+	sb.WriteString("</svg>")
+	return template.HTML(sb.String()), nil
+}
+
+// Sprite returns a hidden svg element with a symbol for each SVG file of the filesystem, e.g. for the template
+// function iconSprite, which must be rendered once on each page whose icons reference it:
+//
+//	<body>
+//		{{iconSprite}}
+//		...
+//	</body>
+func (i *Icons) Sprite() (template.HTML, error) {
+	i.mu.RLock()
+	cached := i.cached
+	i.mu.RUnlock()
+	if cached != "" {
+		return cached, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" hidden aria-hidden="true">`)
+	if i.fsys != nil {
+		err := fs.WalkDir(i.fsys, ".", func(file string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || path.Ext(file) != ".svg" {
+				return err
+			}
+			name := strings.TrimSuffix(file, ".svg")
+			icon, err := i.icon(name)
+			if err != nil {
+				return err
+			}
+			sb.WriteString(`<symbol id="` + symbolID(name) + `"`)
+			if viewBox := attrValue(icon.attrs, "viewBox"); viewBox != "" {
+				sb.WriteString(` viewBox="` + html.EscapeString(viewBox) + `"`)
+			}
+			sb.WriteString(">" + icon.content + "</symbol>")
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	sb.WriteString("</svg>")
+
+	sprite := template.HTML(sb.String())
+	i.mu.Lock()
+	i.cached = sprite
+	i.mu.Unlock()
+	return sprite, nil
+}
+
+// Reset clears the cached icons and sprite, e.g. after the icons changed in development.
+func (i *Icons) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	clear(i.icons)
+	i.cached = ""
+}
+
+// icon returns the cached icon, reading and parsing its file on first use.
+func (i *Icons) icon(name string) (*svgIcon, error) {
+	i.mu.RLock()
+	icon, ok := i.icons[name]
+	i.mu.RUnlock()
+	if ok {
+		return icon, nil
+	}
+
+	if i.fsys == nil {
+		return nil, fmt.Errorf("%w: %s", ErrIconNotFound, name)
+	}
+	data, err := fs.ReadFile(i.fsys, name+".svg")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIconNotFound, name)
+	}
+	icon, err = parseSVG(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("icon %s: %w", name, err)
+	}
+
+	i.mu.Lock()
+	i.icons[name] = icon
+	i.mu.Unlock()
+	return icon, nil
+}
+
+// parseSVG returns the attributes and the content of the svg element of an SVG file. The XML declaration, doctype,
+// and comments before the svg element are skipped.
+func parseSVG(s string) (*svgIcon, error) {
+	start := strings.Index(s, "<svg")
+	if start < 0 {
+		return nil, errors.New("no svg element")
+	}
+	s = s[start+len("<svg"):]
+
+	icon := &svgIcon{}
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+		switch {
+		case strings.HasPrefix(s, "/>"):
+			return icon, nil
+		case strings.HasPrefix(s, ">"):
+			end := strings.LastIndex(s, "</svg>")
+			if end < 0 {
+				return nil, errors.New("unclosed svg element")
+			}
+			icon.content = strings.TrimSpace(s[1:end])
+			return icon, nil
+		case s == "":
+			return nil, errors.New("unterminated svg element")
+		}
+
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok || strings.ContainsAny(name, " \t\n\r>") || len(rest) == 0 || (rest[0] != '"' && rest[0] != '\'') {
+			return nil, errors.New("invalid attribute of the svg element")
+		}
+		end := strings.IndexByte(rest[1:], rest[0])
+		if end < 0 {
+			return nil, errors.New("unterminated attribute of the svg element")
+		}
+		icon.attrs = append(icon.attrs, svgAttr{name: name, value: html.UnescapeString(rest[1 : end+1])})
+		s = rest[end+2:]
+	}
+}
+
+// iconName returns the cleaned name of an icon, without the .svg extension.
+func iconName(name string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(path.Clean("/"+name), "/"), ".svg")
+}
+
+// symbolID returns the ID of the symbol of the icon in the sprite, e.g. icon-arrows-left for arrows/left.
+func symbolID(name string) string {
+	return "icon-" + strings.ReplaceAll(name, "/", "-")
+}
+
+// attrValue returns the value of the attribute, or an empty string.
+func attrValue(attrs []svgAttr, name string) string {
+	for _, a := range attrs {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// setAttr sets the value of the attribute, keeping its position if it exists.
+func setAttr(attrs []svgAttr, name, value string) []svgAttr {
+	for j := range attrs {
+		if attrs[j].name == name {
+			attrs[j].value = value
+			return attrs
+		}
+	}
+	return append(attrs, svgAttr{name: name, value: value})
+}
+
+// writeSVGAttrs writes the attributes with escaped values.
+func writeSVGAttrs(sb *strings.Builder, attrs []svgAttr) {
+	for _, a := range attrs {
+		sb.WriteString(" " + a.name + `="` + html.EscapeString(a.value) + `"`)
+	}
+}
+
+// isAttrName returns true if the name only has letters, digits, and the characters - and :, so that it can't break
+// out of the element.
+func isAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == ':') {
+			return false
+		}
+	}
+	return true
+}
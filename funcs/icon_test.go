@@ -0,0 +1,92 @@
+package funcs_test
+
+import (
+	"errors"
+	"html/template"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func iconTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"close.svg": {Data: []byte(`<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" class="icon" id="x">
+  <path d="M6 6l12 12"/>
+</svg>`)},
+		"arrows/left.svg": {Data: []byte(`<svg viewBox='0 0 16 16'><path d="M8 2L2 8"/></svg>`)},
+		"broken.svg":      {Data: []byte(`<svg viewBox="0 0 16 16"`)},
+	}
+}
+
+func TestIcons_Icon(t *testing.T) {
+	tests := []struct {
+		name    string
+		sprite  bool
+		icon    string
+		attrs   []any
+		want    template.HTML
+		wantErr error
+	}{
+		{
+			name: "inline",
+			icon: "close",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" class="icon" aria-hidden="true"><path d="M6 6l12 12"/></svg>`,
+		},
+		{
+			name:  "merged attributes",
+			icon:  "close.svg",
+			attrs: []any{"class", "text-red-500", "size", 16, "xmlns", nil},
+			want:  `<svg viewBox="0 0 24 24" class="icon text-red-500" width="16" height="16" aria-hidden="true"><path d="M6 6l12 12"/></svg>`,
+		},
+		{
+			name:  "labeled",
+			icon:  "arrows/left",
+			attrs: []any{"aria-label", `Back "home"`, "role", "img"},
+			want:  `<svg viewBox="0 0 16 16" aria-label="Back &#34;home&#34;" role="img"><path d="M8 2L2 8"/></svg>`,
+		},
+		{
+			name:   "sprite",
+			sprite: true,
+			icon:   "arrows/left",
+			attrs:  []any{"class", "w-4"},
+			want:   `<svg viewBox="0 0 16 16" class="w-4" aria-hidden="true"><use href="#icon-arrows-left"></use></svg>`,
+		},
+		{name: "missing", icon: "missing", wantErr: funcs.ErrIconNotFound},
+		{name: "invalid file", icon: "broken", wantErr: errors.New("unterminated")},
+		{name: "odd attributes", icon: "close", attrs: []any{"class"}, wantErr: errors.New("odd")},
+		{name: "invalid attribute name", icon: "close", attrs: []any{`onload="x"`, "1"}, wantErr: errors.New("invalid")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.NewIcons(iconTestFS(), tt.sprite).Icon(tt.icon, tt.attrs...)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("Icon() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, funcs.ErrIconNotFound) && !errors.Is(err, funcs.ErrIconNotFound) {
+				t.Errorf("Icon() error = %v, want ErrIconNotFound", err)
+			}
+			if got != tt.want {
+				t.Errorf("Icon() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIcons_Sprite(t *testing.T) {
+	fsys := iconTestFS()
+	delete(fsys, "broken.svg")
+
+	got, err := funcs.NewIcons(fsys, true).Sprite()
+	if err != nil {
+		t.Fatalf("Sprite() error = %v", err)
+	}
+	want := template.HTML(`<svg xmlns="http://www.w3.org/2000/svg" hidden aria-hidden="true">` +
+		`<symbol id="icon-arrows-left" viewBox="0 0 16 16"><path d="M8 2L2 8"/></symbol>` +
+		`<symbol id="icon-close" viewBox="0 0 24 24"><path d="M6 6l12 12"/></symbol></svg>`)
+	if got != want {
+		t.Errorf("Sprite() = %q, want %q", got, want)
+	}
+}
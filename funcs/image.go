@@ -1,6 +1,14 @@
 package funcs
 
-import "strings"
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// defaultPictureFormats are the formats of the sources of Picture, unless set with the "formats" attribute.
+var defaultPictureFormats = []string{"avif", "webp"}
 
 // Srcset returns a srcset string for an image with the given src and widths.
 // It takes a src string and a variadic list of widths. The widths can be
@@ -39,3 +47,129 @@ func Srcset(src string, widths ...string) string {
 	}
 	return srcset
 }
+
+// SizesAttr returns a sizes attribute value from pairs of media conditions and sizes, followed by the default size,
+// e.g. for images with a srcset of widths:
+//
+//	SizesAttr("(min-width: 1024px)", "33vw", "(min-width: 640px)", "50vw", "100vw")
+//	// => "(min-width: 1024px) 33vw, (min-width: 640px) 50vw, 100vw"
+func SizesAttr(sizes ...string) string {
+	parts := make([]string, 0, len(sizes)/2+1)
+	for i := 0; i+1 < len(sizes); i += 2 {
+		parts = append(parts, sizes[i]+" "+sizes[i+1])
+	}
+	if len(sizes)%2 != 0 {
+		parts = append(parts, sizes[len(sizes)-1])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Picture returns a picture element with a source for each modern image format and the image as the fallback, named
+// like the variants of Srcset with the extension of the format. The attributes are key/value pairs of the img
+// element, except for:
+//
+//   - widths: the widths or densities of the variants, as a slice or a comma-separated string (see Srcset). Without
+//     widths, the sources have a single variant with the name of the image, e.g. /img/hero.avif.
+//   - sizes: the sizes attribute of the sources and the image (see SizesAttr).
+//   - formats: the formats of the sources, as a slice or a comma-separated string (default: avif and webp).
+//
+// The image gets an empty alt attribute, for decorative images, unless one is given:
+//
+//	{{picture "/img/hero.jpg" "widths" "640,1280" "sizes" "100vw" "alt" "Our team" "loading" "lazy"}}
+//	// => <picture>
+//	//	<source type="image/avif" srcset="/img/hero-640w.avif, /img/hero-1280w.avif" sizes="100vw">
+//	//	<source type="image/webp" srcset="/img/hero-640w.webp, /img/hero-1280w.webp" sizes="100vw">
+//	//	<img src="/img/hero.jpg" srcset="/img/hero-640w.jpg, /img/hero-1280w.jpg" sizes="100vw" alt="Our team" loading="lazy">
+//	// </picture>
+func Picture(src string, attrs ...any) (template.HTML, error) {
+	if len(attrs)%2 != 0 {
+		return "", fmt.Errorf("Picture expects attributes as key/value pairs, received odd number of arguments")
+	}
+	if strings.TrimSpace(src) == "" {
+		return "", fmt.Errorf("Picture expects an image")
+	}
+
+	widths, attrs, err := extractList(attrs, "widths")
+	if err != nil {
+		return "", err
+	}
+	formats, attrs, err := extractList(attrs, "formats")
+	if err != nil {
+		return "", err
+	}
+	if formats == nil {
+		formats = defaultPictureFormats
+	}
+	sizes, attrs := extractAttr(attrs, "sizes")
+
+	base, ext := src, ""
+	if i := strings.LastIndex(src, "."); i > strings.LastIndex(src, "/") {
+		base, ext = src[:i], src[i:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<picture>")
+	for _, format := range formats {
+		if !isAttrName(format) {
+			return "", fmt.Errorf("Picture format %q is invalid", format)
+		}
+		variant := base + "." + format
+		srcset := variant
+		if len(widths) > 0 {
+			srcset = Srcset(variant, widths...)
+		}
+		sb.WriteString(`<source type="image/` + format + `" srcset="` + html.EscapeString(srcset) + `"`)
+		writeSizes(&sb, sizes)
+		sb.WriteString(">")
+	}
+
+	sb.WriteString(`<img src="` + html.EscapeString(src) + `"`)
+	if len(widths) > 0 && ext != "" {
+		sb.WriteString(` srcset="` + html.EscapeString(Srcset(src, widths...)) + `"`)
+	}
+	writeSizes(&sb, sizes)
+	hasAlt := false
+	for i := 0; i < len(attrs); i += 2 {
+		key, ok := attrs[i].(string)
+		if !ok || !isAttrName(key) {
+			return "", fmt.Errorf("Picture attribute at position %d is not a valid attribute name", i)
+		}
+		hasAlt = hasAlt || key == "alt"
+		sb.WriteString(" " + key + `="` + html.EscapeString(fmt.Sprint(attrs[i+1])) + `"`)
+	}
+	if !hasAlt {
+		sb.WriteString(` alt=""`)
+	}
+	sb.WriteString("></picture>")
+
+	return template.HTML(sb.String()), nil
+}
+
+// extractList removes the attribute with the key from the key/value pairs and returns its value as a list of strings,
+// given as a slice or a comma-separated string, or nil if it is not set.
+func extractList(attrs []any, key string) ([]string, []any, error) {
+	value, attrs := extractAttr(attrs, key)
+	if value == nil {
+		return nil, attrs, nil
+	}
+	if s, ok := value.(string); ok {
+		return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }), attrs, nil
+	}
+
+	elems, err := toSlice(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", key, err)
+	}
+	list := make([]string, len(elems))
+	for i, elem := range elems {
+		list[i] = fmt.Sprint(elem)
+	}
+	return list, attrs, nil
+}
+
+// writeSizes writes the sizes attribute, if any.
+func writeSizes(sb *strings.Builder, sizes any) {
+	if sizes != nil && sizes != "" {
+		sb.WriteString(` sizes="` + html.EscapeString(fmt.Sprint(sizes)) + `"`)
+	}
+}
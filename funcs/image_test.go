@@ -1,6 +1,7 @@
 package funcs_test
 
 import (
+	"html/template"
 	"strings"
 	"testing"
 
@@ -32,3 +33,73 @@ func TestSrcset(t *testing.T) {
 		})
 	}
 }
+
+func TestSizesAttr(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []string
+		want  string
+	}{
+		{"conditions and default", []string{"(min-width: 1024px)", "33vw", "(min-width: 640px)", "50vw", "100vw"}, "(min-width: 1024px) 33vw, (min-width: 640px) 50vw, 100vw"},
+		{"conditions only", []string{"(min-width: 640px)", "50vw"}, "(min-width: 640px) 50vw"},
+		{"default only", []string{"100vw"}, "100vw"},
+		{"none", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := funcs.SizesAttr(tt.sizes...); got != tt.want {
+				t.Errorf("SizesAttr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPicture(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		attrs   []any
+		want    template.HTML
+		wantErr bool
+	}{
+		{
+			name: "without widths",
+			src:  "/img/hero.jpg",
+			want: `<picture><source type="image/avif" srcset="/img/hero.avif"><source type="image/webp" srcset="/img/hero.webp">` +
+				`<img src="/img/hero.jpg" alt=""></picture>`,
+		},
+		{
+			name:  "widths and sizes",
+			src:   "/img/hero.jpg",
+			attrs: []any{"widths", "640,1280", "sizes", "100vw", "alt", `"Team"`, "loading", "lazy"},
+			want: `<picture>` +
+				`<source type="image/avif" srcset="/img/hero-640w.avif, /img/hero-1280w.avif" sizes="100vw">` +
+				`<source type="image/webp" srcset="/img/hero-640w.webp, /img/hero-1280w.webp" sizes="100vw">` +
+				`<img src="/img/hero.jpg" srcset="/img/hero-640w.jpg, /img/hero-1280w.jpg" sizes="100vw" alt="&#34;Team&#34;" loading="lazy"></picture>`,
+		},
+		{
+			name:  "formats and densities",
+			src:   "/logo.png",
+			attrs: []any{"formats", []string{"webp"}, "widths", []any{"1x", "2x"}},
+			want:  `<picture><source type="image/webp" srcset="/logo-1x.webp, /logo-2x.webp"><img src="/logo.png" srcset="/logo-1x.png, /logo-2x.png" alt=""></picture>`,
+		},
+		{name: "no image", src: " ", wantErr: true},
+		{name: "odd attributes", src: "/a.jpg", attrs: []any{"alt"}, wantErr: true},
+		{name: "invalid attribute", src: "/a.jpg", attrs: []any{`x="y"`, "1"}, wantErr: true},
+		{name: "invalid format", src: "/a.jpg", attrs: []any{"formats", `webp"`}, wantErr: true},
+		{name: "invalid widths", src: "/a.jpg", attrs: []any{"widths", 640}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Picture(tt.src, tt.attrs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Picture() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Picture() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
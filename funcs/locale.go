@@ -0,0 +1,127 @@
+package funcs
+
+import (
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Date styles of FormatDate.
+const (
+	DateShort = "short" // numeric date in the order and with the separators of the locale, e.g. 31.12.2025
+	DateISO   = "iso"   // ISO 8601 date, e.g. 2025-12-31
+)
+
+// localizer is implemented by values that know the locale of the request, such as response.Data.
+type localizer interface {
+	Locale() string
+}
+
+// localeTag returns the language tag of a locale, given as a localizer such as the view data or as a locale string.
+// Unknown locales are formatted like English.
+func localeTag(locale any) language.Tag {
+	var s string
+	switch v := locale.(type) {
+	case localizer:
+		s = v.Locale()
+	case string:
+		s = v
+	}
+
+	tag, err := language.Parse(s)
+	if err != nil {
+		return language.English
+	}
+	return tag
+}
+
+// FormatNumber formats a number with the decimal and grouping separators of the locale, e.g. 1.234,5 for de:
+//
+//	{{.Total | formatNumber .View}}
+func FormatNumber(locale, value any) string {
+	return message.NewPrinter(localeTag(locale)).Sprint(number.Decimal(value))
+}
+
+// FormatCurrency formats an amount in the currency of the ISO 4217 code with the currency symbol and the separators of
+// the locale, e.g. € 1.234,50 for de and EUR:
+//
+//	{{.Price | formatCurrency .View "EUR"}}
+func FormatCurrency(locale any, code string, amount any) (string, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", err
+	}
+	return message.NewPrinter(localeTag(locale)).Sprint(currency.Symbol(unit.Amount(amount))), nil
+}
+
+// FormatPercent formats a ratio as a percentage in the format of the locale, e.g. 25 % for de and 0.25:
+//
+//	{{.Ratio | formatPercent .View}}
+func FormatPercent(locale, value any) string {
+	return message.NewPrinter(localeTag(locale)).Sprint(number.Percent(value))
+}
+
+// FormatDate formats a date in a style of the locale, either DateShort for a numeric date, e.g. 12/31/2025 for en and
+// 31.12.2025 for de, or DateISO. Other styles are used as a layout of time.Format, with English month and day names:
+//
+//	{{.CreatedAt | formatDate .View "short"}}
+func FormatDate(locale any, style string, t time.Time) string {
+	switch style {
+	case DateShort:
+		return t.Format(shortDateLayout(localeTag(locale)))
+	case DateISO:
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(style)
+}
+
+// shortDateLayouts are the layouts of numeric dates by language and region, for regions that differ from their
+// language, and by language.
+var shortDateLayouts = map[string]string{
+	"en-AU": "02/01/2006",
+	"en-CA": "2006-01-02",
+	"en-GB": "02/01/2006",
+	"en-IE": "02/01/2006",
+	"en-IN": "02/01/2006",
+	"en-NZ": "02/01/2006",
+	"en-ZA": "2006/01/02",
+	"fr-CA": "2006-01-02",
+	"fr-CH": "02.01.2006",
+	"it-CH": "02.01.2006",
+
+	"cs": "02.01.2006",
+	"da": "02.01.2006",
+	"de": "02.01.2006",
+	"en": "1/2/2006",
+	"fi": "2.1.2006",
+	"hu": "2006. 01. 02.",
+	"ja": "2006/01/02",
+	"ko": "2006. 1. 2.",
+	"lt": "2006-01-02",
+	"nb": "02.01.2006",
+	"nl": "02-01-2006",
+	"pl": "02.01.2006",
+	"ru": "02.01.2006",
+	"sv": "2006-01-02",
+	"tr": "02.01.2006",
+	"uk": "02.01.2006",
+	"zh": "2006/1/2",
+}
+
+// shortDateLayout returns the layout of numeric dates of the locale, defaulting to day, month, and year separated by
+// slashes, as used by most locales.
+func shortDateLayout(tag language.Tag) string {
+	base, _ := tag.Base()
+	if region, confidence := tag.Region(); confidence == language.Exact {
+		if layout, ok := shortDateLayouts[base.String()+"-"+region.String()]; ok {
+			return layout
+		}
+	}
+	if layout, ok := shortDateLayouts[base.String()]; ok {
+		return layout
+	}
+	return "02/01/2006"
+}
@@ -0,0 +1,107 @@
+package funcs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// localized is a value with a locale, like the view data.
+type localized string
+
+func (l localized) Locale() string { return string(l) }
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale any
+		value  any
+		want   string
+	}{
+		{"en", 1234567.891, "1,234,567.891"},
+		{"de", 1234567.891, "1.234.567,891"},
+		{localized("fr"), 1234, "1 234"},
+		{"de-CH", 1234.5, "1’234.5"},
+		{"", 1234, "1,234"},
+		{"not a locale", 1234, "1,234"},
+		{nil, 1234, "1,234"},
+	}
+
+	for _, tt := range tests {
+		if got := funcs.FormatNumber(tt.locale, tt.value); got != tt.want {
+			t.Errorf("FormatNumber(%v, %v) = %q, want %q", tt.locale, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		locale  any
+		code    string
+		amount  any
+		want    string
+		wantErr bool
+	}{
+		{"en", "USD", 1234.5, "$ 1,234.50", false},
+		{"de", "EUR", 1234.5, "€ 1.234,50", false},
+		{localized("de"), "EUR", 3, "€ 3,00", false},
+		{"ja", "JPY", 1234, "￥ 1,234", false},
+		{"en", "XYZ", 1, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := funcs.FormatCurrency(tt.locale, tt.code, tt.amount)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FormatCurrency(%v, %s) error = %v, wantErr %v", tt.locale, tt.code, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("FormatCurrency(%v, %s, %v) = %q, want %q", tt.locale, tt.code, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		locale any
+		value  any
+		want   string
+	}{
+		{"en", 0.25, "25%"},
+		{"de", 0.25, "25 %"},
+		{"en", 1.5, "150%"},
+	}
+
+	for _, tt := range tests {
+		if got := funcs.FormatPercent(tt.locale, tt.value); got != tt.want {
+			t.Errorf("FormatPercent(%v, %v) = %q, want %q", tt.locale, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2025, time.December, 31, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		locale any
+		style  string
+		want   string
+	}{
+		{"en", funcs.DateShort, "12/31/2025"},
+		{"en-US", funcs.DateShort, "12/31/2025"},
+		{"en-GB", funcs.DateShort, "31/12/2025"},
+		{"de", funcs.DateShort, "31.12.2025"},
+		{localized("de-AT"), funcs.DateShort, "31.12.2025"},
+		{"fr", funcs.DateShort, "31/12/2025"},
+		{"fr-CA", funcs.DateShort, "2025-12-31"},
+		{"ja", funcs.DateShort, "2025/12/31"},
+		{"", funcs.DateShort, "12/31/2025"},
+		{"de", funcs.DateISO, "2025-12-31"},
+		{"de", "Jan 2, 2006", "Dec 31, 2025"},
+	}
+
+	for _, tt := range tests {
+		if got := funcs.FormatDate(tt.locale, tt.style, date); got != tt.want {
+			t.Errorf("FormatDate(%v, %s) = %q, want %q", tt.locale, tt.style, got, tt.want)
+		}
+	}
+}
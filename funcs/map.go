@@ -32,3 +32,39 @@ func ClassMap(classMap ...any) (string, error) {
 
 	return strings.Join(classes, " "), nil
 }
+
+// Dict takes pairs of keys and values and returns them as a map, e.g. to pass several values to a partial:
+//
+//	{{template "partial:card" dict "Title" .Title "Items" .Items}}
+func Dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("Dict expects key/value pairs, received odd number of arguments")
+	}
+
+	dict := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("Dict key at position %d is not a string", i)
+		}
+		dict[key] = pairs[i+1]
+	}
+
+	return dict, nil
+}
+
+// MergeMaps returns a new map with the entries of all maps, where later maps override the entries of earlier maps
+// with the same key, e.g. to override the defaults of a partial:
+//
+//	{{template "partial:button" merge (dict "Size" "md" "Variant" "primary") (dict "Label" "Delete" "Variant" "danger")}}
+//
+// All arguments must be maps of type map[string]any, such as the maps of dict. None of the maps are modified.
+func MergeMaps(maps ...map[string]any) map[string]any {
+	merged := make(map[string]any)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,68 @@
+package funcs_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestDict(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []any
+		want    map[string]any
+		wantErr bool
+	}{
+		{"empty", nil, map[string]any{}, false},
+		{"pairs", []any{"Title", "Hello", "Count", 3}, map[string]any{"Title": "Hello", "Count": 3}, false},
+		{"odd arguments", []any{"Title"}, nil, true},
+		{"non-string key", []any{1, "one"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Dict(tt.pairs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeMaps(t *testing.T) {
+	defaults := map[string]any{"Size": "md", "Variant": "primary"}
+	got := funcs.MergeMaps(defaults, map[string]any{"Size": "lg", "Label": "Save"}, nil)
+
+	want := map[string]any{"Size": "lg", "Variant": "primary", "Label": "Save"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeMaps() = %v, want %v", got, want)
+	}
+	if defaults["Size"] != "md" || len(defaults) != 2 {
+		t.Errorf("MergeMaps() modified the first map: %v", defaults)
+	}
+}
+
+func TestCompositeFuncsInTemplates(t *testing.T) {
+	const src = `{{define "button"}}<{{.Size}} {{.Variant}}>{{.Label}}{{end}}` +
+		`{{template "button" merge (dict "Size" "md" "Variant" "primary") (dict "Label" "Save" "Size" "lg")}}|` +
+		`{{range prepend (append (list "b" "c") "d") "a"}}{{.}}{{end}}`
+
+	tmpl, err := template.New("test").Funcs(funcs.Base()).Parse(src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := sb.String(), "<lg primary>Save|abcd"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
@@ -2,7 +2,9 @@ package funcs
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
 func toInt64(i any) (int64, error) {
@@ -32,3 +34,175 @@ func toInt64(i any) (int64, error) {
 
 	return 0, fmt.Errorf("unable to convert type %T to int", i)
 }
+
+// toFloat64 converts a number, or a numeric string, to a float64.
+func toFloat64(i any) (float64, error) {
+	switch v := i.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+
+	n, err := toInt64(i)
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert type %T to float", i)
+	}
+	return float64(n), nil
+}
+
+// Comma formats a number with commas between groups of thousands, e.g. 12,345 for 12345 and 1,234.5 for 1234.5.
+// Use FormatNumber for the separators of a locale.
+func Comma(value any) (string, error) {
+	var s string
+	switch v := value.(type) {
+	case float32, float64:
+		f, _ := toFloat64(v)
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	default:
+		n, err := toInt64(v)
+		if err != nil {
+			return "", err
+		}
+		s = strconv.FormatInt(n, 10)
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(r)
+	}
+	if hasFrac {
+		sb.WriteString("." + fracPart)
+	}
+	return sb.String(), nil
+}
+
+// FormatFloat formats a number with a fixed number of decimals, e.g. 3.14 for a precision of 2 and 3.14159:
+//
+//	{{.Average | formatFloat 2}}
+func FormatFloat(precision int, value any) (string, error) {
+	f, err := toFloat64(value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(f, 'f', precision, 64), nil
+}
+
+// HumanizeBytes formats a size in bytes with decimal units and one decimal, e.g. 1.2 MB for 1234567 and 512 B for 512.
+func HumanizeBytes(value any) (string, error) {
+	size, err := toFloat64(value)
+	if err != nil {
+		return "", err
+	}
+	return scaleNumber(size, 1000, []string{" B", " kB", " MB", " GB", " TB", " PB", " EB"}), nil
+}
+
+// CompactNumber formats a number in a short form with one decimal, e.g. 1.2k for 1234, 3.4M for 3400000, and 999 for
+// 999, for counters and stats.
+func CompactNumber(value any) (string, error) {
+	n, err := toFloat64(value)
+	if err != nil {
+		return "", err
+	}
+	return scaleNumber(n, 1000, []string{"", "k", "M", "B", "T"}), nil
+}
+
+// scaleNumber divides the number by the base until it is smaller than the base, and formats it with one decimal
+// (without a trailing .0) and the unit of the number of divisions.
+func scaleNumber(n, base float64, units []string) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+
+	// Numbers without a unit are rounded to integers, e.g. 999.6 to 1000, which then needs the next unit
+	rounded := func(n float64, i int) float64 {
+		if i == 0 {
+			return math.Round(n)
+		}
+		return math.Round(n*10) / 10
+	}
+
+	i := 0
+	for i < len(units)-1 && rounded(n, i) >= base {
+		n /= base
+		i++
+	}
+
+	s := strconv.FormatFloat(rounded(n, i), 'f', 1, 64)
+	return sign + strings.TrimSuffix(s, ".0") + units[i]
+}
+
+// Ordinal formats a number as an English ordinal, e.g. 1st, 2nd, 3rd, 4th, 11th, and 22nd, for rankings and steps.
+func Ordinal(value any) (string, error) {
+	n, err := toInt64(value)
+	if err != nil {
+		return "", err
+	}
+
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	suffix := "th"
+	switch abs % 100 {
+	case 11, 12, 13:
+	default:
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.FormatInt(n, 10) + suffix, nil
+}
+
+// romanNumerals are the values and symbols of Roman numerals, including the subtractive forms, in descending order.
+var romanNumerals = []struct {
+	value  int64
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+	{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// Roman formats a number from 1 to 3999 as a Roman numeral, e.g. IV for 4 and MMXXV for 2025, for headings and
+// numbered sections. Other numbers return an error.
+func Roman(value any) (string, error) {
+	n, err := toInt64(value)
+	if err != nil {
+		return "", err
+	}
+	if n < 1 || n > 3999 {
+		return "", fmt.Errorf("Roman expects a number from 1 to 3999, got %d", n)
+	}
+
+	var sb strings.Builder
+	for _, numeral := range romanNumerals {
+		for n >= numeral.value {
+			sb.WriteString(numeral.symbol)
+			n -= numeral.value
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,193 @@
+package funcs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestComma(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1,000"},
+		{12345, "12,345"},
+		{-1234567, "-1,234,567"},
+		{int64(1234567890), "1,234,567,890"},
+		{uint64(18446744073709551615), "18,446,744,073,709,551,615"},
+		{1234.5, "1,234.5"},
+		{-0.25, "-0.25"},
+		{"98765", "98,765"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := funcs.Comma(tt.value)
+			if err != nil {
+				t.Fatalf("Comma(%v) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Comma(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := funcs.Comma("abc"); err == nil {
+		t.Error("Comma(\"abc\") error = nil, want an error")
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		precision int
+		value     any
+		want      string
+	}{
+		{2, 3.14159, "3.14"},
+		{0, 2.5, "2"},
+		{1, 42, "42.0"},
+		{3, float32(0.5), "0.500"},
+		{2, "1.005", "1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := funcs.FormatFloat(tt.precision, tt.value)
+			if err != nil {
+				t.Fatalf("FormatFloat(%d, %v) error = %v", tt.precision, tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatFloat(%d, %v) = %q, want %q", tt.precision, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{999.6, "1 kB"},
+		{1000, "1 kB"},
+		{1234567, "1.2 MB"},
+		{999_999, "1 MB"},
+		{int64(5_500_000_000), "5.5 GB"},
+		{uint64(2_000_000_000_000_000_000), "2 EB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := funcs.HumanizeBytes(tt.value)
+			if err != nil {
+				t.Fatalf("HumanizeBytes(%v) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("HumanizeBytes(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactNumber(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1234, "1.2k"},
+		{12_345, "12.3k"},
+		{999_950, "1M"},
+		{3_400_000, "3.4M"},
+		{-1500, "-1.5k"},
+		{int64(7_000_000_000), "7B"},
+		{2.5e13, "25T"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := funcs.CompactNumber(tt.value)
+			if err != nil {
+				t.Fatalf("CompactNumber(%v) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("CompactNumber(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "0th"},
+		{1, "1st"},
+		{2, "2nd"},
+		{3, "3rd"},
+		{4, "4th"},
+		{11, "11th"},
+		{12, "12th"},
+		{13, "13th"},
+		{21, "21st"},
+		{22, "22nd"},
+		{101, "101st"},
+		{111, "111th"},
+		{-1, "-1st"},
+		{"23", "23rd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := funcs.Ordinal(tt.value)
+			if err != nil {
+				t.Fatalf("Ordinal(%v) error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Ordinal(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoman(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{1, "I", false},
+		{4, "IV", false},
+		{9, "IX", false},
+		{14, "XIV", false},
+		{40, "XL", false},
+		{90, "XC", false},
+		{400, "CD", false},
+		{1994, "MCMXCIV", false},
+		{2025, "MMXXV", false},
+		{3999, "MMMCMXCIX", false},
+		{0, "", true},
+		{4000, "", true},
+		{-5, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.value), func(t *testing.T) {
+			got, err := funcs.Roman(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Roman(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Roman(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
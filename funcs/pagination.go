@@ -0,0 +1,21 @@
+package funcs
+
+import "github.com/hypergopher/hyperview/response"
+
+// PageRange returns the page numbers of a paginator around the current page, with 0 for gaps (see
+// response.Pagination.Window):
+//
+//	{{range pageRange .Pagination 5}}{{if eq . 0}}…{{else}}{{.}}{{end}}{{end}}
+func PageRange(p response.Pagination, size int) []int {
+	return p.Window(size)
+}
+
+// PageLinks returns the links of a paginator around the current page, with the page query parameter set on the base
+// URL (see response.Pagination.Links). The request URL as the base URL keeps the other query parameters, e.g. filters:
+//
+//	{{range pageLinks .Pagination .View.RequestURL 5}}
+//		{{if .Gap}}…{{else if .Current}}<span>{{.Page}}</span>{{else}}<a href="{{.URL}}">{{.Page}}</a>{{end}}
+//	{{end}}
+func PageLinks(p response.Pagination, baseURL string, size int) []response.PageLink {
+	return p.Links(baseURL, size)
+}
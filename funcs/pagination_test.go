@@ -0,0 +1,27 @@
+package funcs_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hypergopher/hyperview/funcs"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestPaginationFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("paginator").Funcs(funcs.Base()).Parse(
+		`{{range pageRange .P 3}}{{if eq . 0}}…{{else}}{{.}}{{end}} {{end}}|` +
+			`{{range pageLinks .P "/posts" 3}}{{if .Gap}}…{{else if .Current}}[{{.Page}}]{{else}}{{.URL}}{{end}} {{end}}`,
+	))
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, map[string]any{"P": response.NewPagination(5, 10, 100)}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "1 … 4 5 6 … 10 |/posts?page=1 … /posts?page=4 [5] /posts?page=6 … /posts?page=10 "
+	if got := sb.String(); got != want {
+		t.Errorf("paginator = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package funcs
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// randomAlphabet are the characters of RandomString, which are valid in HTML IDs, CSS selectors, and URLs.
+const randomAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// maxRandomLen is the maximum length of RandomString, so that a wrong argument can't exhaust the memory.
+const maxRandomLen = 1024
+
+// errRandomTooLong is returned by RandomString for lengths of more than maxRandomLen.
+var errRandomTooLong = errors.New("random string too long")
+
+// UUID returns a random version 4 UUID, e.g. "f47ac10b-58cc-4372-a567-0e02b2c3d479", for unique element IDs.
+func UUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// RandomString returns a random string of n letters and digits, e.g. for the IDs of the htmx targets of repeated
+// partials:
+//
+//	{{$id := printf "comments-%s" (randomString 8)}}
+//	<div id="{{$id}}" hx-get="/comments" hx-target="#{{$id}}">
+//
+// Random strings are limited to 1,024 characters.
+func RandomString(n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("RandomString expects a length of at least 0, got %d", n)
+	}
+	if n > maxRandomLen {
+		return "", fmt.Errorf("RandomString: %w: more than %d characters", errRandomTooLong, maxRandomLen)
+	}
+
+	// Random bytes of 248 and more are skipped, so that each character is equally likely (248 = 4 * 62)
+	const limit = 256 / len(randomAlphabet) * len(randomAlphabet)
+
+	b := make([]byte, 0, n)
+	buf := make([]byte, n+n/4+1)
+	for len(b) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, c := range buf {
+			if int(c) < limit && len(b) < n {
+				b = append(b, randomAlphabet[int(c)%len(randomAlphabet)])
+			}
+		}
+	}
+	return string(b), nil
+}
+
+// RandomInt returns a random integer from min to max, excluding max, e.g. for cache-busting values.
+func RandomInt(minValue, maxValue any) (int64, error) {
+	lo, err := toInt64(minValue)
+	if err != nil {
+		return 0, err
+	}
+	hi, err := toInt64(maxValue)
+	if err != nil {
+		return 0, err
+	}
+	if hi <= lo {
+		return 0, fmt.Errorf("RandomInt expects min to be less than max, got %d and %d", lo, hi)
+	}
+
+	n, err := rand.Int(rand.Reader, new(big.Int).Sub(big.NewInt(hi), big.NewInt(lo)))
+	if err != nil {
+		return 0, err
+	}
+	return lo + n.Int64(), nil
+}
@@ -0,0 +1,77 @@
+package funcs_test
+
+import (
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestUUID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for range 100 {
+		id := funcs.UUID()
+		if !pattern.MatchString(id) {
+			t.Fatalf("UUID() = %q, want a version 4 UUID", id)
+		}
+		if seen[id] {
+			t.Fatalf("UUID() returned %q twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestRandomString(t *testing.T) {
+	pattern := regexp.MustCompile(`^[a-zA-Z0-9]*$`)
+
+	for _, n := range []int{0, 1, 8, 100, 1024} {
+		s, err := funcs.RandomString(n)
+		if err != nil {
+			t.Fatalf("RandomString(%d) error = %v", n, err)
+		}
+		if len(s) != n || !pattern.MatchString(s) {
+			t.Errorf("RandomString(%d) = %q, want %d letters and digits", n, s, n)
+		}
+	}
+
+	a, _ := funcs.RandomString(16)
+	b, _ := funcs.RandomString(16)
+	if a == b {
+		t.Errorf("RandomString(16) returned %q twice", a)
+	}
+
+	for _, n := range []int{-1, 1025, math.MaxInt} {
+		if _, err := funcs.RandomString(n); err == nil {
+			t.Errorf("RandomString(%d) error = nil, want an error", n)
+		}
+	}
+}
+
+func TestRandomInt(t *testing.T) {
+	seen := make(map[int64]bool)
+	for range 200 {
+		n, err := funcs.RandomInt(-2, 3)
+		if err != nil {
+			t.Fatalf("RandomInt() error = %v", err)
+		}
+		if n < -2 || n >= 3 {
+			t.Fatalf("RandomInt(-2, 3) = %d, want a number from -2 to 2", n)
+		}
+		seen[n] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("RandomInt(-2, 3) returned %v, want all numbers from -2 to 2", seen)
+	}
+
+	if n, err := funcs.RandomInt("10", 11); err != nil || n != 10 {
+		t.Errorf("RandomInt(\"10\", 11) = %d, %v, want 10", n, err)
+	}
+	for _, args := range [][2]any{{5, 5}, {5, 1}, {"x", 1}} {
+		if _, err := funcs.RandomInt(args[0], args[1]); err == nil {
+			t.Errorf("RandomInt(%v, %v) error = nil, want an error", args[0], args[1])
+		}
+	}
+}
@@ -1,6 +1,166 @@
 package funcs
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// maxSeqLen is the maximum number of elements of Seq and Times, so that a wrong argument can't exhaust the memory.
+const maxSeqLen = 10_000
+
+// errSeqTooLong is returned by Seq and Times for sequences with more than maxSeqLen elements.
+var errSeqTooLong = errors.New("sequence too long")
+
 // Slices takes a variadic list of values and returns them as a slice.
 func slice(values ...any) []any {
 	return values
 }
+
+// List takes a variadic list of values and returns them as a slice, e.g. to range over literal values:
+//
+//	{{range list "draft" "published" "archived"}}...{{end}}
+func List(values ...any) []any {
+	return values
+}
+
+// Append returns a new slice with the elements of the list followed by the values. The list can be a slice of any
+// type, or nil for an empty list, and is not modified.
+func Append(list any, values ...any) ([]any, error) {
+	elems, err := toSlice(list)
+	if err != nil {
+		return nil, fmt.Errorf("Append: %w", err)
+	}
+	return append(elems, values...), nil
+}
+
+// Prepend returns a new slice with the values followed by the elements of the list, like Append.
+func Prepend(list any, values ...any) ([]any, error) {
+	elems, err := toSlice(list)
+	if err != nil {
+		return nil, fmt.Errorf("Prepend: %w", err)
+	}
+	return append(append(make([]any, 0, len(values)+len(elems)), values...), elems...), nil
+}
+
+// Seq returns the integers from start to end, inclusive, counting by the step, which defaults to 1, or -1 if end is
+// less than start. It returns an empty slice if the step goes away from end, e.g. to render a grid of columns:
+//
+//	{{range seq 1 12}}<div class="col-{{.}}"></div>{{end}}
+//	{{range seq 10 0 5}}{{.}} {{end}} // => 10 5 0
+//
+// Sequences are limited to 10,000 elements.
+func Seq(start, end any, step ...any) ([]int, error) {
+	from, err := toInt64(start)
+	if err != nil {
+		return nil, fmt.Errorf("Seq: start: %w", err)
+	}
+	to, err := toInt64(end)
+	if err != nil {
+		return nil, fmt.Errorf("Seq: end: %w", err)
+	}
+
+	by := int64(1)
+	if to < from {
+		by = -1
+	}
+	switch len(step) {
+	case 0:
+	case 1:
+		if by, err = toInt64(step[0]); err != nil {
+			return nil, fmt.Errorf("Seq: step: %w", err)
+		}
+		if by == 0 {
+			return nil, errors.New("Seq: step must not be zero")
+		}
+	default:
+		return nil, fmt.Errorf("Seq expects at most 3 arguments, received %d", len(step)+2)
+	}
+
+	if by > 0 && from > to || by < 0 && from < to {
+		return []int{}, nil
+	}
+	// The span and the step are unsigned, so that they can't overflow
+	span, stride := uint64(to)-uint64(from), uint64(by)
+	if by < 0 {
+		span, stride = uint64(from)-uint64(to), uint64(-by)
+	}
+	if span/stride >= maxSeqLen {
+		return nil, fmt.Errorf("Seq: %w: more than %d elements", errSeqTooLong, maxSeqLen)
+	}
+	n := span/stride + 1
+
+	seq := make([]int, n)
+	for i := range seq {
+		seq[i] = int(from + int64(i)*by)
+	}
+	return seq, nil
+}
+
+// Times returns the integers from 0 to n-1, e.g. to repeat placeholders while content is loading:
+//
+//	{{range times 3}}<div class="skeleton"></div>{{end}}
+//
+// It returns an empty slice for n <= 0. Sequences are limited to 10,000 elements.
+func Times(n any) ([]int, error) {
+	count, err := toInt64(n)
+	if err != nil {
+		return nil, fmt.Errorf("Times: %w", err)
+	}
+	if count <= 0 {
+		return []int{}, nil
+	}
+	return Seq(0, count-1)
+}
+
+// Chunk splits a slice of any type into slices of the size, where the last slice has the remaining elements, e.g. to
+// render rows of cards:
+//
+//	{{range chunk .Products 3}}
+//		<div class="row">{{range .}}{{template "partial:card" .}}{{end}}</div>
+//	{{end}}
+func Chunk(list any, size any) ([][]any, error) {
+	n, err := toInt64(size)
+	if err != nil {
+		return nil, fmt.Errorf("Chunk: size: %w", err)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("Chunk: size must be positive, got %d", n)
+	}
+	elems, err := toSlice(list)
+	if err != nil {
+		return nil, fmt.Errorf("Chunk: %w", err)
+	}
+
+	if len(elems) == 0 {
+		return [][]any{}, nil
+	}
+
+	// Sizes beyond the length make a single chunk, and would overflow the capacity of the chunks
+	chunkSize := int(min(n, int64(len(elems))))
+	chunks := make([][]any, 0, (len(elems)+chunkSize-1)/chunkSize)
+	for len(elems) > 0 {
+		end := min(chunkSize, len(elems))
+		chunks = append(chunks, elems[:end:end])
+		elems = elems[end:]
+	}
+	return chunks, nil
+}
+
+// toSlice returns a copy of the elements of a slice or array of any type.
+func toSlice(list any) ([]any, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice, got %T", list)
+	}
+
+	elems := make([]any, v.Len())
+	for i := range v.Len() {
+		elems[i] = v.Index(i).Interface()
+	}
+	return elems, nil
+}
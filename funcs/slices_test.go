@@ -0,0 +1,153 @@
+package funcs_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestAppendPrepend(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        any
+		values      []any
+		wantAppend  []any
+		wantPrepend []any
+		wantErr     bool
+	}{
+		{"nil list", nil, []any{"a"}, []any{"a"}, []any{"a"}, false},
+		{"any slice", []any{"a", 1}, []any{"b"}, []any{"a", 1, "b"}, []any{"b", "a", 1}, false},
+		{"typed slice", []string{"a", "b"}, []any{"c", "d"}, []any{"a", "b", "c", "d"}, []any{"c", "d", "a", "b"}, false},
+		{"array", [2]int{1, 2}, []any{3}, []any{1, 2, 3}, []any{3, 1, 2}, false},
+		{"no values", []int{1}, nil, []any{1}, []any{1}, false},
+		{"not a slice", "abc", []any{"d"}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Append(tt.list, tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Append() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantAppend) {
+				t.Errorf("Append() = %v, want %v", got, tt.wantAppend)
+			}
+
+			got, err = funcs.Prepend(tt.list, tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Prepend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.wantPrepend) {
+				t.Errorf("Prepend() = %v, want %v", got, tt.wantPrepend)
+			}
+		})
+	}
+}
+
+func TestAppendDoesNotModifyList(t *testing.T) {
+	list := make([]any, 1, 4)
+	list[0] = "a"
+
+	first, _ := funcs.Append(list, "b")
+	second, _ := funcs.Append(list, "c")
+	if first[1] != "b" || second[1] != "c" {
+		t.Errorf("Append() = %v and %v, want independent slices", first, second)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   any
+		end     any
+		step    []any
+		want    []int
+		wantErr bool
+	}{
+		{"ascending", 1, 5, nil, []int{1, 2, 3, 4, 5}, false},
+		{"descending", 3, 1, nil, []int{3, 2, 1}, false},
+		{"single", 2, 2, nil, []int{2}, false},
+		{"step", 0, 10, []any{5}, []int{0, 5, 10}, false},
+		{"step not reaching end", 1, 10, []any{4}, []int{1, 5, 9}, false},
+		{"negative step", 10, 0, []any{-5}, []int{10, 5, 0}, false},
+		{"step away from end", 1, 5, []any{-1}, []int{}, false},
+		{"strings", "1", "3", nil, []int{1, 2, 3}, false},
+		{"zero step", 1, 5, []any{0}, nil, true},
+		{"too long", 0, 1_000_000, nil, nil, true},
+		{"extreme range", int64(-1 << 63), int64(1<<63 - 1), nil, nil, true},
+		{"too many arguments", 1, 5, []any{1, 2}, nil, true},
+		{"invalid start", "a", 5, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Seq(tt.start, tt.end, tt.step...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Seq() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Seq() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       any
+		want    []int
+		wantErr bool
+	}{
+		{"three", 3, []int{0, 1, 2}, false},
+		{"zero", 0, []int{}, false},
+		{"negative", -2, []int{}, false},
+		{"too many", 20_000, nil, true},
+		{"invalid", "x", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Times(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Times() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Times() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    any
+		size    any
+		want    [][]any
+		wantErr bool
+	}{
+		{"even", []int{1, 2, 3, 4}, 2, [][]any{{1, 2}, {3, 4}}, false},
+		{"remainder", []string{"a", "b", "c"}, 2, [][]any{{"a", "b"}, {"c"}}, false},
+		{"larger size", []int{1}, 3, [][]any{{1}}, false},
+		{"max size", []int{1, 2}, int64(math.MaxInt64), [][]any{{1, 2}}, false},
+		{"empty", []int{}, 3, [][]any{}, false},
+		{"nil", nil, 3, [][]any{}, false},
+		{"zero size", []int{1}, 0, nil, true},
+		{"not a slice", "abc", 2, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.Chunk(tt.list, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Chunk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
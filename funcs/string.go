@@ -2,10 +2,16 @@ package funcs
 
 import (
 	"bytes"
+	"fmt"
+	"html/template"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
+// Pluralize returns the singular for a count of 1 and the plural otherwise, following the rules of English. The tn
+// function of the i18n package (see i18n.Funcs) follows the CLDR plural rules of the locale instead.
 func Pluralize(count any, singular string, plural string) (string, error) {
 	n, err := toInt64(count)
 	if err != nil {
@@ -61,12 +67,147 @@ func Slugify(s string) string {
 	return buf.String()
 }
 
+// Truncate shortens a string to n characters and appends "..." if it is longer. It cuts between runes, so multi-byte
+// characters are never split, but can cut words; use TruncateWords or TruncateHTML to keep words whole.
 func Truncate(s string, n int) string {
-	if len(s) <= n {
+	if utf8.RuneCountInString(s) <= n {
 		return s
 	}
 
-	return s[:n] + "..."
+	return string([]rune(s)[:max(n, 0)]) + "..."
+}
+
+// TruncateWords shortens a string to its first n words and appends "..." if it has more words. Words are separated by
+// white space, which is kept within the first n words.
+func TruncateWords(s string, n int) string {
+	words := 0
+	inWord := false
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		if !inWord {
+			if words == n {
+				return strings.TrimRightFunc(s[:i], unicode.IsSpace) + "..."
+			}
+			words++
+			inWord = true
+		}
+	}
+
+	return s
+}
+
+// TruncateHTML shortens trusted HTML to n characters of text, cutting at the last word boundary before the limit if
+// there is one, appends "..." if it is longer, and closes the elements that are open at the cut, e.g.
+// "<p>Hello <b>world</b></p>" truncated to 8 characters is "<p>Hello...</p>". Tags are kept and don't count towards
+// the limit, character references such as &amp; count as one character, and a < that doesn't start a tag is text. s
+// can be a string or template.HTML.
+func TruncateHTML(s any, n int) (template.HTML, error) {
+	var src string
+	switch v := s.(type) {
+	case string:
+		src = v
+	case template.HTML:
+		src = string(v)
+	default:
+		return "", fmt.Errorf("TruncateHTML expects a string or template.HTML, got %T", s)
+	}
+
+	var open []string // names of the open elements
+	lastBreak := -1   // offset of the last white space in the text
+	var openAtBreak []string
+	chars := 0
+
+	for i := 0; i < len(src); {
+		switch src[i] {
+		case '<':
+			// A < that doesn't start a tag, e.g. in "1 < 2", is text
+			end := strings.IndexByte(src[i:], '>')
+			if end < 0 || !isTagStart(src[i+1]) {
+				break
+			}
+			tag := src[i+1 : i+end]
+			i += end + 1
+
+			if strings.HasPrefix(tag, "!") || strings.HasPrefix(tag, "?") {
+				continue
+			}
+			name, closing := tagName(tag)
+			switch {
+			case name == "":
+			case closing:
+				if idx := slices.Index(open, name); idx >= 0 {
+					open = slices.Delete(open, idx, len(open))
+				}
+			case !voidElements[name] && !strings.HasSuffix(tag, "/"):
+				open = append(open, name)
+			}
+			continue
+		case '&':
+			if end := strings.IndexByte(src[i:], ';'); end > 1 && end <= 32 && !strings.ContainsAny(src[i+1:i+end], " <&") {
+				if chars >= n {
+					return truncatedHTML(src, i, open, lastBreak, openAtBreak), nil
+				}
+				chars++
+				i += end + 1
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if unicode.IsSpace(r) {
+			lastBreak = i
+			openAtBreak = slices.Clone(open)
+		} else if chars >= n {
+			return truncatedHTML(src, i, open, lastBreak, openAtBreak), nil
+		}
+		chars++
+		i += size
+	}
+
+	return template.HTML(src), nil
+}
+
+// truncatedHTML returns the HTML cut at the last break, if any, or at the offset, followed by "..." and the end tags of
+// the elements that are open at the cut.
+func truncatedHTML(src string, offset int, open []string, lastBreak int, openAtBreak []string) template.HTML {
+	if lastBreak >= 0 {
+		offset, open = lastBreak, openAtBreak
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRightFunc(src[:offset], unicode.IsSpace))
+	sb.WriteString("...")
+	for i := len(open) - 1; i >= 0; i-- {
+		sb.WriteString("</" + open[i] + ">")
+	}
+	return template.HTML(sb.String())
+}
+
+// tagName returns the lowercased name of the element of the tag without its angle brackets, and whether it is an end
+// tag.
+func tagName(tag string) (string, bool) {
+	tag, closing := strings.CutPrefix(tag, "/")
+	end := strings.IndexFunc(tag, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '/' || r == '>'
+	})
+	if end < 0 {
+		end = len(tag)
+	}
+	return strings.ToLower(tag[:end]), closing
+}
+
+// isTagStart returns true if the character after a < starts a tag, an end tag, a comment, or a processing instruction.
+func isTagStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '/' || c == '!' || c == '?'
+}
+
+// voidElements are the HTML elements without end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+	"link": true, "meta": true, "source": true, "track": true, "wbr": true,
 }
 
 func IsBlank(s string) bool {
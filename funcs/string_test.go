@@ -0,0 +1,98 @@
+package funcs_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 5, "hello..."},
+		{"héllo wörld", 7, "héllo w..."},
+		{"日本語のテキスト", 3, "日本語..."},
+		{"hello", 0, "..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := funcs.Truncate(tt.s, tt.n); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{"shorter", "one two", 3, "one two"},
+		{"exact", "one two three", 3, "one two three"},
+		{"trailing space", "one two three  ", 3, "one two three  "},
+		{"longer", "one two three four", 2, "one two..."},
+		{"keeps white space", "one\n two   three", 2, "one\n two..."},
+		{"leading space", "  one two", 1, "  one..."},
+		{"unicode", "über größe straße", 2, "über größe..."},
+		{"zero", "one two", 0, "..."},
+		{"empty", "", 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := funcs.TruncateWords(tt.s, tt.n); got != tt.want {
+				t.Errorf("TruncateWords(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		s    any
+		n    int
+		want template.HTML
+	}{
+		{"shorter", "<p>Hello <b>world</b></p>", 20, "<p>Hello <b>world</b></p>"},
+		{"exact", "<p>Hello <b>world</b></p>", 11, "<p>Hello <b>world</b></p>"},
+		{"word boundary", "<p>Hello <b>world</b></p>", 8, "<p>Hello...</p>"},
+		{"closes open tags", "<div><p>Hello <b>big world</b></p></div>", 10, "<div><p>Hello <b>big...</b></p></div>"},
+		{"long word", "<p>Supercalifragilistic</p>", 5, "<p>Super...</p>"},
+		{"void elements", "<p>One<br>two <img src=\"x.png\"/>three four</p>", 12, "<p>One<br>two <img src=\"x.png\"/>three...</p>"},
+		{"entities count as one character", "<p>Tom &amp; Jerry &amp; friends</p>", 11, "<p>Tom &amp; Jerry...</p>"},
+		{"unicode", "<em>naïve café au lait</em>", 11, "<em>naïve café...</em>"},
+		{"attributes with spaces", `<a href="/x" title="a b c">link text here</a>`, 9, `<a href="/x" title="a b c">link text...</a>`},
+		{"template.HTML", template.HTML("<i>one two</i>"), 4, "<i>one...</i>"},
+		{"comment", "<!-- note --><p>one two</p>", 4, "<!-- note --><p>one...</p>"},
+		{"trailing <", "a<", 10, "a<"},
+		{"stray <", "1 < 2 and more text here", 5, "1 < 2..."},
+		{"< before >", "<p>1 < 2 > 0 and more</p>", 9, "<p>1 < 2 > 0...</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.TruncateHTML(tt.s, tt.n)
+			if err != nil {
+				t.Fatalf("TruncateHTML() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TruncateHTML(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := funcs.TruncateHTML(42, 3); err == nil {
+		t.Error("TruncateHTML(42) error = nil, want an error")
+	}
+}
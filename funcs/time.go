@@ -3,6 +3,7 @@ package funcs
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 )
 
@@ -11,6 +12,17 @@ const (
 	year = 365 * day
 )
 
+// dateStyles are the named layouts of DateFormat.
+var dateStyles = map[string]string{
+	"date":     "Jan 2, 2006",
+	"datetime": "Jan 2, 2006 3:04 PM",
+	"time":     "3:04 PM",
+	"kitchen":  time.Kitchen,
+	"rfc1123":  time.RFC1123,
+	"rfc3339":  time.RFC3339,
+	DateISO:    time.DateOnly,
+}
+
 func FormatTime(t time.Time, format string) string {
 	return t.Format(format)
 }
@@ -59,3 +71,136 @@ func ApproximateDuration(d time.Duration) string {
 
 	return fmt.Sprintf("%d years", dy)
 }
+
+// DateFormat formats a time.Time or *time.Time with a named style or a time.Format layout. The styles are "date" (Dec
+// 31, 2025), "datetime" (Dec 31, 2025 3:04 PM), "time" (3:04 PM), "kitchen", "rfc1123", "rfc3339", and "iso"
+// (2025-12-31). Zero and nil times return an empty string:
+//
+//	{{.CreatedAt | dateFormat "datetime"}}
+//	{{.CreatedAt | dateFormat "Monday, 2 January"}}
+//
+// Use FormatDate for the date formats of the locale of the request.
+func DateFormat(layoutOrStyle string, t any) (string, error) {
+	var tt time.Time
+	switch v := t.(type) {
+	case time.Time:
+		tt = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		tt = *v
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("DateFormat expects a time.Time, got %T", t)
+	}
+
+	if tt.IsZero() {
+		return "", nil
+	}
+	if layout, ok := dateStyles[layoutOrStyle]; ok {
+		return tt.Format(layout), nil
+	}
+	return tt.Format(layoutOrStyle), nil
+}
+
+// RFC3339 formats a time as RFC 3339, e.g. for the datetime attribute of time elements.
+func RFC3339(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// ISODate formats the date of a time as ISO 8601, e.g. 2025-12-31 for the value of date inputs.
+func ISODate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// TimeAgo returns the approximate time from now to t in words, e.g. "3 hours ago" for past times, "in 2 days" for
+// future times, and "just now" for less than a second:
+//
+//	<time datetime="{{rfc3339 .CreatedAt}}">{{timeAgo .CreatedAt}}</time>
+func TimeAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Second && d > -time.Second:
+		return "just now"
+	case d < 0:
+		return "in " + ApproximateDuration(-d)
+	}
+	return ApproximateDuration(d) + " ago"
+}
+
+// HumanDuration returns a duration in words with its two largest units, e.g. "1 hour 30 minutes" or "45 seconds".
+// Durations below a second return "less than 1 second"; use ApproximateDuration for a single rounded unit.
+func HumanDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Second {
+		return "less than 1 second"
+	}
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{year, "year"},
+		{day, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	}
+
+	// The largest unit, followed by the next smaller unit if it isn't zero
+	var parts []string
+	for i, unit := range units {
+		n := d / unit.size
+		if n == 0 {
+			continue
+		}
+		parts = append(parts, formatUnit(n, unit.name))
+		if i+1 < len(units) {
+			if next := d % unit.size / units[i+1].size; next > 0 {
+				parts = append(parts, formatUnit(next, units[i+1].name))
+			}
+		}
+		break
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatUnit formats a number of units, e.g. "1 hour" or "2 hours".
+func formatUnit(n time.Duration, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// locationer is implemented by values that know the time zone of the viewer, such as response.Data.
+type locationer interface {
+	Location() *time.Location
+}
+
+// InTZ converts a time to a time zone, given as a locationer such as the view data, a *time.Location, or an IANA
+// name such as "Europe/Berlin", so that times are rendered in the time zone of the viewer:
+//
+//	{{.CreatedAt | inTZ .View | dateFormat "datetime"}}
+func InTZ(loc any, t time.Time) (time.Time, error) {
+	switch v := loc.(type) {
+	case locationer:
+		return t.In(v.Location()), nil
+	case *time.Location:
+		if v == nil {
+			return t, nil
+		}
+		return t.In(v), nil
+	case string:
+		l, err := time.LoadLocation(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.In(l), nil
+	}
+	return time.Time{}, fmt.Errorf("InTZ expects a time zone, got %T", loc)
+}
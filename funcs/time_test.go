@@ -0,0 +1,144 @@
+package funcs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestDateFormat(t *testing.T) {
+	ts := time.Date(2025, 12, 31, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		style string
+		value any
+		want  string
+	}{
+		{"date", "date", ts, "Dec 31, 2025"},
+		{"datetime", "datetime", ts, "Dec 31, 2025 3:04 PM"},
+		{"time", "time", ts, "3:04 PM"},
+		{"iso", "iso", ts, "2025-12-31"},
+		{"rfc3339", "rfc3339", ts, "2025-12-31T15:04:05Z"},
+		{"layout", "Monday, 2 January", ts, "Wednesday, 31 December"},
+		{"pointer", "iso", &ts, "2025-12-31"},
+		{"nil pointer", "iso", (*time.Time)(nil), ""},
+		{"nil", "iso", nil, ""},
+		{"zero", "iso", time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.DateFormat(tt.style, tt.value)
+			if err != nil {
+				t.Fatalf("DateFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DateFormat(%q) = %q, want %q", tt.style, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := funcs.DateFormat("iso", "2025-12-31"); err == nil {
+		t.Error("DateFormat() with a string error = nil, want an error")
+	}
+}
+
+func TestRFC3339AndISODate(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	if got, want := funcs.RFC3339(ts), "2025-01-02T03:04:05+01:00"; got != want {
+		t.Errorf("RFC3339() = %q, want %q", got, want)
+	}
+	if got, want := funcs.ISODate(ts), "2025-01-02"; got != want {
+		t.Errorf("ISODate() = %q, want %q", got, want)
+	}
+}
+
+func TestTimeAgo(t *testing.T) {
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{0, "just now"},
+		{-3 * time.Hour, "3 hours ago"},
+		{-1 * time.Minute, "1 minute ago"},
+		{-45 * 24 * time.Hour, "45 days ago"},
+		{2*24*time.Hour + time.Minute, "in 2 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := funcs.TimeAgo(time.Now().Add(tt.offset)); got != tt.want {
+				t.Errorf("TimeAgo(now%+v) = %q, want %q", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{500 * time.Millisecond, "less than 1 second"},
+		{45 * time.Second, "45 seconds"},
+		{time.Minute + time.Second, "1 minute 1 second"},
+		{90 * time.Minute, "1 hour 30 minutes"},
+		{2 * time.Hour, "2 hours"},
+		{2*time.Hour + 30*time.Second, "2 hours"},
+		{50*time.Hour + 10*time.Minute, "2 days 2 hours"},
+		{-90 * time.Second, "1 minute 30 seconds"},
+		{400 * 24 * time.Hour, "1 year 35 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := funcs.HumanDuration(tt.d); got != tt.want {
+				t.Errorf("HumanDuration(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+type viewer struct{ loc *time.Location }
+
+func (v viewer) Location() *time.Location { return v.loc }
+
+func TestInTZ(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	ts := time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		loc  any
+		want string
+	}{
+		{"locationer", viewer{tokyo}, "2026-01-01 08:30 JST"},
+		{"location", tokyo, "2026-01-01 08:30 JST"},
+		{"nil location", (*time.Location)(nil), "2025-12-31 23:30 UTC"},
+		{"name", "Asia/Tokyo", "2026-01-01 08:30 JST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.InTZ(tt.loc, ts)
+			if err != nil {
+				t.Fatalf("InTZ() error = %v", err)
+			}
+			if s := got.Format("2006-01-02 15:04 MST"); s != tt.want {
+				t.Errorf("InTZ() = %q, want %q", s, tt.want)
+			}
+		})
+	}
+
+	for _, loc := range []any{"Nowhere/Special", 42} {
+		if _, err := funcs.InTZ(loc, ts); err == nil {
+			t.Errorf("InTZ(%v) error = nil, want an error", loc)
+		}
+	}
+}
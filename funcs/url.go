@@ -0,0 +1,73 @@
+package funcs
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// URLWithQuery returns the URL with the query parameters of the key/value pairs set, replacing their existing values,
+// e.g. for sorting and filter links that keep the other parameters of the current URL:
+//
+//	<a href="{{urlWithQuery .View.RequestURL "sort" "name" "dir" "asc"}}">Name</a>
+//
+// Values are formatted with fmt.Sprint, a slice of strings sets several values, and nil removes the parameter.
+func URLWithQuery(base string, pairs ...any) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("URLWithQuery expects key/value pairs, received odd number of arguments")
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return "", fmt.Errorf("URLWithQuery key at position %d is not a string", i)
+		}
+
+		switch v := pairs[i+1].(type) {
+		case nil:
+			query.Del(key)
+		case []string:
+			query[key] = v
+		default:
+			query.Set(key, fmt.Sprint(v))
+		}
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// URLWithoutParam returns the URL without the query parameters with the keys, e.g. for a link that clears a filter:
+//
+//	<a href="{{urlWithoutParam .View.RequestURL "status"}}">All statuses</a>
+func URLWithoutParam(base string, keys ...string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	for _, key := range keys {
+		query.Del(key)
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// URLSetPage returns the URL with the page query parameter set to the page, keeping the other parameters, e.g. for
+// the previous and next links of a paginated list (see also PageLinks):
+//
+//	<a href="{{urlSetPage .View.RequestURL .Pagination.NextPage}}">Next</a>
+func URLSetPage(base string, page any) (string, error) {
+	n, err := toInt64(page)
+	if err != nil {
+		return "", err
+	}
+	return URLWithQuery(base, "page", n)
+}
@@ -0,0 +1,77 @@
+package funcs_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+func TestURLWithQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		pairs   []any
+		want    string
+		wantErr bool
+	}{
+		{"adds", "/posts", []any{"sort", "name"}, "/posts?sort=name", false},
+		{"replaces and keeps", "/posts?sort=date&status=draft", []any{"sort", "name", "dir", "asc"}, "/posts?dir=asc&sort=name&status=draft", false},
+		{"escapes", "/search", []any{"q", "a&b c", "page", 2}, "/search?page=2&q=a%26b+c", false},
+		{"several values", "/posts?tag=x", []any{"tag", []string{"go", "web"}}, "/posts?tag=go&tag=web", false},
+		{"nil removes", "/posts?sort=name&page=3", []any{"page", nil}, "/posts?sort=name", false},
+		{"absolute URL with fragment", "https://example.com/a?b=1#top", []any{"c", true}, "https://example.com/a?b=1&c=true#top", false},
+		{"odd arguments", "/posts", []any{"sort"}, "", true},
+		{"non-string key", "/posts", []any{1, "x"}, "", true},
+		{"invalid URL", "http://[::1", []any{"a", "b"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := funcs.URLWithQuery(tt.base, tt.pairs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("URLWithQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("URLWithQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLWithoutParam(t *testing.T) {
+	tests := []struct {
+		base string
+		keys []string
+		want string
+	}{
+		{"/posts?status=draft&sort=name", []string{"status"}, "/posts?sort=name"},
+		{"/posts?status=draft&page=2", []string{"status", "page"}, "/posts"},
+		{"/posts?sort=name", []string{"missing"}, "/posts?sort=name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.base, func(t *testing.T) {
+			got, err := funcs.URLWithoutParam(tt.base, tt.keys...)
+			if err != nil {
+				t.Fatalf("URLWithoutParam() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("URLWithoutParam(%q, %v) = %q, want %q", tt.base, tt.keys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLSetPage(t *testing.T) {
+	got, err := funcs.URLSetPage("/posts?sort=name&page=1", 3)
+	if err != nil {
+		t.Fatalf("URLSetPage() error = %v", err)
+	}
+	if want := "/posts?page=3&sort=name"; got != want {
+		t.Errorf("URLSetPage() = %q, want %q", got, want)
+	}
+
+	if _, err := funcs.URLSetPage("/posts", "next"); err == nil {
+		t.Error("URLSetPage() with a non-numeric page error = nil, want an error")
+	}
+}
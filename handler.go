@@ -0,0 +1,62 @@
+package hyperview
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// HandlerFunc is a handler that returns the response to render, or an error (see HyperView.Wrap).
+type HandlerFunc func(r *http.Request) (*response.Response, error)
+
+// Wrap returns an http.Handler that calls the handler and renders the response it returns (see Render), so handlers
+// do not have to pass the ResponseWriter around. Errors are rendered with RenderErrorAs, so they are mapped to system
+// pages by the error renderers, and panics are recovered and rendered as system errors. A nil response without an
+// error responds with 204 No Content.
+//
+// Errors and panics are rendered with the json adapter for requests that prefer JSON, with the yaml adapter for
+// requests that accept YAML, and with the html adapter otherwise.
+func (s *HyperView) Wrap(handler HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				// The panic value may contain internal data, so it is only logged
+				s.logger.Error("Handler panic", slog.Any("panic", p), slog.String("stack", string(debug.Stack())))
+				s.RenderErrorAs(w, r, requestAdapterKey(r), errors.New(http.StatusText(http.StatusInternalServerError)))
+			}
+		}()
+
+		resp, err := handler(r)
+		if err != nil {
+			s.RenderErrorAs(w, r, requestAdapterKey(r), err)
+			return
+		}
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		s.Render(w, r, resp)
+	})
+}
+
+// requestAdapterKey returns the key of the adapter for responses that are not built by the application, such as
+// error and maintenance pages: json for requests that prefer JSON, yaml for requests that accept YAML, and html
+// otherwise.
+func requestAdapterKey(r *http.Request) string {
+	switch {
+	case request.PreferredMediaType(r) == "application/json":
+		return "json"
+	case request.AcceptsYAML(r):
+		return "yaml"
+	default:
+		return "html"
+	}
+}
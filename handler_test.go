@@ -0,0 +1,74 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWrap(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":       {Data: []byte(`{{define "page:main"}}Hello {{.Name}}{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Page not found{{end}}`)},
+		"views/system/500.html": {Data: []byte(`{{define "page:main"}}Server error{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base"))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		handler    hyperview.HandlerFunc
+		accept     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"response", func(r *http.Request) (*response.Response, error) {
+			return response.NewResponse().Path("home").Data(map[string]any{"Name": "Gopher"}), nil
+		}, "", http.StatusOK, "Hello Gopher"},
+		{"error", func(r *http.Request) (*response.Response, error) {
+			return nil, hyperview.ErrNotFound
+		}, "", http.StatusNotFound, "Page not found"},
+		{"json error", func(r *http.Request) (*response.Response, error) {
+			return nil, hyperview.ErrNotFound
+		}, "application/json", http.StatusNotFound, `"code": 404`},
+		{"panic", func(r *http.Request) (*response.Response, error) {
+			panic("boom")
+		}, "", http.StatusInternalServerError, "Server error"},
+		{"json panic", func(r *http.Request) (*response.Response, error) {
+			panic("password=hunter2")
+		}, "application/json", http.StatusInternalServerError, "Internal Server Error"},
+		{"no response", func(r *http.Request) (*response.Response, error) {
+			return nil, nil
+		}, "", http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			hv.Wrap(tt.handler).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+			if strings.Contains(w.Body.String(), "hunter2") {
+				t.Errorf("body = %q, want the panic value not to be exposed", w.Body.String())
+			}
+		})
+	}
+}
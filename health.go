@@ -0,0 +1,165 @@
+package hyperview
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/request"
+)
+
+// defaultHealthCheckTimeout is the timeout of health checks without a timeout.
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// Statuses of the health report and its checks.
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck is a named check of the health handler (see HealthHandler), e.g. a ping of the database.
+type HealthCheck struct {
+	// Name is the name of the check in the report.
+	Name string
+	// Check returns an error if the check fails. It should return when the context is done.
+	Check func(ctx context.Context) error
+	// Timeout is the timeout of the check. Default is 5 seconds.
+	Timeout time.Duration
+}
+
+// HealthReport is the report served by the health handler.
+type HealthReport struct {
+	Status string              `json:"status"`
+	Checks []HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthCheckResult is the result of a health check.
+type HealthCheckResult struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// healthTemplate renders the health report as an HTML page, if there is no views/system/health template.
+var healthTemplate = template.Must(template.New("health").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Health: {{.Status}}</title></head>
+<body>
+<h1>Health: {{.Status}}</h1>
+{{if .Checks}}
+<table>
+<thead><tr><th>Check</th><th>Status</th><th>Duration</th><th>Error</th></tr></thead>
+<tbody>
+{{range .Checks}}<tr><td>{{.Name}}</td><td>{{.Status}}</td><td>{{printf "%.1f" .DurationMS}} ms</td><td>{{.Error}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+</body>
+</html>
+`))
+
+// HealthHandler returns an http.Handler that runs the health checks concurrently and serves a health report, e.g. for
+// the liveness (without checks) and readiness probes of load balancers. The handler responds with 200 OK if all checks
+// pass and 503 Service Unavailable otherwise.
+//
+// The report is served as JSON, except for requests that prefer text/html, e.g. from browsers, which get an HTML
+// status page. The page is rendered with the views/system/health template of the html adapter and the report as
+// .Health, or with a built-in page if there is no such template.
+func (s *HyperView) HealthHandler(checks ...HealthCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := runHealthChecks(r.Context(), checks)
+
+		status := http.StatusOK
+		if report.Status != HealthStatusOK {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+
+		if request.PreferredMediaType(r) != "text/html" {
+			if err := JSONWithHeaders(w, status, report); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		path := constants.ViewsDir + "/" + constants.SystemDir + "/health"
+		if ta, ok := s.adapterMap()["html"].(*TemplateAdapter); ok {
+			if _, found := ta.resolvePage(r, path); found {
+				ta.Render(w, r, s.newSystemResponse("html").Path(path).Data(map[string]any{"Health": report}).Status(status))
+				return
+			}
+		}
+
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		if err := healthTemplate.Execute(buf, report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = buf.WriteTo(w)
+	})
+}
+
+// runHealthChecks runs the checks concurrently and returns the report, in the order of the checks.
+func runHealthChecks(ctx context.Context, checks []HealthCheck) HealthReport {
+	report := HealthReport{
+		Status: HealthStatusOK,
+		Checks: make([]HealthCheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = runHealthCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != HealthStatusOK {
+			report.Status = HealthStatusFail
+		}
+	}
+
+	return report
+}
+
+// runHealthCheck runs the check with its timeout. A check that panics fails.
+func runHealthCheck(ctx context.Context, check HealthCheck) (result HealthCheckResult) {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result = HealthCheckResult{Name: check.Name, Status: HealthStatusOK}
+	start := time.Now()
+
+	defer func() {
+		if p := recover(); p != nil {
+			result.Status = HealthStatusFail
+			result.Error = fmt.Sprintf("panic: %v", p)
+		}
+		result.DurationMS = float64(time.Since(start).Microseconds()) / 1000
+	}()
+
+	if err := check.Check(ctx); err != nil {
+		result.Status = HealthStatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package hyperview_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ok := hyperview.HealthCheck{Name: "db", Check: func(ctx context.Context) error { return nil }}
+	failing := hyperview.HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return errors.New("connection refused") }}
+	slow := hyperview.HealthCheck{Name: "search", Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	panicking := hyperview.HealthCheck{Name: "queue", Check: func(ctx context.Context) error { panic("boom") }}
+
+	tests := []struct {
+		name       string
+		checks     []hyperview.HealthCheck
+		wantStatus int
+		want       hyperview.HealthReport
+	}{
+		{"liveness", nil, http.StatusOK, hyperview.HealthReport{Status: "ok"}},
+		{"healthy", []hyperview.HealthCheck{ok}, http.StatusOK, hyperview.HealthReport{Status: "ok", Checks: []hyperview.HealthCheckResult{
+			{Name: "db", Status: "ok"},
+		}}},
+		{"unhealthy", []hyperview.HealthCheck{ok, failing, slow, panicking}, http.StatusServiceUnavailable, hyperview.HealthReport{Status: "fail", Checks: []hyperview.HealthCheckResult{
+			{Name: "db", Status: "ok"},
+			{Name: "cache", Status: "fail", Error: "connection refused"},
+			{Name: "search", Status: "fail", Error: "context deadline exceeded"},
+			{Name: "queue", Status: "fail", Error: "panic: boom"},
+		}}},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fstest.MapFS{}))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.HealthHandler(tt.checks...).ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var got hyperview.HealthReport
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got.Status != tt.want.Status || len(got.Checks) != len(tt.want.Checks) {
+				t.Fatalf("report = %+v, want %+v", got, tt.want)
+			}
+			for i, check := range got.Checks {
+				check.DurationMS = 0
+				if check != tt.want.Checks[i] {
+					t.Errorf("check %d = %+v, want %+v", i, check, tt.want.Checks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthHandler_HTML(t *testing.T) {
+	failing := hyperview.HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return errors.New("connection refused") }}
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"built-in page", fstest.MapFS{}, "<td>connection refused</td>"},
+		{"system template", fstest.MapFS{
+			"layouts/base.html":        {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+			"views/system/health.html": {Data: []byte(`{{define "page:main"}}Status {{.Health.Status}}{{end}}`)},
+		}, "Status fail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(tt.fsys))
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			r := httptest.NewRequest("GET", "/healthz", nil)
+			r.Header.Set("Accept", "text/html,application/xhtml+xml")
+			w := httptest.NewRecorder()
+			hv.HealthHandler(failing).ServeHTTP(w, r)
+
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
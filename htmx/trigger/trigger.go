@@ -2,6 +2,7 @@ package trigger
 
 import (
 	"encoding/json"
+	"maps"
 )
 
 // Trigger represents an HTMX trigger
@@ -35,6 +36,15 @@ func NewTriggers() *Triggers {
 	}
 }
 
+// Clone returns a copy of the triggers, which can be changed without changing the original triggers.
+func (t *Triggers) Clone() *Triggers {
+	return &Triggers{
+		triggers:    maps.Clone(t.triggers),
+		afterSettle: maps.Clone(t.afterSettle),
+		afterSwap:   maps.Clone(t.afterSwap),
+	}
+}
+
 // Set sets a trigger, overwriting any existing trigger
 func (t *Triggers) Set(name string, value any) {
 	t.triggers[name] = NewTrigger(name, value)
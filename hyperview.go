@@ -1,35 +1,111 @@
 package hyperview
 
 import (
+	"bytes"
+	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/hypergopher/hyperview/cache"
 	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/funcs"
 	"github.com/hypergopher/hyperview/htmx"
+	"github.com/hypergopher/hyperview/i18n"
 	"github.com/hypergopher/hyperview/request"
 	"github.com/hypergopher/hyperview/response"
+	"github.com/hypergopher/hyperview/unpoly"
 )
 
+// adapterMap is a map of view adapters by name.
+type adapterMap map[string]Adapter
+
+// adapterRegistry holds the registered view adapters and the layouts of their registrations.
+type adapterRegistry struct {
+	adapters adapterMap
+	layouts  map[string]adapterConfig
+}
+
+// AdapterOption configures the registration of a view adapter (see RegisterAdapter).
+type AdapterOption func(*adapterConfig)
+
+// adapterConfig is the configuration of an adapter registration.
+type adapterConfig struct {
+	baseLayout   string
+	systemLayout string
+}
+
+// WithAdapterLayouts sets the base and system layouts of the responses rendered by the adapter, e.g. a separate
+// layout for markdown pages or an admin adapter. Empty layouts fall back to the layouts of the view service (see
+// WithLayouts).
+func WithAdapterLayouts(base, system string) AdapterOption {
+	return func(c *adapterConfig) {
+		c.baseLayout = base
+		c.systemLayout = system
+	}
+}
+
 // Option is a function that can be used to configure the HyperView struct.
 type Option func(*HyperView) error
 
 // HyperView provides a service to render views from different template adapters.
 type HyperView struct {
-	adapters      map[string]Adapter // map of view adapters
-	baseLayout    string             // default layout to use if none is specified
-	systemLayout  string             // layout to use for system pages
-	filesystemMap map[string]fs.FS   // map of file systems to use for the view adapters
-	funcMap       template.FuncMap   // map of html/template functions to pass to the view
-	logger        *slog.Logger       // logger to use for the view service
-	mu            sync.RWMutex       // protects the adapters map
+	adapters       atomic.Pointer[adapterRegistry]   // view adapters by name, replaced on registration (copy-on-write)
+	aliases        atomic.Pointer[map[string]string] // template paths by alias, replaced on change (copy-on-write)
+	variants       VariantResolver                   // selects the variant of the template to render, if any
+	locales        LocaleResolver                    // resolves the locale of the request, if any
+	timezones      TimezoneResolver                  // resolves the time zone of the viewer of the request, if any
+	translator     i18n.Translator                   // translates the messages of the t and tn template functions
+	sanitizer      funcs.Sanitizer                   // sanitizes the HTML of the sanitizeHTML template function, if set
+	assetsURL      string                            // URL prefix of the fingerprinted asset URLs
+	assetPaths     *funcs.AssetPaths                 // fingerprinted asset paths of the assetPath template function
+	assetPipeline  *assetPipeline                    // builds the assets and maps their logical names, if set
+	assetInliner   *funcs.AssetInliner               // inlined assets of the inlineCSS and inlineJS template functions
+	iconsDir       string                            // directory of the SVG files of the icon template function
+	iconSprite     bool                              // render icons as references to the symbols of the icon sprite
+	icons          *funcs.Icons                      // icons of the icon and iconSprite template functions
+	baseLayout     string                            // default layout to use if none is specified
+	systemLayout   string                            // layout to use for system pages
+	filesystemMap  map[string]fs.FS                  // map of file systems to use for the view adapters
+	funcMap        template.FuncMap                  // map of html/template functions to pass to the view
+	logger         *slog.Logger                      // logger to use for the view service
+	mu             sync.Mutex                        // serializes changes to the adapters and tenants
+	devReloadDirs  []string                          // template directories to watch for changes in development
+	tenants        map[string]fs.FS                  // tenant file systems by tenant ID
+	tenantResolver func(r *http.Request) string      // resolves the tenant of a request
+	session        response.SessionReader            // reads session values for Data.Session
+	flash          FlashStore                        // stores values for the next request, nil for the default cookie store
+	htmxPartial    bool                              // render HTMX requests without the layout
+	partialName    string                            // template to render for partial responses
+	renderCache    cache.Store                       // render cache of the default html adapter
+	etags          bool                              // set ETags in the default html and json adapters
+	defaultHeaders http.Header                       // headers added to every rendered response
+	devErrors      bool                              // render template errors of the default html adapter as a developer error page
+	lazyTemplates  bool                              // compile the pages of the default html adapter on first render
+	onMissing      MissingTemplateFunc               // handles renders of missing templates in the default html adapter
+	metrics        Metrics                           // observes renders and render cache lookups
+	tracer         RenderTracer                      // traces renders
+	debug          bool                              // serve the debug handler
+	expvar         bool                              // publish runtime stats via expvar
+	errorRenderers []errorMapping                    // renderers of RenderError by error matcher
+	maintenance    atomic.Pointer[maintenance]       // maintenance mode configuration, nil if disabled
+	csp            *CSP                              // Content-Security-Policy written by the nonce middleware
+	canonical      *url.URL                          // canonical scheme and host of the site, nil if not set
+	done           chan struct{}                     // closed when the view service is closed
+	closeOnce      sync.Once                         // ensures the done channel is only closed once
 }
 
 // NewHyperView creates a new view service. It accepts a list of options to configure the view service.
@@ -39,17 +115,50 @@ type HyperView struct {
 //   - WithLayouts: sets the base and system layouts for the view service.
 //   - WithFuncMap: sets an initial function map to use for the template engine.
 //   - WithBaseTemplateFS: sets an initial template and assets filesystem to use for the template engine.
+//   - WithTemplateFS: sets an initial template and assets filesystem from any fs.FS, such as os.DirFS.
+//   - WithTemplateFSOverlay: sets an ordered list of template filesystems, where higher-priority filesystems shadow lower-priority ones.
+//   - WithCanonicalURL: sets the canonical scheme and host, used by .View.CanonicalURL and the canonical host middleware.
+//   - WithCSP: sets the Content-Security-Policy written by the nonce middleware, with the nonce of each request.
+//   - WithDebugHandler: enables the debug handler, which lists the adapters and templates.
+//   - WithDefaultResponseHeaders: adds headers, such as X-Content-Type-Options, to every rendered response.
+//   - WithDevErrors: renders template errors of the default html adapter as a developer error page with the source.
+//   - WithDevReload: watches template directories on disk and reinitializes the adapters when files change.
+//   - WithFlashStore: sets the store of the submitted values and errors of RedirectWithErrors (default: cookies).
+//   - WithHtmxPartialMode: renders HTMX requests without the layout.
+//   - WithExpvar: publishes runtime stats (renders, templates, and reinitializations) via expvar.
+//   - WithErrorRenderer: registers the renderer of RenderError for matching errors.
+//   - WithETags: sets ETags in the default html and json adapters and responds with 304 Not Modified when they match.
+//   - WithMetrics: observes renders and render cache lookups, e.g. to export them to Prometheus.
+//   - WithVariantResolver: renders variants of templates, e.g. views/pricing@b, selected from the request for A/B tests.
+//   - WithLocaleResolver: resolves the locale of the request and renders translated templates, e.g. views/checkout.de.
+//   - WithTranslator: adds the t and tn functions, which translate messages for the locale of the request.
+//   - WithSanitizer: sets the policy of the sanitizeHTML function, which removes unsafe markup from untrusted HTML.
+//   - WithAssetPipeline: builds the assets, e.g. with esbuild, and maps their logical names to the built files for assetPath.
+//   - WithAssetsURL: sets the URL prefix of the fingerprinted asset URLs of the assetPath function (default: /assets/).
+//   - WithIconsDir: sets the directory of the SVG files of the icon function (default: icons).
+//   - WithIconSprite: renders icons as references to the symbols of the sprite rendered by iconSprite.
+//   - WithTimezoneResolver: resolves the time zone of the viewer of the request, used by .View.Location and inTZ.
+//   - WithRenderTracer: traces renders, e.g. with OpenTelemetry spans.
+//   - WithLazyTemplates: compiles the pages of the default html adapter on their first render instead of at startup.
+//   - WithOnMissingTemplate: handles renders of missing templates in the default html adapter, e.g. with a 404 page.
+//   - WithRenderCache: sets the render cache of the default html adapter.
+//   - WithSessionProvider: reads session values for templates (see Data.Session), e.g. from scs or gorilla/sessions.
+//   - WithTenantResolver: resolves the tenant of a request, so that tenant templates are preferred.
 //   - WithLogger: sets an initial logger to use for the HyperView instance. If not set, a default logger is created when the HyperView instance is created.
 //   - WithViewAdapter: sets a view adapter to use for the view service. If no view adapters are set, the default adapters are used. Default adapters
-//     use html/template for html templates and json for json templates.
+//     use html/template for html templates, goldmark for markdown files (rendered within the html layouts), a node adapter for gomponents trees,
+//     a proto adapter for Protocol Buffers messages, a file adapter for streamed bodies and files, and json and yaml for data responses.
 func NewHyperView(options ...Option) (*HyperView, error) {
 	hgo := &HyperView{
-		adapters:      make(map[string]Adapter),
 		baseLayout:    "base",
 		systemLayout:  "base",
 		filesystemMap: nil,
 		funcMap:       nil,
 		logger:        nil,
+		tenants:       make(map[string]fs.FS),
+		assetsURL:     defaultAssetsURL,
+		iconsDir:      constants.IconsDir,
+		done:          make(chan struct{}),
 	}
 
 	// Apply options
@@ -72,14 +181,34 @@ func NewHyperView(options ...Option) (*HyperView, error) {
 		}))
 	}
 
+	manifest, err := hgo.assetManifest()
+	if err != nil {
+		return nil, err
+	}
+	assets := hgo.assetsFS()
+	hgo.assetPaths = funcs.NewAssetPaths(assets, hgo.assetsURL)
+	hgo.assetPaths.SetManifest(manifest)
+	hgo.assetInliner = funcs.NewAssetInliner(assets)
+	hgo.icons = funcs.NewIcons(hgo.iconsFS(), hgo.iconSprite)
+
 	if err := hgo.MaybeRegisterDefaultAdapters(); err != nil {
 		return nil, fmt.Errorf("error registering default adapters: %w", err)
 	}
 
+	// Publish the stats only once the instance is complete, so that failed instances are not counted
+	if hgo.expvar {
+		publishExpvar(hgo)
+	}
+
+	if len(hgo.devReloadDirs) > 0 {
+		go hgo.watchTemplates(hgo.templatesFingerprint())
+	}
+
 	return hgo, nil
 }
 
-// WithLayouts sets the base and system layouts for the view service.
+// WithLayouts sets the base and system layouts for the view service. Adapters can override them when they are
+// registered (see WithAdapterLayouts).
 func WithLayouts(base, system string) Option {
 	return func(hgo *HyperView) error {
 		hgo.baseLayout = base
@@ -88,8 +217,8 @@ func WithLayouts(base, system string) Option {
 	}
 }
 
-// WithFuncMap sets an initial function map to use for the template engine.
-// Additional functions can be added later via Plugin options.
+// WithFuncMap sets the functions added to the built-in template functions of the default template adapter.
+// The adapter works on its own copy, so the map is not modified and can be shared with other adapters.
 func WithFuncMap(funcs template.FuncMap) Option {
 	return func(hgo *HyperView) error {
 		hgo.funcMap = funcs
@@ -105,6 +234,97 @@ func WithBaseTemplateFS(efs *embed.FS) Option {
 	}
 }
 
+// WithTemplateFS sets an initial template and assets filesystem to use for the template engine. Unlike
+// WithBaseTemplateFS, it accepts any fs.FS, such as os.DirFS for loading templates from disk during development.
+func WithTemplateFS(fsys fs.FS) Option {
+	return func(hgo *HyperView) error {
+		hgo.filesystemMap = map[string]fs.FS{constants.RootFSID: fsys}
+		return nil
+	}
+}
+
+// WithTemplateFSOverlay sets an ordered list of template and assets filesystems, from the highest priority to the
+// lowest. Templates and partials with the same path in a higher-priority filesystem shadow those in lower-priority
+// filesystems, e.g. WithTemplateFSOverlay(appFS, pluginFS) lets the app override any template of the plugin.
+func WithTemplateFSOverlay(layers ...fs.FS) Option {
+	return func(hgo *HyperView) error {
+		hgo.filesystemMap = map[string]fs.FS{constants.RootFSID: NewOverlayFS(layers...)}
+		return nil
+	}
+}
+
+// WithHtmxPartialMode renders HTMX requests (but not boosted or history restore requests) without wrapping them in the
+// layout, so that handlers don't need separate partial templates. Only the named template is rendered, which defaults
+// to "page:main" if empty. Individual responses can override this behavior with Response.FullPage or
+// Response.PartialOnly.
+func WithHtmxPartialMode(template string) Option {
+	return func(hgo *HyperView) error {
+		hgo.htmxPartial = true
+		hgo.partialName = template
+		return nil
+	}
+}
+
+// WithETags sets an ETag computed from the rendered output on successful responses of the default html and json
+// adapters, and responds with 304 Not Modified when the If-None-Match header of the request matches.
+func WithETags() Option {
+	return func(hgo *HyperView) error {
+		hgo.etags = true
+		return nil
+	}
+}
+
+// WithDevErrors renders template parse and execution errors of the default html adapter as a developer error page. See
+// TemplateViewAdapterOptions.DevErrors.
+func WithDevErrors() Option {
+	return func(hgo *HyperView) error {
+		hgo.devErrors = true
+		return nil
+	}
+}
+
+// WithLazyTemplates compiles the pages of the default html adapter on their first render instead of at startup. See
+// TemplateViewAdapterOptions.LazyCompile.
+func WithLazyTemplates() Option {
+	return func(hgo *HyperView) error {
+		hgo.lazyTemplates = true
+		return nil
+	}
+}
+
+// WithOnMissingTemplate sets the function that handles renders of template paths that do not exist in the default
+// html adapter. See TemplateViewAdapterOptions.OnMissingTemplate.
+func WithOnMissingTemplate(fn MissingTemplateFunc) Option {
+	return func(hgo *HyperView) error {
+		hgo.onMissing = fn
+		return nil
+	}
+}
+
+// WithRenderCache sets the render cache of the default html adapter, which caches the output of the cache template
+// function and of responses with a cache key (see Response.Cache).
+func WithRenderCache(store cache.Store) Option {
+	return func(hgo *HyperView) error {
+		hgo.renderCache = store
+		return nil
+	}
+}
+
+// WithDefaultResponseHeaders sets headers that are added to every rendered response, including system pages, such as
+// X-Content-Type-Options or a standard Cache-Control. Headers that are already set on the ResponseWriter (e.g. by a
+// middleware) are kept, and headers of the response (see Response.Header) replace the defaults.
+func WithDefaultResponseHeaders(header http.Header) Option {
+	return func(hgo *HyperView) error {
+		hgo.defaultHeaders = make(http.Header, len(header))
+		for key, values := range header {
+			for _, value := range values {
+				hgo.defaultHeaders.Add(key, value)
+			}
+		}
+		return nil
+	}
+}
+
 // WithLogger sets an initial logger to use for the HyperView instance. If not set, a default logger is created when the HyperView instance is created.
 func WithLogger(logger *slog.Logger) Option {
 	return func(hgo *HyperView) error {
@@ -114,9 +334,9 @@ func WithLogger(logger *slog.Logger) Option {
 }
 
 // WithViewAdapter sets a view adapter to use for the view service. If no view adapters are set, the default adapters are used.
-func WithViewAdapter(name string, adapter Adapter) Option {
+func WithViewAdapter(name string, adapter Adapter, opts ...AdapterOption) Option {
 	return func(hgo *HyperView) error {
-		return hgo.RegisterAdapter(name, adapter)
+		return hgo.RegisterAdapter(name, adapter, opts...)
 	}
 }
 
@@ -125,17 +345,45 @@ func (s *HyperView) Logger() *slog.Logger {
 	return s.logger
 }
 
+// templateFuncs returns the functions of the default template adapter: the asset functions, the translation
+// functions, if a translator is set, the sanitizeHTML function of the sanitizer, if one is set, and the functions of
+// WithFuncMap.
+func (s *HyperView) templateFuncs() template.FuncMap {
+	funcMap := make(template.FuncMap, len(s.funcMap)+8)
+	funcMap["assetPath"] = s.assetPaths.Path
+	funcMap["inlineCSS"] = s.assetInliner.CSS
+	funcMap["inlineJS"] = s.assetInliner.JS
+	funcMap["icon"] = s.icons.Icon
+	funcMap["iconSprite"] = s.icons.Sprite
+	if s.translator != nil {
+		maps.Copy(funcMap, i18n.Funcs(s.translator))
+	}
+	if s.sanitizer != nil {
+		funcMap["sanitizeHTML"] = funcs.SanitizeHTMLFunc(s.sanitizer)
+	}
+	maps.Copy(funcMap, s.funcMap)
+	return funcMap
+}
+
 // MaybeRegisterDefaultAdapters registers the built-in adapters for
-// using html/template for html templates and json for json templates, but only
+// using html/template for html templates, goldmark for markdown files, nodes (e.g. gomponents) and Protocol Buffers
+// messages set on the response, and json and yaml for data responses, but only
 // if they are not already registered. The ext parameter is used to determine the file extension for the html template adapter.
 func (s *HyperView) MaybeRegisterDefaultAdapters() error {
 	// Check if the html adapter is already registered
-	if _, ok := s.adapters["html"]; !ok {
+	if _, ok := s.Adapter("html"); !ok {
 		tempAdapter := NewTemplateViewAdapter(TemplateViewAdapterOptions{
-			Extension:     ".html",
-			FileSystemMap: s.filesystemMap,
-			Funcs:         s.funcMap,
-			Logger:        s.logger,
+			Extension:         ".html",
+			FileSystemMap:     s.filesystemMap,
+			Funcs:             s.templateFuncs(),
+			Logger:            s.logger,
+			PartialTemplate:   s.partialName,
+			Cache:             s.renderCache,
+			ETags:             s.etags,
+			DevErrors:         s.devErrors,
+			LazyCompile:       s.lazyTemplates,
+			Metrics:           s.metrics,
+			OnMissingTemplate: s.onMissing,
 		})
 
 		if err := s.RegisterAdapter("html", tempAdapter); err != nil {
@@ -143,30 +391,136 @@ func (s *HyperView) MaybeRegisterDefaultAdapters() error {
 		}
 	}
 
+	// Check if the markdown adapter is already registered. It uses the layouts of the html adapter.
+	if _, ok := s.Adapter("md"); !ok {
+		if tempAdapter, ok := s.adapterMap()["html"].(*TemplateAdapter); ok {
+			mdAdapter := NewMarkdownAdapter(MarkdownAdapterOptions{
+				FileSystemMap: s.filesystemMap,
+				Logger:        s.logger,
+				Templates:     tempAdapter,
+			})
+
+			if err := s.RegisterAdapter("md", mdAdapter); err != nil {
+				return fmt.Errorf("error registering default Markdown adapter: %w", err)
+			}
+		}
+	}
+
 	// Check if the json adapter is already registered
-	if _, ok := s.adapters["json"]; !ok {
-		jsonAdapter := NewJSONViewAdapter()
+	if _, ok := s.Adapter("json"); !ok {
+		var jsonOpts []JSONAdapterOption
+		if s.etags {
+			jsonOpts = append(jsonOpts, WithJSONETags())
+		}
+
+		jsonAdapter := NewJSONViewAdapter(jsonOpts...)
 		if err := s.RegisterAdapter("json", jsonAdapter); err != nil {
 			return fmt.Errorf("error registering default JSON adapter: %w", err)
 		}
 	}
 
+	// Check if the node adapter is already registered
+	if _, ok := s.Adapter("node"); !ok {
+		nodeAdapter := NewNodeAdapter(NodeAdapterOptions{})
+		if err := s.RegisterAdapter("node", nodeAdapter); err != nil {
+			return fmt.Errorf("error registering default node adapter: %w", err)
+		}
+	}
+
+	// Check if the proto adapter is already registered
+	if _, ok := s.Adapter("proto"); !ok {
+		protoAdapter := NewProtoAdapter()
+		if err := s.RegisterAdapter("proto", protoAdapter); err != nil {
+			return fmt.Errorf("error registering default proto adapter: %w", err)
+		}
+	}
+
+	// Check if the file adapter is already registered
+	if _, ok := s.Adapter("file"); !ok {
+		fileAdapter := NewFileAdapter(FileAdapterOptions{Logger: s.logger})
+		if err := s.RegisterAdapter("file", fileAdapter); err != nil {
+			return fmt.Errorf("error registering default file adapter: %w", err)
+		}
+	}
+
+	// Check if the yaml adapter is already registered
+	if _, ok := s.Adapter("yaml"); !ok {
+		yamlAdapter := NewYAMLViewAdapter()
+		if err := s.RegisterAdapter("yaml", yamlAdapter); err != nil {
+			return fmt.Errorf("error registering default YAML adapter: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// RegisterAdapter registers a new view adapter with the view service
-func (s *HyperView) RegisterAdapter(name string, adapter Adapter) error {
+// RegisterAdapter registers a new view adapter with the view service. The adapter is initialized (and the registered
+// tenants are added to it) before it is used for rendering, and it is not registered if that fails. The options
+// configure the registration, such as the layouts of the adapter (see WithAdapterLayouts).
+func (s *HyperView) RegisterAdapter(name string, adapter Adapter, opts ...AdapterOption) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.adapters[name] = adapter
-	return s.adapters[name].Init()
+	if err := adapter.Init(); err != nil {
+		return err
+	}
+
+	// Register any existing tenants with the new adapter
+	if ta, ok := adapter.(TenantAdapter); ok {
+		for id, fsys := range s.tenants {
+			if err := ta.AddFS(id, fsys); err != nil {
+				return fmt.Errorf("error registering tenant %s: %w", id, err)
+			}
+		}
+	}
+
+	var config adapterConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	// Replace the adapters with a copy, so that lookups never need a lock
+	registry := &adapterRegistry{adapters: maps.Clone(s.adapterMap()), layouts: make(map[string]adapterConfig)}
+	if registry.adapters == nil {
+		registry.adapters = make(adapterMap, 1)
+	}
+	if current := s.adapters.Load(); current != nil {
+		maps.Copy(registry.layouts, current.layouts)
+	}
+	registry.adapters[name] = adapter
+	registry.layouts[name] = config
+	s.adapters.Store(registry)
+
+	return nil
+}
+
+// InvalidateCacheTags removes the output tagged with any of the tags from the render cache (see WithRenderCache and
+// Response.CacheTags). If the render cache store is shared with a CacheHandler, its cached pages are removed as well.
+func (s *HyperView) InvalidateCacheTags(ctx context.Context, tags ...string) error {
+	if s.renderCache == nil {
+		return nil
+	}
+	return s.renderCache.DeleteTags(ctx, tags...)
 }
 
 // Reinit reinitialize the view service adapters. This is useful for reloading templates after they have changed.
 func (s *HyperView) Reinit() error {
+	err := s.reinit()
+	if s.expvar && err == nil {
+		expvarReinit(time.Now())
+	}
+	if observer, ok := s.metrics.(ReinitObserver); ok {
+		observer.ObserveReinit(err)
+	}
+	return err
+}
+
+func (s *HyperView) reinit() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	for _, adapter := range s.adapters {
+	s.assetPaths.Reset()
+	s.assetInliner.Reset()
+	s.icons.Reset()
+	for _, adapter := range s.adapterMap() {
 		// s.logger.Debug("Reinitializing view adapter", slog.String("adapter", fmt.Sprintf("%T", adapter)))
 		if err := adapter.Init(); err != nil {
 			return err
@@ -175,50 +529,148 @@ func (s *HyperView) Reinit() error {
 	return nil
 }
 
-// Adapter returns the view adapter with the specified name
+// Adapter returns the view adapter with the specified name. It does not lock, so it is safe to call on every request.
 func (s *HyperView) Adapter(name string) (Adapter, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	adapter, ok := s.adapters[name]
+	adapter, ok := s.adapterMap()[name]
 	return adapter, ok
 }
 
-// Render renders the specified opts with the provided adapter key
-func (s *HyperView) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
-	// First, find an extension if there is one
-	ext := ""
-	if idx := strings.LastIndex(resp.TemplatePath(), "."); idx != -1 {
-		ext = resp.TemplatePath()[idx:]
-		resp.Path(resp.TemplatePath()[:idx])
+// adapterMap returns the registered adapters. The map must not be modified, RegisterAdapter replaces it instead.
+func (s *HyperView) adapterMap() adapterMap {
+	if registry := s.adapters.Load(); registry != nil {
+		return registry.adapters
 	}
+	return nil
+}
 
-	// If the resp has a content-type header of application/json, use the json adapter
-	if resp.HTTPHeader().Get("Content-Type") == "application/json" {
-		s.RenderAs(w, r, "json", resp)
-		return
+// layoutsFor returns the base and system layouts of the adapter, falling back to the layouts of the view service.
+func (s *HyperView) layoutsFor(adapterKey string) (base, system string) {
+	if adapterKey == "" {
+		adapterKey = "html"
 	}
 
-	// If the extension is empty or .html, use the html adapter
-	if ext == "" || ext == ".html" {
-		s.RenderAs(w, r, "html", resp)
-		return
+	base, system = s.baseLayout, s.systemLayout
+	registry := s.adapters.Load()
+	if registry == nil {
+		return base, system
 	}
 
-	// Otherwise, use the specified extension
-	s.RenderAs(w, r, ext[1:], resp)
+	config := registry.layouts[adapterKey]
+	if config.baseLayout != "" {
+		base = config.baseLayout
+	}
+	if config.systemLayout != "" {
+		system = config.systemLayout
+	}
+	return base, system
+}
+
+// Render renders the specified opts with the provided adapter key
+func (s *HyperView) Render(w http.ResponseWriter, r *http.Request, resp *response.Response) {
+	s.RenderAs(w, r, s.adapterKeyFor(r, resp), resp)
 }
 
 // RenderAs renders the specified opts with the provided adapter key
 func (s *HyperView) RenderAs(w http.ResponseWriter, r *http.Request, adapterKey string, resp *response.Response) {
+	s.resolveAlias(resp)
+	s.setDefaultHeaders(w)
+
+	if resp.IsNoContent() {
+		writeNoContent(w, resp)
+		return
+	}
+
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		// If there is no layout set, set the base layout
+		r = s.withTimezoneContext(s.withLocaleContext(s.withCanonical(s.withSession(s.withTenant(r)))))
+		s.withVariant(r, adapter, resp)
+		s.withLocale(r, adapter, resp)
+		s.withFormFlash(w, r, resp)
+
+		// If there is no layout set, set the base layout of the adapter
 		if resp.TemplateLayout() == "" {
-			resp.Layout(s.baseLayout)
+			base, _ := s.layoutsFor(adapterKey)
+			resp.Layout(base)
+		}
+
+		// In HTMX partial mode, render HTMX requests without the layout unless the response overrides it. History
+		// restore requests replace the whole page, so they get the layout.
+		if s.htmxPartial && resp.PageMode() == response.PageModeDefault {
+			resp.Vary(htmx.HXRequest, htmx.HXBoosted, htmx.HXHistoryRestoreRequest)
+			if htmx.IsHtmxRequest(r) && !htmx.IsHistoryRestoreRequest(r) {
+				resp.PartialOnly()
+			}
+		}
+
+		// Fragments are typically selected by the target of the HTMX request
+		if resp.TemplateFragment() != "" {
+			resp.Vary(htmx.HXRequest, htmx.HXTarget)
+		}
+		addVary(w.Header(), resp.VaryHeaders()...)
+
+		if !s.observed() {
+			adapter.Render(w, r, resp)
+			return
 		}
-		adapter.Render(w, r, resp)
+
+		_ = s.observe(r, adapterKey, resp, func(r *http.Request) (int, error) {
+			sw := &statusWriter{ResponseWriter: w}
+			r, re := withRenderError(r)
+			adapter.Render(sw, r, resp)
+			return sw.Status(), re.err
+		})
 	}
 }
 
+// RenderToWriter renders the response to the given io.Writer using the same adapter selection as Render. The
+// selected adapter must implement WriterRenderer. Headers and the status code of the response are ignored.
+func (s *HyperView) RenderToWriter(wr io.Writer, r *http.Request, resp *response.Response) error {
+	return s.RenderToWriterAs(wr, r, s.adapterKeyFor(r, resp), resp)
+}
+
+// RenderToWriterAs renders the response to the given io.Writer with the provided adapter key.
+func (s *HyperView) RenderToWriterAs(wr io.Writer, r *http.Request, adapterKey string, resp *response.Response) error {
+	s.resolveAlias(resp)
+
+	adapter, ok := s.Adapter(adapterKey)
+	if !ok {
+		return fmt.Errorf("adapter not found: %s", adapterKey)
+	}
+
+	renderer, ok := adapter.(WriterRenderer)
+	if !ok {
+		return fmt.Errorf("adapter %s does not support rendering to a writer", adapterKey)
+	}
+
+	if resp.TemplateLayout() == "" {
+		base, _ := s.layoutsFor(adapterKey)
+		resp.Layout(base)
+	}
+
+	r = s.withTimezoneContext(s.withLocaleContext(s.withCanonical(s.withSession(s.withTenant(r)))))
+	s.withVariant(r, adapter, resp)
+	s.withLocale(r, adapter, resp)
+	if !s.observed() {
+		return renderer.RenderToWriter(wr, r, resp)
+	}
+
+	return s.observe(r, adapterKey, resp, func(r *http.Request) (int, error) {
+		if err := renderer.RenderToWriter(wr, r, resp); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return resp.StatusCode(), nil
+	})
+}
+
+// RenderToString renders the response and returns the output as a string. See RenderToWriter for details.
+func (s *HyperView) RenderToString(r *http.Request, resp *response.Response) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := s.RenderToWriter(buf, r, resp); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 // RenderNotFound renders a 404 not found page
 func (s *HyperView) RenderNotFound(w http.ResponseWriter, r *http.Request) {
 	s.RenderNotFoundAs(w, r, "html")
@@ -226,8 +678,9 @@ func (s *HyperView) RenderNotFound(w http.ResponseWriter, r *http.Request) {
 
 // RenderNotFoundAs renders a 404 not found page as the specified adapter
 func (s *HyperView) RenderNotFoundAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderNotFound(w, r, s.NewSystemResponse().StatusNotFound())
+		adapter.RenderNotFound(w, r, s.newSystemResponse(adapterKey).StatusNotFound())
 	}
 }
 
@@ -239,8 +692,9 @@ func (s *HyperView) RenderSystemError(w http.ResponseWriter, r *http.Request, er
 // RenderSystemErrorAs renders a system error page as the specified adapter
 func (s *HyperView) RenderSystemErrorAs(w http.ResponseWriter, r *http.Request, adapterKey string, err error) {
 	s.logger.Error("Server error", slog.String("err", err.Error()))
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderSystemError(w, r, err, s.NewSystemResponse().StatusError())
+		adapter.RenderSystemError(w, r, err, s.newSystemResponse(adapterKey).StatusError())
 	}
 }
 
@@ -251,8 +705,9 @@ func (s *HyperView) RenderMaintenance(w http.ResponseWriter, r *http.Request) {
 
 // RenderMaintenanceAs renders a maintenance page as the specified adapter
 func (s *HyperView) RenderMaintenanceAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderMaintenance(w, r, s.NewSystemResponse().Status(http.StatusServiceUnavailable))
+		adapter.RenderMaintenance(w, r, s.newSystemResponse(adapterKey).Status(http.StatusServiceUnavailable))
 	}
 }
 
@@ -263,8 +718,9 @@ func (s *HyperView) RenderForbidden(w http.ResponseWriter, r *http.Request) {
 
 // RenderForbiddenAs renders a forbidden page as the specified adapter
 func (s *HyperView) RenderForbiddenAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderForbidden(w, r, s.NewSystemResponse().StatusForbidden())
+		adapter.RenderForbidden(w, r, s.newSystemResponse(adapterKey).StatusForbidden())
 	}
 }
 
@@ -275,8 +731,9 @@ func (s *HyperView) RenderMethodNotAllowed(w http.ResponseWriter, r *http.Reques
 
 // RenderMethodNotAllowedAs renders a method not allowed page as the specified adapter
 func (s *HyperView) RenderMethodNotAllowedAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderMethodNotAllowed(w, r, s.NewSystemResponse().Status(http.StatusMethodNotAllowed))
+		adapter.RenderMethodNotAllowed(w, r, s.newSystemResponse(adapterKey).Status(http.StatusMethodNotAllowed))
 	}
 }
 
@@ -287,8 +744,101 @@ func (s *HyperView) RenderUnauthorized(w http.ResponseWriter, r *http.Request) {
 
 // RenderUnauthorizedAs renders an unauthorized page as the specified adapter
 func (s *HyperView) RenderUnauthorizedAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.setDefaultHeaders(w)
 	if adapter, ok := s.adapterFor(w, adapterKey); ok {
-		adapter.RenderUnauthorized(w, r, s.NewSystemResponse().StatusUnauthorized())
+		adapter.RenderUnauthorized(w, r, s.newSystemResponse(adapterKey).StatusUnauthorized())
+	}
+}
+
+// RenderStatus renders the system page of the status code
+func (s *HyperView) RenderStatus(w http.ResponseWriter, r *http.Request, status int) {
+	s.RenderStatusAs(w, r, "html", status)
+}
+
+// RenderStatusAs renders the system page of the status code as the specified adapter. Status codes with a system
+// renderer of the Adapter interface (401, 403, 404, 405, 500, and 503) use it. Other status codes are rendered by
+// adapters that implement StatusRenderer (e.g. views/system/429 of the TemplateAdapter), or as a plain text error.
+func (s *HyperView) RenderStatusAs(w http.ResponseWriter, r *http.Request, adapterKey string, status int) {
+	if status == http.StatusInternalServerError {
+		s.RenderSystemErrorAs(w, r, adapterKey, errors.New(http.StatusText(status)))
+		return
+	}
+	s.setDefaultHeaders(w)
+	if adapter, ok := s.adapterFor(w, adapterKey); ok {
+		renderStatus(w, r, adapter, s.newSystemResponse(adapterKey).Status(status))
+	}
+}
+
+// RenderBadRequest renders a 400 bad request page
+func (s *HyperView) RenderBadRequest(w http.ResponseWriter, r *http.Request) {
+	s.RenderBadRequestAs(w, r, "html")
+}
+
+// RenderBadRequestAs renders a 400 bad request page as the specified adapter
+func (s *HyperView) RenderBadRequestAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.RenderStatusAs(w, r, adapterKey, http.StatusBadRequest)
+}
+
+// RenderConflict renders a 409 conflict page
+func (s *HyperView) RenderConflict(w http.ResponseWriter, r *http.Request) {
+	s.RenderConflictAs(w, r, "html")
+}
+
+// RenderConflictAs renders a 409 conflict page as the specified adapter
+func (s *HyperView) RenderConflictAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.RenderStatusAs(w, r, adapterKey, http.StatusConflict)
+}
+
+// RenderGone renders a 410 gone page
+func (s *HyperView) RenderGone(w http.ResponseWriter, r *http.Request) {
+	s.RenderGoneAs(w, r, "html")
+}
+
+// RenderGoneAs renders a 410 gone page as the specified adapter
+func (s *HyperView) RenderGoneAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.RenderStatusAs(w, r, adapterKey, http.StatusGone)
+}
+
+// RenderUnprocessable renders a 422 unprocessable entity page
+func (s *HyperView) RenderUnprocessable(w http.ResponseWriter, r *http.Request) {
+	s.RenderUnprocessableAs(w, r, "html")
+}
+
+// RenderUnprocessableAs renders a 422 unprocessable entity page as the specified adapter
+func (s *HyperView) RenderUnprocessableAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.RenderStatusAs(w, r, adapterKey, http.StatusUnprocessableEntity)
+}
+
+// RenderTooManyRequests renders a 429 too many requests page. Set the Retry-After header before rendering to tell
+// clients when to retry
+func (s *HyperView) RenderTooManyRequests(w http.ResponseWriter, r *http.Request) {
+	s.RenderTooManyRequestsAs(w, r, "html")
+}
+
+// RenderTooManyRequestsAs renders a 429 too many requests page as the specified adapter
+func (s *HyperView) RenderTooManyRequestsAs(w http.ResponseWriter, r *http.Request, adapterKey string) {
+	s.RenderStatusAs(w, r, adapterKey, http.StatusTooManyRequests)
+}
+
+// renderStatus renders the response with the system renderer of its status code.
+func renderStatus(w http.ResponseWriter, r *http.Request, adapter Adapter, resp *response.Response) {
+	switch status := resp.StatusCode(); status {
+	case http.StatusNotFound:
+		adapter.RenderNotFound(w, r, resp)
+	case http.StatusForbidden:
+		adapter.RenderForbidden(w, r, resp)
+	case http.StatusUnauthorized:
+		adapter.RenderUnauthorized(w, r, resp)
+	case http.StatusMethodNotAllowed:
+		adapter.RenderMethodNotAllowed(w, r, resp)
+	case http.StatusServiceUnavailable:
+		adapter.RenderMaintenance(w, r, resp)
+	default:
+		if renderer, ok := adapter.(StatusRenderer); ok {
+			renderer.RenderStatus(w, r, resp)
+			return
+		}
+		http.Error(w, http.StatusText(status), status)
 	}
 }
 
@@ -305,6 +855,10 @@ func (s *HyperView) Redirect(w http.ResponseWriter, r *http.Request, url string)
 	if htmx.IsHtmxRequest(r) {
 		s.HxRedirect(w, url)
 		return
+	} else if unpoly.IsUnpolyRequest(r) {
+		// Unpoly follows redirects, so use 303 See Other to ensure that form submissions are followed with a GET
+		http.Redirect(w, r, url, http.StatusSeeOther)
+		return
 	} else if request.IsXMLHttpRequest(r) {
 		// Create a JSON response with a redirect
 		w.Header().Set("Content-Type", "application/json")
@@ -322,16 +876,86 @@ func (s *HyperView) Redirect(w http.ResponseWriter, r *http.Request, url string)
 	http.Redirect(w, r, url, http.StatusFound)
 }
 
+// RedirectBack redirects to the URL to return to (see request.ReturnTo), such as the page that sent the user to the
+// login form, or to the fallback if there is no same-origin URL to return to.
+func (s *HyperView) RedirectBack(w http.ResponseWriter, r *http.Request, fallback string) {
+	s.Redirect(w, r, request.ReturnTo(r, fallback))
+}
+
 // NewResponse creates a new response with the given layout
 func (s *HyperView) NewResponse(layout string) *response.Response {
 	return response.NewResponse().Layout(layout)
 }
 
+// NewResponseFrom creates a new response from a prototype response (see Response.Clone), so that default headers,
+// layouts, and cache policies can be defined once and shared by handlers. The base is not changed by changes to the
+// new response. If the base is nil, a new response with the base layout is created.
+func (s *HyperView) NewResponseFrom(base *response.Response) *response.Response {
+	if base == nil {
+		return s.NewResponse(s.baseLayout)
+	}
+	return base.Clone()
+}
+
 // NewSystemResponse creates a new response with the system layout
 func (s *HyperView) NewSystemResponse() *response.Response {
 	return response.NewResponse().Layout(s.systemLayout)
 }
 
+// newSystemResponse creates a new response with the system layout of the adapter.
+func (s *HyperView) newSystemResponse(adapterKey string) *response.Response {
+	_, system := s.layoutsFor(adapterKey)
+	return response.NewResponse().Layout(system)
+}
+
+// adapterKeyFor returns the adapter key to use for the response. The key is determined by the extension of the
+// template path (which is stripped from the path), by a body, file, node, Protocol Buffers message, or raw JSON value
+// set on the response, by a Content-Type header of application/json, or by an Accept header of the request that
+// prefers YAML.
+func (s *HyperView) adapterKeyFor(r *http.Request, resp *response.Response) string {
+	s.resolveAlias(resp)
+
+	// First, find an extension if there is one
+	ext := ""
+	if idx := strings.LastIndex(resp.TemplatePath(), "."); idx != -1 {
+		ext = resp.TemplatePath()[idx:]
+		resp.Path(resp.TemplatePath()[:idx])
+	}
+
+	// If the resp has a body or file to stream, use the file adapter
+	if resp.BodyReader() != nil || resp.FilePath() != "" {
+		return "file"
+	}
+
+	// If the resp has a node, such as a gomponents tree, use the node adapter
+	if resp.TemplateNode() != nil {
+		return "node"
+	}
+
+	// If the resp has a Protocol Buffers message, use the proto adapter
+	if resp.ProtoMessage() != nil {
+		return "proto"
+	}
+
+	// If the resp has a raw JSON value or a content-type header of application/json, use the json adapter
+	if resp.RawJSONValue() != nil || resp.HTTPHeader().Get("Content-Type") == "application/json" {
+		return "json"
+	}
+
+	// If the extension is .yml or the request accepts YAML, use the yaml adapter
+	if ext == ".yml" || (ext == "" && request.AcceptsYAML(r)) {
+		return "yaml"
+	}
+
+	// If the extension is empty or .html, use the html adapter
+	if ext == "" || ext == ".html" {
+		return "html"
+	}
+
+	// Otherwise, use the specified extension
+	return ext[1:]
+}
+
 // adapterFor returns the adapter for the specified key
 func (s *HyperView) adapterFor(w http.ResponseWriter, key string) (Adapter, bool) {
 	if key == "" {
@@ -1,12 +1,15 @@
 package hyperview_test
 
 import (
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
 	"github.com/hypergopher/hyperview/response"
 )
 
@@ -122,6 +125,15 @@ func TestViewService_Redirect(t *testing.T) {
 				"Content-Type": []string{"application/json"},
 			},
 		},
+		{
+			name:       "Unpoly request",
+			request:    httptest.NewRequest("POST", "/", nil),
+			url:        "https://example.com",
+			wantStatus: http.StatusSeeOther,
+			wantHeader: http.Header{
+				"Location": []string{"https://example.com"},
+			},
+		},
 		{
 			name:       "default request",
 			request:    httptest.NewRequest("GET", "/", nil),
@@ -138,6 +150,8 @@ func TestViewService_Redirect(t *testing.T) {
 				tt.request.Header.Set("HX-Request", "true")
 			} else if tt.name == "XMLHttpRequest" {
 				tt.request.Header.Set("X-Requested-With", "XMLHttpRequest")
+			} else if tt.name == "Unpoly request" {
+				tt.request.Header.Set("X-Up-Version", "3.9.0")
 			}
 
 			rr := httptest.NewRecorder()
@@ -255,3 +269,505 @@ func TestViewService_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestViewService_RenderStatus(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Page not found{{end}}`)},
+		"views/system/429.html": {Data: []byte(`{{define "page:main"}}Slow down{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	if err := hv.RegisterAdapter("mock", &mockViewAdapter{}); err != nil {
+		t.Fatalf("RegisterAdapter() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		adapter    string
+		status     int
+		wantStatus int
+		wantBody   string
+	}{
+		{"system renderer", "html", http.StatusNotFound, http.StatusNotFound, "Page not found"},
+		{"system template", "html", http.StatusTooManyRequests, http.StatusTooManyRequests, "Slow down"},
+		{"without system template", "html", http.StatusGone, http.StatusGone, "Gone"},
+		{"json", "json", http.StatusConflict, http.StatusConflict, `"message": "Conflict"`},
+		{"json server error", "json", http.StatusBadGateway, http.StatusBadGateway, `"status": "error"`},
+		{"without status renderer", "mock", http.StatusBadRequest, http.StatusBadRequest, "Bad Request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.RenderStatusAs(w, httptest.NewRequest("GET", "/", nil), tt.adapter, tt.status)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestViewService_RenderStatusRenderers(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fstest.MapFS{}))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		render func(w http.ResponseWriter, r *http.Request)
+		want   int
+	}{
+		{"bad request", hv.RenderBadRequest, http.StatusBadRequest},
+		{"conflict", hv.RenderConflict, http.StatusConflict},
+		{"gone", hv.RenderGone, http.StatusGone},
+		{"unprocessable", hv.RenderUnprocessable, http.StatusUnprocessableEntity},
+		{"too many requests", hv.RenderTooManyRequests, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.render(w, httptest.NewRequest("GET", "/", nil))
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewService_RenderVary(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}<main>{{template "page:main" .}}</main>{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Home{{end}}{{define "row"}}Row{{end}}`)},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []hyperview.Option
+		resp     func(r *http.Request) *response.Response
+		vary     string
+		wantVary []string
+		wantBody string
+	}{
+		{"no htmx rendering", nil, func(r *http.Request) *response.Response {
+			return response.NewResponse().Path("home")
+		}, "", nil, "<main>Home</main>"},
+		{"partial mode", []hyperview.Option{hyperview.WithHtmxPartialMode("")}, func(r *http.Request) *response.Response {
+			return response.NewResponse().Path("home")
+		}, "", []string{"Hx-Request", "Hx-Boosted", "Hx-History-Restore-Request"}, "<main>Home</main>"},
+		{"partial mode with full page", []hyperview.Option{hyperview.WithHtmxPartialMode("")}, func(r *http.Request) *response.Response {
+			return response.NewResponse().Path("home").FullPage()
+		}, "", nil, "<main>Home</main>"},
+		{"fragment", nil, func(r *http.Request) *response.Response {
+			return response.NewResponse().Path("home").Fragment("row")
+		}, "", []string{"Hx-Request", "Hx-Target"}, "Row"},
+		{"htmx layout", nil, func(r *http.Request) *response.Response {
+			return response.NewResponse().Path("home").HxLayout(r, "base", "base")
+		}, "Accept-Encoding", []string{"Accept-Encoding", "Hx-Request", "Hx-Boosted"}, "<main>Home</main>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append([]hyperview.Option{hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base")}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			if tt.vary != "" {
+				w.Header().Set("Vary", tt.vary)
+			}
+			hv.Render(w, r, tt.resp(r))
+
+			if got := w.Header().Values("Vary"); strings.Join(got, ",") != strings.Join(tt.wantVary, ",") {
+				t.Errorf("Vary = %v, want %v", got, tt.wantVary)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestViewService_RenderHtmxPartialMode(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}<main>{{template "page:main" .}}</main>{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}<p>hi</p>{{end}}`)},
+	}
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base"),
+		hyperview.WithHtmxPartialMode(""))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		wantBody string
+	}{
+		{"page", nil, "<main><p>hi</p></main>"},
+		{"htmx", map[string]string{"HX-Request": "true"}, "<p>hi</p>"},
+		{"boosted", map[string]string{"HX-Request": "true", "HX-Boosted": "true"}, "<main><p>hi</p></main>"},
+		{"history restore", map[string]string{"HX-Request": "true", "HX-History-Restore-Request": "true"}, "<main><p>hi</p></main>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path("home"))
+
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestViewService_AdapterDuringRegistration(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if err := hv.RegisterAdapter("mock", &mockViewAdapter{}); err != nil {
+				t.Errorf("RegisterAdapter() error = %v", err)
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			if _, ok := hv.Adapter("mock"); !ok {
+				t.Error("Adapter() did not return the registered adapter")
+			}
+			return
+		default:
+			if _, ok := hv.Adapter("html"); !ok {
+				t.Fatal("Adapter() did not return the default html adapter")
+			}
+		}
+	}
+}
+
+func BenchmarkViewService_Adapter(b *testing.B) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		b.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, ok := hv.Adapter("json"); !ok {
+				b.Fatal("Adapter() did not return the json adapter")
+			}
+		}
+	})
+}
+
+func TestViewService_RenderCookies(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Home{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base"))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	want := []string{"session=abc; Path=/; HttpOnly", "theme=dark", "flash=; Path=/; Expires=Thu, 01 Jan 1970 00:00:00 GMT; Max-Age=0"}
+
+	for _, adapterKey := range []string{"html", "json", "yaml"} {
+		t.Run(adapterKey, func(t *testing.T) {
+			resp := response.NewResponse().Path("home").
+				SetCookie(&http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true}).
+				SetCookie(&http.Cookie{Name: "theme", Value: "light"}).
+				SetCookie(&http.Cookie{Name: "theme", Value: "dark"}).
+				DeleteCookie("flash")
+
+			w := httptest.NewRecorder()
+			w.Header().Add("Set-Cookie", "csrf=token")
+			hv.RenderAs(w, httptest.NewRequest("GET", "/", nil), adapterKey, resp)
+
+			got := w.Header().Values("Set-Cookie")
+			if wantAll := append([]string{"csrf=token"}, want...); strings.Join(got, "\n") != strings.Join(wantAll, "\n") {
+				t.Errorf("Set-Cookie = %q, want %q", got, wantAll)
+			}
+		})
+	}
+}
+
+func TestViewService_RedirectBack(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{"return to", "/login?return_to=/settings", "/settings"},
+		{"other host", "/login?return_to=https://evil.example/", "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.RedirectBack(w, httptest.NewRequest("POST", tt.target, nil), "/")
+
+			if w.Code != http.StatusFound || w.Header().Get("Location") != tt.want {
+				t.Errorf("redirect = %d %q, want 302 to %q", w.Code, w.Header().Get("Location"), tt.want)
+			}
+		})
+	}
+}
+
+func TestViewService_RenderNoContent(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		resp       *response.Response
+		wantStatus int
+	}{
+		{"no content", response.NewResponse().Path("missing").NoContent(), http.StatusNoContent},
+		{"empty ok for hx-swap delete", response.NewResponse().NoContent().StatusOK(), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.resp.HxTrigger("rowDeleted", nil).SetCookie(&http.Cookie{Name: "undo", Value: "42"})
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("DELETE", "/rows/42", nil), tt.resp)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Body.Len() != 0 {
+				t.Errorf("body = %q, want none", w.Body.String())
+			}
+			if got := w.Header().Get("HX-Trigger"); !strings.Contains(got, "rowDeleted") {
+				t.Errorf("HX-Trigger = %q, want it to contain rowDeleted", got)
+			}
+			if got := w.Header().Get("Set-Cookie"); got != "undo=42" {
+				t.Errorf("Set-Cookie = %q, want %q", got, "undo=42")
+			}
+		})
+	}
+}
+
+func TestViewService_NewResponseFrom(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithLayouts("app", "system"))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	if got := hv.NewResponseFrom(nil).TemplateLayout(); got != "app" {
+		t.Errorf("NewResponseFrom(nil) layout = %q, want %q", got, "app")
+	}
+
+	base := response.NewResponse().Layout("admin").Header("Cache-Control", "private")
+	resp := hv.NewResponseFrom(base).Header("Cache-Control", "no-store")
+
+	if got := resp.TemplateLayout(); got != "admin" {
+		t.Errorf("layout = %q, want %q", got, "admin")
+	}
+	if got := base.Headers()["Cache-Control"]; got != "private" {
+		t.Errorf("base Cache-Control = %q, want %q", got, "private")
+	}
+}
+
+func TestViewService_DefaultResponseHeaders(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":       {Data: []byte(`{{define "page:main"}}Home{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Not found{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithLayouts("base", "base"),
+		hyperview.WithDefaultResponseHeaders(http.Header{
+			"x-content-type-options": {"nosniff"},
+			"Cache-Control":          {"no-cache"},
+			"Referrer-Policy":        {"same-origin"},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		render func(w http.ResponseWriter, r *http.Request)
+		want   map[string]string
+	}{
+		{"page", func(w http.ResponseWriter, r *http.Request) {
+			hv.Render(w, r, response.NewResponse().Path("home").Header("Cache-Control", "max-age=60"))
+		}, map[string]string{"X-Content-Type-Options": "nosniff", "Cache-Control": "max-age=60", "Referrer-Policy": "strict-origin"}},
+		{"system page", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderNotFound(w, r)
+		}, map[string]string{"X-Content-Type-Options": "nosniff", "Cache-Control": "no-cache", "Referrer-Policy": "strict-origin"}},
+		{"no content", func(w http.ResponseWriter, r *http.Request) {
+			hv.Render(w, r, response.NewResponse().NoContent())
+		}, map[string]string{"X-Content-Type-Options": "nosniff", "Cache-Control": "no-cache", "Referrer-Policy": "strict-origin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			w.Header().Set("Referrer-Policy", "strict-origin")
+			tt.render(w, httptest.NewRequest("GET", "/", nil))
+
+			for key, want := range tt.want {
+				if got := w.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestViewService_AdapterLayouts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}base:{{template "page:main" .}}{{end}}`)},
+		"layouts/admin.html":    {Data: []byte(`{{define "layout:admin"}}admin:{{template "page:main" .}}{{end}}`)},
+		"layouts/minimal.html":  {Data: []byte(`{{define "layout:minimal"}}minimal:{{template "page:main" .}}{{end}}`)},
+		"views/home.html":       {Data: []byte(`{{define "page:main"}}Home{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Not found{{end}}`)},
+	}
+	admin := hyperview.NewTemplateViewAdapter(hyperview.TemplateViewAdapterOptions{
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	})
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithViewAdapter("admin", admin, hyperview.WithAdapterLayouts("admin", "minimal")),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		render func(w http.ResponseWriter, r *http.Request)
+		want   string
+	}{
+		{"service layout", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderAs(w, r, "html", response.NewResponse().Path("home"))
+		}, "base:Home"},
+		{"adapter layout", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderAs(w, r, "admin", response.NewResponse().Path("home"))
+		}, "admin:Home"},
+		{"response layout", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderAs(w, r, "admin", response.NewResponse().Layout("base").Path("home"))
+		}, "base:Home"},
+		{"service system layout", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderNotFound(w, r)
+		}, "base:Not found"},
+		{"adapter system layout", func(w http.ResponseWriter, r *http.Request) {
+			hv.RenderNotFoundAs(w, r, "admin")
+		}, "minimal:Not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.render(w, httptest.NewRequest("GET", "/", nil))
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewService_AliasTemplate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":                 {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":                   {Data: []byte(`{{define "page:main"}}Home{{end}}`)},
+		"views/marketing/landing-v3.html":   {Data: []byte(`{{define "page:main"}}Landing{{end}}`)},
+		"views/marketing/pricing-2024.html": {Data: []byte(`{{define "page:main"}}Pricing{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	for name, path := range map[string]string{
+		"home":            "marketing/landing-v3",
+		"pricing":         "views/pricing-current",
+		"pricing-current": "marketing/pricing-2024",
+	} {
+		if err := hv.AliasTemplate(name, path); err != nil {
+			t.Fatalf("AliasTemplate(%q) error = %v", name, err)
+		}
+	}
+	if err := hv.AliasTemplate("marketing/pricing-2024", "pricing"); err == nil {
+		t.Error("AliasTemplate() of a circular alias, want error")
+	}
+	if err := hv.Reinit(); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"alias", "home", "Landing"},
+		{"alias with views prefix", "views/home", "Landing"},
+		{"alias of alias", "pricing", "Pricing"},
+		{"template", "marketing/landing-v3", "Landing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path(tt.path))
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	aliases := hv.TemplateAliases()
+	if got := aliases["views/home"]; got != "views/marketing/landing-v3" {
+		t.Errorf("TemplateAliases()[views/home] = %q, want %q", got, "views/marketing/landing-v3")
+	}
+	delete(aliases, "views/home")
+	if _, ok := hv.TemplateAliases()["views/home"]; !ok {
+		t.Error("TemplateAliases() returned the registered aliases, want a copy")
+	}
+}
@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Catalog is a Translator with the messages of each locale in memory. Messages are format strings (see fmt.Sprintf),
+// which ignore the arguments if they have no verbs. The plural forms of a key are stored under the key with the CLDR
+// plural category of the locale as suffix (see PluralCategory), e.g. ".one", ".few", ".many", and ".other":
+//
+//	catalog := i18n.NewCatalog("en")
+//	catalog.Add("pl", map[string]string{
+//		"greeting":         "Cześć %s",
+//		"cart.items.one":   "%d produkt",
+//		"cart.items.few":   "%d produkty",
+//		"cart.items.many":  "%d produktów",
+//		"cart.items.other": "%d produktu",
+//	})
+//
+// Messages are looked up in the locale, then in its language (de for de-CH), and then in the fallback locale. Keys
+// without a message are returned as is, so that missing translations are visible.
+type Catalog struct {
+	mu       sync.RWMutex
+	fallback string
+	messages map[string]map[string]string // messages by locale and key
+}
+
+// NewCatalog creates a new Catalog with the fallback locale of keys without a message in the requested locale.
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: fallback,
+		messages: make(map[string]map[string]string),
+	}
+}
+
+// Add adds the messages of the locale to the catalog, replacing messages with the same key.
+func (c *Catalog) Add(locale string, messages map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string, len(messages))
+	}
+	for key, message := range messages {
+		c.messages[locale][key] = message
+	}
+}
+
+// LoadFS adds the messages of the JSON files in the directory of the filesystem to the catalog. Each file holds the
+// messages of the locale of its name, e.g. locales/de.json, as an object of keys and messages.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("error parsing messages %s: %w", file, err)
+		}
+		c.Add(strings.TrimSuffix(path.Base(file), ".json"), messages)
+	}
+
+	return nil
+}
+
+// Translate implements Translator.
+func (c *Catalog) Translate(locale, key string, args ...any) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, candidate := range c.locales(locale) {
+		if message, ok := c.messages[candidate][key]; ok {
+			return format(message, args)
+		}
+	}
+	return key
+}
+
+// TranslatePlural implements Translator. The message is the form of the key for the plural category of the count in
+// the locale, falling back to the ".other" form and then to the message of the key itself. A ".zero" form is used for
+// a count of 0 in every locale, e.g. for "No items", even if the language has no zero category. The forms of a locale
+// take precedence over the forms of its fallbacks.
+func (c *Catalog) TranslatePlural(locale, key string, count int, args ...any) string {
+	if len(args) == 0 {
+		args = []any{count}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, candidate := range c.locales(locale) {
+		forms := make([]string, 0, 4)
+		if count == 0 {
+			forms = append(forms, PluralZero)
+		}
+		forms = append(forms, PluralCategory(candidate, count), PluralOther)
+
+		messages := c.messages[candidate]
+		for _, form := range forms {
+			if message, ok := messages[key+"."+form]; ok {
+				return format(message, args)
+			}
+		}
+		if message, ok := messages[key]; ok {
+			return format(message, args)
+		}
+	}
+	return key
+}
+
+// MissingKeys returns the keys without a message in the locale itself, ignoring its fallbacks, e.g. to check the
+// translations of the keys extracted from the templates (see ExtractKeys) in a test. Plural keys have a message if
+// the key or its ".other" form has one.
+func (c *Catalog) MissingKeys(locale string, keys []Key) []Key {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var missing []Key
+	messages := c.messages[locale]
+	for _, key := range keys {
+		if _, ok := messages[key.Key]; ok {
+			continue
+		}
+		if _, ok := messages[key.Key+"."+PluralOther]; ok && key.Plural {
+			continue
+		}
+		missing = append(missing, key)
+	}
+	return missing
+}
+
+// format formats the message with the arguments, if it has verbs.
+func format(message string, args []any) string {
+	if len(args) == 0 || !strings.Contains(message, "%") {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// locales returns the locales to look up messages in, in order.
+func (c *Catalog) locales(locale string) []string {
+	locales := make([]string, 0, 3)
+	if locale != "" {
+		locales = append(locales, locale)
+		if language, _, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); found {
+			locales = append(locales, language)
+		}
+	}
+	return append(locales, c.fallback)
+}
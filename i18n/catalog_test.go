@@ -0,0 +1,90 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview/i18n"
+)
+
+func newTestCatalog(t *testing.T) *i18n.Catalog {
+	t.Helper()
+	catalog := i18n.NewCatalog("en")
+	err := catalog.LoadFS(fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"greeting": "Hello %s", "title": "Checkout", "cart.items.one": "%d item", "cart.items.other": "%d items", "cart.total": "Total"}`)},
+		"locales/de.json": {Data: []byte(`{"greeting": "Hallo %s", "title": "Kasse", "cart.items.one": "%d Artikel", "cart.items.other": "%d Artikel"}`)},
+	}, "locales")
+	if err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	catalog.Add("de-CH", map[string]string{"title": "Kassa"})
+	catalog.Add("en", map[string]string{"cart.items.zero": "No items"})
+	catalog.Add("pl", map[string]string{
+		"cart.items.one":   "%d produkt",
+		"cart.items.few":   "%d produkty",
+		"cart.items.many":  "%d produktów",
+		"cart.items.other": "%d produktu",
+	})
+	return catalog
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"message", "de", "title", nil, "Kasse"},
+		{"arguments", "de", "greeting", []any{"Gopher"}, "Hallo Gopher"},
+		{"regional message", "de-CH", "title", nil, "Kassa"},
+		{"regional falls back to language", "de-CH", "greeting", []any{"Gopher"}, "Hallo Gopher"},
+		{"falls back to fallback locale", "fr", "title", nil, "Checkout"},
+		{"no locale", "", "title", nil, "Checkout"},
+		{"missing key", "de", "missing.key", nil, "missing.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+				t.Errorf("Translate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_TranslatePlural(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		count  int
+		want   string
+	}{
+		{"one", "en", "cart.items", 1, "1 item"},
+		{"other", "en", "cart.items", 3, "3 items"},
+		{"zero form", "en", "cart.items", 0, "No items"},
+		{"zero without zero form", "de", "cart.items", 0, "0 Artikel"},
+		{"cldr one", "pl", "cart.items", 1, "1 produkt"},
+		{"cldr few", "pl", "cart.items", 3, "3 produkty"},
+		{"cldr many", "pl", "cart.items", 5, "5 produktów"},
+		{"cldr few of tens", "pl", "cart.items", 22, "22 produkty"},
+		{"missing category falls back to other", "de", "cart.items", 5, "5 Artikel"},
+		{"locale", "de", "cart.items", 2, "2 Artikel"},
+		{"no plural forms", "en", "cart.total", 2, "Total"},
+		{"missing key", "en", "missing", 2, "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.TranslatePlural(tt.locale, tt.key, tt.count); got != tt.want {
+				t.Errorf("TranslatePlural() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package i18n
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Key is a message key used by the templates, with the places where it is used.
+type Key struct {
+	// Key is the message key.
+	Key string `json:"key"`
+	// Plural is true if the key is used with tn, so that it needs plural forms.
+	Plural bool `json:"plural,omitempty"`
+	// Refs are the places where the key is used, in the order of the files and lines.
+	Refs []Ref `json:"refs"`
+}
+
+// Ref is a place in a template file.
+type Ref struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// String returns the place as file:line.
+func (r Ref) String() string {
+	return r.File + ":" + strconv.Itoa(r.Line)
+}
+
+// ExtractKeys walks the templates with the extension, e.g. ".html", in the filesystem and returns the message keys of
+// their t and tn calls, sorted by key. Only keys given as string literals are extracted, both as arguments, e.g.
+// {{t .View "greeting" .Name}}, and piped, e.g. {{"greeting" | t .View}}.
+//
+// The keys can be compared with the messages of a catalog (see Catalog.MissingKeys) to keep translation files in sync,
+// e.g. in a test or a make target.
+func ExtractKeys(fsys fs.FS, ext string) ([]Key, error) {
+	keys := make(map[string]*Key)
+
+	err := fs.WalkDir(fsys, ".", func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(file) != ext {
+			return nil
+		}
+
+		text, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		tree := parse.New(file)
+		tree.Mode = parse.SkipFuncCheck
+		trees := make(map[string]*parse.Tree)
+		if _, err := tree.Parse(string(text), "", "", trees); err != nil {
+			return fmt.Errorf("error parsing template %s: %w", file, err)
+		}
+
+		for _, t := range trees {
+			if t.Root != nil {
+				extractNode(t, t.Root, file, keys)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	extracted := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		slices.SortFunc(key.Refs, func(a, b Ref) int {
+			return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+		})
+		extracted = append(extracted, *key)
+	}
+	slices.SortFunc(extracted, func(a, b Key) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return extracted, nil
+}
+
+// extractNode adds the keys of the t and tn calls in the node and its children.
+func extractNode(tree *parse.Tree, node parse.Node, file string, keys map[string]*Key) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			extractNode(tree, child, file, keys)
+		}
+	case *parse.ActionNode:
+		extractNode(tree, n.Pipe, file, keys)
+	case *parse.IfNode:
+		extractBranch(tree, &n.BranchNode, file, keys)
+	case *parse.RangeNode:
+		extractBranch(tree, &n.BranchNode, file, keys)
+	case *parse.WithNode:
+		extractBranch(tree, &n.BranchNode, file, keys)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			extractNode(tree, n.Pipe, file, keys)
+		}
+	case *parse.PipeNode:
+		for i, cmd := range n.Cmds {
+			var piped parse.Node
+			if i > 0 && len(n.Cmds[i-1].Args) == 1 {
+				piped = n.Cmds[i-1].Args[0]
+			}
+			extractCommand(tree, cmd, piped, file, keys)
+		}
+	}
+}
+
+// extractBranch adds the keys of an if, range, or with node.
+func extractBranch(tree *parse.Tree, n *parse.BranchNode, file string, keys map[string]*Key) {
+	extractNode(tree, n.Pipe, file, keys)
+	extractNode(tree, n.List, file, keys)
+	if n.ElseList != nil {
+		extractNode(tree, n.ElseList, file, keys)
+	}
+}
+
+// extractCommand adds the key of a t or tn command, given as its second argument or piped into it, and the keys of
+// its parenthesized arguments.
+func extractCommand(tree *parse.Tree, cmd *parse.CommandNode, piped parse.Node, file string, keys map[string]*Key) {
+	for _, arg := range cmd.Args {
+		if pipe, ok := arg.(*parse.PipeNode); ok {
+			extractNode(tree, pipe, file, keys)
+		}
+	}
+
+	if len(cmd.Args) == 0 {
+		return
+	}
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok || (ident.Ident != "t" && ident.Ident != "tn") {
+		return
+	}
+
+	keyNode := piped
+	if len(cmd.Args) > 2 {
+		keyNode = cmd.Args[2]
+	}
+	str, ok := keyNode.(*parse.StringNode)
+	if !ok {
+		return
+	}
+
+	key, ok := keys[str.Text]
+	if !ok {
+		key = &Key{Key: str.Text}
+		keys[str.Text] = key
+	}
+	key.Plural = key.Plural || ident.Ident == "tn"
+	key.Refs = append(key.Refs, Ref{File: file, Line: nodeLine(tree, cmd)})
+}
+
+// nodeLine returns the line of the node in its template file.
+func nodeLine(tree *parse.Tree, node parse.Node) int {
+	location, _ := tree.ErrorContext(node)
+	parts := strings.Split(location, ":")
+	if len(parts) < 3 {
+		return 0
+	}
+	line, _ := strconv.Atoi(parts[len(parts)-2])
+	return line
+}
@@ -0,0 +1,87 @@
+package i18n_test
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview/i18n"
+)
+
+func TestExtractKeys(t *testing.T) {
+	fsys := fstest.MapFS{
+		"views/checkout.html": {Data: []byte(`{{define "page:main"}}
+<h1>{{t .View "title"}}</h1>
+{{if .Items}}
+	<p>{{tn .View "cart.items" (len .Items)}}</p>
+{{else}}
+	<p>{{t .View "cart.empty"}}</p>
+{{end}}
+{{range .Items}}{{.Name}} {{"cart.remove" | t $.View}}{{end}}
+{{end}}`)},
+		"views/home.html": {Data: []byte(`<h1>{{t .View "title"}}</h1>
+{{with .User}}{{printf "%s!" (t $.View "greeting" .Name)}}{{end}}
+{{t .View .DynamicKey}}`)},
+		"views/notes.txt":         {Data: []byte(`{{t .View "ignored"}}`)},
+		"partials/footer.html":    {Data: []byte(`{{template "partial:links" (t .View "footer.links")}}`)},
+		"partials/unrelated.html": {Data: []byte(`{{.Title | printf "%s"}}`)},
+	}
+
+	keys, err := i18n.ExtractKeys(fsys, ".html")
+	if err != nil {
+		t.Fatalf("ExtractKeys() error = %v", err)
+	}
+
+	want := []i18n.Key{
+		{Key: "cart.empty", Refs: []i18n.Ref{{File: "views/checkout.html", Line: 6}}},
+		{Key: "cart.items", Plural: true, Refs: []i18n.Ref{{File: "views/checkout.html", Line: 4}}},
+		{Key: "cart.remove", Refs: []i18n.Ref{{File: "views/checkout.html", Line: 8}}},
+		{Key: "footer.links", Refs: []i18n.Ref{{File: "partials/footer.html", Line: 1}}},
+		{Key: "greeting", Refs: []i18n.Ref{{File: "views/home.html", Line: 2}}},
+		{Key: "title", Refs: []i18n.Ref{{File: "views/checkout.html", Line: 2}, {File: "views/home.html", Line: 1}}},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("ExtractKeys() = %+v, want %+v", keys, want)
+	}
+}
+
+func TestExtractKeys_ParseError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"views/broken.html": {Data: []byte(`{{t .View "title"`)},
+	}
+
+	if _, err := i18n.ExtractKeys(fsys, ".html"); err == nil {
+		t.Error("ExtractKeys() error = nil, want parse error")
+	}
+}
+
+func TestCatalog_MissingKeys(t *testing.T) {
+	catalog := newTestCatalog(t)
+	keys := []i18n.Key{
+		{Key: "title"},
+		{Key: "cart.items", Plural: true},
+		{Key: "cart.total"},
+		{Key: "cart.empty"},
+	}
+
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{locale: "en", want: []string{"cart.empty"}},
+		{locale: "de", want: []string{"cart.total", "cart.empty"}},
+		{locale: "de-CH", want: []string{"cart.items", "cart.total", "cart.empty"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			var got []string
+			for _, key := range catalog.MissingKeys(tt.locale, keys) {
+				got = append(got, key.Key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
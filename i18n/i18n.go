@@ -0,0 +1,73 @@
+// Package i18n translates the messages of templates for the locale of the request. A Translator, such as a Catalog,
+// is set with hyperview.WithTranslator, which adds the t and tn functions to the default template adapter:
+//
+//	{{t .View "checkout.title"}}
+//	{{t .View "greeting" .Name}}
+//	{{tn .View "cart.items" .Count}}
+//
+// The first argument is the locale of the message, usually the view data (see response.Data.Locale), which carries
+// the locale resolved for the request (see hyperview.WithLocaleResolver).
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Translator translates message keys to the messages of a locale. Implementations must be safe for concurrent use.
+type Translator interface {
+	// Translate returns the message of the key for the locale, formatted with the arguments, if any.
+	Translate(locale, key string, args ...any) string
+	// TranslatePlural returns the plural form of the message of the key for the count and the locale, formatted with
+	// the arguments, or with the count if there are no arguments.
+	TranslatePlural(locale, key string, count int, args ...any) string
+}
+
+// Localizer is implemented by values that know the locale of the request, such as response.Data.
+type Localizer interface {
+	Locale() string
+}
+
+// Funcs returns the template functions of the translator:
+//
+//   - t: translates a message key, e.g. {{t .View "greeting" .Name}}
+//   - tn: translates the plural form of a message key for a count, e.g. {{tn .View "cart.items" .Count}}
+//
+// The first argument of both functions is the locale, either a Localizer such as the view data, or a locale string.
+func Funcs(translator Translator) map[string]any {
+	return map[string]any{
+		"t": func(locale any, key string, args ...any) string {
+			return translator.Translate(localeOf(locale), key, args...)
+		},
+		"tn": func(locale any, key string, count any, args ...any) (string, error) {
+			n, err := toInt(count)
+			if err != nil {
+				return "", err
+			}
+			return translator.TranslatePlural(localeOf(locale), key, n, args...), nil
+		},
+	}
+}
+
+// localeOf returns the locale of a Localizer or a locale string.
+func localeOf(locale any) string {
+	switch v := locale.(type) {
+	case Localizer:
+		return v.Locale()
+	case string:
+		return v
+	}
+	return ""
+}
+
+// toInt converts an integer of any type, as passed by templates, to an int.
+func toInt(count any) (int, error) {
+	v := reflect.ValueOf(count)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("unable to convert type %T to a count", count)
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// CLDR plural categories. Every locale has the PluralOther category, and the other categories only where its
+// language distinguishes them, e.g. PluralFew for 2 to 4 in Polish.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// pluralCategories are the names of the plural forms.
+var pluralCategories = map[plural.Form]string{
+	plural.Zero:  PluralZero,
+	plural.One:   PluralOne,
+	plural.Two:   PluralTwo,
+	plural.Few:   PluralFew,
+	plural.Many:  PluralMany,
+	plural.Other: PluralOther,
+}
+
+// PluralCategory returns the CLDR plural category of the count in the locale, e.g. "one" for 1 and "other" for 2 in
+// English, or "few" for 3 and "many" for 5 in Polish. Unknown locales use the rules of English.
+func PluralCategory(locale string, count int) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.English
+	}
+	if count < 0 {
+		count = -count
+	}
+
+	return pluralCategories[plural.Cardinal.MatchPlural(tag, count, 0, 0, 0, 0)]
+}
@@ -0,0 +1,36 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/i18n"
+)
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		count  int
+		want   string
+	}{
+		{"en", 0, i18n.PluralOther},
+		{"en", 1, i18n.PluralOne},
+		{"en", 2, i18n.PluralOther},
+		{"en", -1, i18n.PluralOne},
+		{"fr", 0, i18n.PluralOne},
+		{"pl", 1, i18n.PluralOne},
+		{"pl", 3, i18n.PluralFew},
+		{"pl", 5, i18n.PluralMany},
+		{"pl", 22, i18n.PluralFew},
+		{"ru-RU", 21, i18n.PluralOne},
+		{"ar", 0, i18n.PluralZero},
+		{"ar", 2, i18n.PluralTwo},
+		{"ja", 1, i18n.PluralOther},
+		{"", 1, i18n.PluralOne},
+	}
+
+	for _, tt := range tests {
+		if got := i18n.PluralCategory(tt.locale, tt.count); got != tt.want {
+			t.Errorf("PluralCategory(%q, %d) = %q, want %q", tt.locale, tt.count, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/i18n"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithTranslator(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/cart.html":   {Data: []byte(`{{define "page:main"}}{{.View.Locale}}: {{t .View "greeting" .Name}}, {{tn .View "cart.items" .Count}}{{end}}`)},
+	}
+
+	catalog := i18n.NewCatalog("en")
+	catalog.Add("en", map[string]string{"greeting": "Hello %s", "cart.items.one": "%d item", "cart.items.other": "%d items"})
+	catalog.Add("de", map[string]string{"greeting": "Hallo %s", "cart.items.one": "%d Artikel", "cart.items.other": "%d Artikel"})
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithTranslator(catalog),
+		hyperview.WithLocaleResolver(func(r *http.Request) string {
+			return r.URL.Query().Get("lang")
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		locale string
+		count  int
+		want   string
+	}{
+		{"resolved locale", "/?lang=de", "", 3, "de: Hallo Gopher, 3 Artikel"},
+		{"fallback locale", "/", "", 1, ": Hello Gopher, 1 item"},
+		{"context locale", "/?lang=de", "en", 2, "en: Hello Gopher, 2 items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.locale != "" {
+				r = r.WithContext(hyperview.ContextWithLocale(r.Context(), tt.locale))
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path("cart").Data(map[string]any{"Name": "Gopher", "Count": tt.count}))
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
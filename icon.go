@@ -0,0 +1,58 @@
+package hyperview
+
+import (
+	"io/fs"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// WithIconsDir sets the directory of the template filesystem with the SVG files of the icon template function
+// (default: "icons"), e.g. "assets/icons" to also serve them as assets.
+func WithIconsDir(dir string) Option {
+	return func(hgo *HyperView) error {
+		hgo.iconsDir = dir
+		return nil
+	}
+}
+
+// WithIconSprite renders icons as references to the symbols of a sprite of all icons, instead of inline copies of
+// their SVG files, which keeps pages with many icons small. The sprite must be rendered once on each page with icons,
+// e.g. at the start of the body of the layout:
+//
+//	<body>
+//		{{iconSprite}}
+//		<button>{{icon "close" "size" 16}}</button>
+//	</body>
+func WithIconSprite() Option {
+	return func(hgo *HyperView) error {
+		hgo.iconSprite = true
+		return nil
+	}
+}
+
+// Icons returns the icons of the icons directory of the template filesystem, which back the icon and iconSprite
+// functions of the default template adapter:
+//
+//	{{icon "close" "class" "text-red-500" "size" 16}}
+//
+// Other adapters can add Icons().Icon to their functions.
+func (s *HyperView) Icons() *funcs.Icons {
+	return s.icons
+}
+
+// iconsFS returns the icons directory of the root template filesystem, or nil if there is none.
+func (s *HyperView) iconsFS() fs.FS {
+	root, ok := s.filesystemMap[constants.RootFSID]
+	if !ok {
+		return nil
+	}
+	if info, err := fs.Stat(root, s.iconsDir); err != nil || !info.IsDir() {
+		return nil
+	}
+	icons, err := fs.Sub(root, s.iconsDir)
+	if err != nil {
+		return nil
+	}
+	return icons
+}
@@ -0,0 +1,55 @@
+package hyperview_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestIcon(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":      {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":        {Data: []byte(`{{define "page:main"}}{{iconSprite}}{{icon "close" "size" 16}}{{end}}`)},
+		"assets/icons/close.svg": {Data: []byte(`<svg viewBox="0 0 24 24"><path d="M6 6l12 12"/></svg>`)},
+	}
+
+	tests := []struct {
+		name string
+		opts []hyperview.Option
+		want string
+	}{
+		{
+			name: "inline",
+			opts: []hyperview.Option{hyperview.WithIconsDir("assets/icons")},
+			want: `<svg xmlns="http://www.w3.org/2000/svg" hidden aria-hidden="true">` +
+				`<symbol id="icon-close" viewBox="0 0 24 24"><path d="M6 6l12 12"/></symbol></svg>` +
+				`<svg viewBox="0 0 24 24" width="16" height="16" aria-hidden="true"><path d="M6 6l12 12"/></svg>`,
+		},
+		{
+			name: "sprite",
+			opts: []hyperview.Option{hyperview.WithIconsDir("assets/icons"), hyperview.WithIconSprite()},
+			want: `<svg xmlns="http://www.w3.org/2000/svg" hidden aria-hidden="true">` +
+				`<symbol id="icon-close" viewBox="0 0 24 24"><path d="M6 6l12 12"/></symbol></svg>` +
+				`<svg viewBox="0 0 24 24" width="16" height="16" aria-hidden="true"><use href="#icon-close"></use></svg>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append(tt.opts, hyperview.WithTemplateFS(fsys))...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("home"))
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,36 @@
 package hyperview
 
 import (
-	"encoding/json"
+	"bytes"
 	"net/http"
+	"regexp"
 )
 
+const (
+	jsonContentType    = "application/json; charset=UTF-8"
+	problemContentType = "application/problem+json"
+	jsonpContentType   = "text/javascript; charset=UTF-8"
+
+	// jsonIndent is the indent of pretty JSON output.
+	jsonIndent = "\t"
+	// prettyQueryParam is the query parameter that toggles pretty JSON output.
+	prettyQueryParam = "pretty"
+
+	// jsonpCallbackParam is the query parameter with the name of the JSONP callback.
+	jsonpCallbackParam = "callback"
+	// maxJSONPCallbackLength is the maximum length of a JSONP callback name.
+	maxJSONPCallbackLength = 128
+)
+
+// jsonpCallbackPattern matches JavaScript identifiers, optionally dotted (e.g. "jQuery123_456" or "widget.load").
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][0-9A-Za-z_$]*(\.[A-Za-z_$][0-9A-Za-z_$]*)*$`)
+
 // Envelope represents the structure of an envelope used for encapsulating response data.
 type Envelope struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Data    any    `json:"data"`
-	Code    int    `json:"code,omitempty"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Data    any    `json:"data" yaml:"data"`
+	Code    int    `json:"code,omitempty" yaml:"code,omitempty"`
 }
 
 // JSONSuccess creates a successful JSON response with the given data and optional headers.
@@ -95,22 +115,69 @@ func JSONRedirect(w http.ResponseWriter, r *http.Request, url string, headers ..
 // serialization fails, an error is returned. The function accepts optional headers
 // that will be applied to the response.
 func JSONWithHeaders(w http.ResponseWriter, status int, data any, headers ...http.Header) error {
-	js, err := json.MarshalIndent(data, "", "\t")
+	return writeJSON(w, currentJSONEncoder(), jsonIndent, status, jsonContentType, data, headers...)
+}
+
+// writeJSON serializes the given data to JSON with the encoder and indent (compact if empty) and writes it with the
+// status code, content type, and headers.
+func writeJSON(w http.ResponseWriter, enc JSONEncoder, indent string, status int, contentType string, data any, headers ...http.Header) error {
+	js, err := marshalJSON(enc, indent, data)
 	if err != nil {
 		return err
 	}
 
 	js = append(js, '\n')
 
+	writeWithHeaders(w, status, contentType, js, headers...)
+	return nil
+}
+
+// marshalJSON serializes the given data to JSON with the encoder and indent (compact if empty).
+func marshalJSON(enc JSONEncoder, indent string, data any) ([]byte, error) {
+	if indent == "" {
+		return enc.Marshal(data)
+	}
+	return enc.MarshalIndent(data, "", indent)
+}
+
+// writeJSONP serializes the given data to JSON with the encoder and writes it wrapped in a call of the callback,
+// which must be validated with validJSONPCallback. The call is guarded, so nothing happens if the callback is not
+// defined.
+func writeJSONP(w http.ResponseWriter, enc JSONEncoder, status int, callback string, data any, headers ...http.Header) error {
+	js, err := enc.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// U+2028 and U+2029 are valid in JSON strings, but not in JavaScript strings before ES2019, and not every
+	// encoder escapes them
+	js = bytes.ReplaceAll(js, []byte("\u2028"), []byte(`\u2028`))
+	js = bytes.ReplaceAll(js, []byte("\u2029"), []byte(`\u2029`))
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("/**/ typeof " + callback + " === 'function' && " + callback + "(")
+	buf.Write(js)
+	buf.WriteString(");\n")
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	writeWithHeaders(w, status, jsonpContentType, buf.Bytes(), headers...)
+	return nil
+}
+
+// validJSONPCallback returns true if the callback name is safe to use in a JSONP response.
+func validJSONPCallback(callback string) bool {
+	return len(callback) <= maxJSONPCallbackLength && jsonpCallbackPattern.MatchString(callback)
+}
+
+// writeWithHeaders writes the body with the status code, content type, and headers.
+func writeWithHeaders(w http.ResponseWriter, status int, contentType string, body []byte, headers ...http.Header) {
 	for _, header := range headers {
 		for key, value := range header {
 			w.Header()[key] = value
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
-	_, _ = w.Write(js)
-
-	return nil
+	_, _ = w.Write(body)
 }
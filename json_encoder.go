@@ -0,0 +1,84 @@
+package hyperview
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync/atomic"
+)
+
+// JSONEncoder encodes values as JSON. It allows replacing encoding/json with another implementation, such as go-json,
+// jsoniter, or sonic, whose configured APIs (e.g. jsoniter.ConfigCompatibleWithStandardLibrary or sonic.ConfigStd)
+// implement it as is. Marshal options, such as HTML escaping or the omitempty policy, are set by the encoder.
+type JSONEncoder interface {
+	// Marshal returns the JSON encoding of v.
+	Marshal(v any) ([]byte, error)
+	// MarshalIndent is like Marshal but applies the prefix and indent to format the output.
+	MarshalIndent(v any, prefix, indent string) ([]byte, error)
+}
+
+// JSONStreamEncoder is a JSONEncoder that can encode values straight to a writer, which is used to stream large
+// responses without buffering the output. Encoders that don't implement it are buffered.
+type JSONStreamEncoder interface {
+	JSONEncoder
+	// EncodeIndent writes the JSON encoding of v, formatted with the prefix and indent and followed by a newline, to w.
+	EncodeIndent(w io.Writer, v any, prefix, indent string) error
+}
+
+// StdJSONEncoder is the default JSONEncoder, using encoding/json. It implements JSONStreamEncoder.
+type StdJSONEncoder struct {
+	// DisableHTMLEscaping disables the escaping of <, >, and & in JSON strings.
+	DisableHTMLEscaping bool
+}
+
+// Marshal returns the JSON encoding of v.
+func (e StdJSONEncoder) Marshal(v any) ([]byte, error) {
+	return e.MarshalIndent(v, "", "")
+}
+
+// MarshalIndent is like Marshal but applies the prefix and indent to format the output.
+func (e StdJSONEncoder) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	if !e.DisableHTMLEscaping {
+		if prefix == "" && indent == "" {
+			return json.Marshal(v)
+		}
+		return json.MarshalIndent(v, prefix, indent)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := e.EncodeIndent(buf, v, prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// EncodeIndent writes the JSON encoding of v, formatted with the prefix and indent and followed by a newline, to w.
+func (e StdJSONEncoder) EncodeIndent(w io.Writer, v any, prefix, indent string) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(!e.DisableHTMLEscaping)
+	enc.SetIndent(prefix, indent)
+	return enc.Encode(v)
+}
+
+// defaultJSONEncoder is the encoder set with SetJSONEncoder, if any. It is atomic, so that it can be replaced while
+// requests are served.
+var defaultJSONEncoder atomic.Pointer[JSONEncoder]
+
+// SetJSONEncoder sets the encoder used by the JSON helpers, such as JSONWithHeaders and JSONProblem, and by JSON
+// adapters that are not configured with WithJSONEncoder. A nil encoder restores StdJSONEncoder.
+func SetJSONEncoder(enc JSONEncoder) {
+	if enc == nil {
+		defaultJSONEncoder.Store(nil)
+		return
+	}
+	defaultJSONEncoder.Store(&enc)
+}
+
+// currentJSONEncoder returns the encoder set with SetJSONEncoder, or StdJSONEncoder.
+func currentJSONEncoder() JSONEncoder {
+	if enc := defaultJSONEncoder.Load(); enc != nil {
+		return *enc
+	}
+	return StdJSONEncoder{}
+}
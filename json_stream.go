@@ -0,0 +1,84 @@
+package hyperview
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// JSONStream serializes the given data to JSON and streams it to the provided http.ResponseWriter as it is encoded,
+// without buffering the output or setting a Content-Length, for large payloads such as exports. It sets the status
+// code, the Content-Type header to "application/json; charset=UTF-8", and the optional headers. Encoders that don't
+// implement JSONStreamEncoder are buffered.
+//
+// An error before any output is written is returned, so that an error response can be written instead. If the
+// encoding fails after the output was started, the response is aborted with http.ErrAbortHandler, so that clients
+// don't mistake the truncated output for a complete response.
+func JSONStream(w http.ResponseWriter, status int, data any, headers ...http.Header) error {
+	return streamJSON(w, currentJSONEncoder(), jsonIndent, 0, status, jsonContentType, data, headers...)
+}
+
+// streamJSON streams the data as JSON with the encoder and indent (compact if empty). Output up to the threshold
+// (in bytes) is buffered and written with a Content-Length, larger output is streamed.
+func streamJSON(w http.ResponseWriter, enc JSONEncoder, indent string, threshold int, status int, contentType string, data any, headers ...http.Header) error {
+	streamEnc, ok := enc.(JSONStreamEncoder)
+	if !ok {
+		return writeJSON(w, enc, indent, status, contentType, data, headers...)
+	}
+
+	for _, header := range headers {
+		for key, value := range header {
+			w.Header()[key] = value
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	sw := &spillWriter{w: w, status: status, threshold: threshold}
+	if err := streamEnc.EncodeIndent(sw, data, "", indent); err != nil {
+		if sw.streaming {
+			panic(http.ErrAbortHandler)
+		}
+		return err
+	}
+
+	return sw.Close()
+}
+
+// spillWriter buffers output up to a threshold. Once the threshold is exceeded, it writes the status code and
+// streams the buffered and all further output.
+type spillWriter struct {
+	w         http.ResponseWriter
+	status    int
+	threshold int
+	buf       bytes.Buffer
+	streaming bool
+}
+
+func (s *spillWriter) Write(p []byte) (int, error) {
+	if s.streaming {
+		return s.w.Write(p)
+	}
+
+	s.buf.Write(p)
+	if s.buf.Len() > s.threshold {
+		s.streaming = true
+		s.w.WriteHeader(s.status)
+		if _, err := s.buf.WriteTo(s.w); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close writes the buffered output with a Content-Length, unless the output is already streamed.
+func (s *spillWriter) Close() error {
+	if s.streaming {
+		return nil
+	}
+
+	s.w.Header().Set("Content-Length", strconv.Itoa(s.buf.Len()))
+	s.w.WriteHeader(s.status)
+	_, err := s.buf.WriteTo(s.w)
+	return err
+}
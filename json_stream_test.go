@@ -0,0 +1,82 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestJSONStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := hyperview.JSONStream(w, http.StatusOK, []int{1, 2}, http.Header{"X-Export": {"users"}}); err != nil {
+		t.Fatalf("JSONStream() error = %v", err)
+	}
+
+	if got, want := w.Body.String(), "[\n\t1,\n\t2\n]\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if got := w.Header().Get("Content-Length"); got != "" {
+		t.Errorf("Content-Length = %q, want none", got)
+	}
+	if got := w.Header().Get("X-Export"); got != "users" {
+		t.Errorf("X-Export = %q, want users", got)
+	}
+}
+
+func TestJSONStream_Error(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := hyperview.JSONStream(w, http.StatusOK, map[string]any{"fn": func() {}}); err == nil {
+		t.Fatal("JSONStream() error = nil, want error")
+	}
+
+	if w.Body.Len() != 0 {
+		t.Errorf("body = %q, want empty", w.Body.String())
+	}
+}
+
+func TestJSONAdapter_Streaming(t *testing.T) {
+	rows := make([]string, 100)
+	for i := range rows {
+		rows[i] = strings.Repeat("x", 10)
+	}
+
+	tests := []struct {
+		name              string
+		threshold         int
+		data              any
+		wantContentLength bool
+	}{
+		{name: "below threshold", threshold: 4096, data: rows[:2], wantContentLength: true},
+		{name: "above threshold", threshold: 64, data: rows, wantContentLength: false},
+		{name: "no threshold", threshold: 0, data: rows[:2], wantContentLength: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(hyperview.WithStreaming(tt.threshold))))
+			if err != nil {
+				t.Fatalf("error creating HyperView: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/export", nil), response.NewResponse().RawJSON(tt.data).StatusCreated())
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusCreated)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json; charset=UTF-8" {
+				t.Errorf("Content-Type = %q, want application/json; charset=UTF-8", got)
+			}
+			if got := w.Header().Get("Content-Length") != ""; got != tt.wantContentLength {
+				t.Errorf("has Content-Length = %v, want %v", got, tt.wantContentLength)
+			}
+			if !strings.HasPrefix(w.Body.String(), "[\n\t\"xxxxxxxxxx\"") || !strings.HasSuffix(w.Body.String(), "]\n") {
+				t.Errorf("body = %q, want the indented rows", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package hyperview
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/i18n"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// LocaleResolver returns the locale of the request, e.g. "de" or "de-CH" from a cookie, the URL, or the
+// Accept-Language header, or an empty string to render the templates without a locale. Resolvers are called on every
+// render, so they must be safe for concurrent use.
+type LocaleResolver func(r *http.Request) string
+
+// ContextWithLocale returns a copy of the context with the locale, e.g. to set the locale of a request in a middleware
+// instead of a locale resolver.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, constants.LocaleContextKey, locale)
+}
+
+// LocaleFromContext returns the locale from the context, if any.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(constants.LocaleContextKey).(string)
+	return locale
+}
+
+// WithLocaleResolver sets the resolver of the locale of the request. The locale is stored in the request context
+// before rendering, where templates read it with .View.Locale and the translation functions use it (see
+// WithTranslator).
+//
+// It also renders the translated variant of the template for the locale, if the adapter has it, so that fully
+// translated pages don't need switching in their handlers. For the locale "de-CH", the template views/checkout is
+// rendered from views/checkout.de-CH, views/checkout.de, or views/checkout, whichever is found first. Only adapters
+// that implement TemplateFinder, such as the TemplateAdapter, render localized templates.
+//
+// Localized templates are selected after template variants (see WithVariantResolver), e.g. views/pricing@b.de.
+func WithLocaleResolver(resolver LocaleResolver) Option {
+	return func(hgo *HyperView) error {
+		hgo.locales = resolver
+		return nil
+	}
+}
+
+// WithTranslator sets the translator of the t and tn functions of the default template adapter, which translate
+// message keys for the locale of the request (see the i18n package):
+//
+//	{{t .View "greeting" .Name}}
+//	{{tn .View "cart.items" .Count}}
+//
+// Functions set with WithFuncMap override the translation functions. Other adapters get them with i18n.Funcs.
+func WithTranslator(translator i18n.Translator) Option {
+	return func(hgo *HyperView) error {
+		hgo.translator = translator
+		return nil
+	}
+}
+
+// withLocaleContext stores the locale resolved for the request in the request context, if a locale resolver is
+// configured and the context has no locale yet.
+func (s *HyperView) withLocaleContext(r *http.Request) *http.Request {
+	if s.locales == nil || LocaleFromContext(r.Context()) != "" {
+		return r
+	}
+
+	if locale := s.locales(r); locale != "" {
+		return r.WithContext(ContextWithLocale(r.Context(), locale))
+	}
+
+	return r
+}
+
+// withLocale replaces the template path of the response with the path of the template for the locale of the request,
+// if the adapter has it.
+func (s *HyperView) withLocale(r *http.Request, adapter Adapter, resp *response.Response) {
+	path := resp.TemplatePath()
+	locale := LocaleFromContext(r.Context())
+	if locale == "" || path == "" {
+		return
+	}
+
+	finder, ok := adapter.(TemplateFinder)
+	if !ok {
+		return
+	}
+
+	for _, candidate := range localeCandidates(locale) {
+		if localized := path + "." + candidate; finder.HasTemplate(r, localized) {
+			resp.Path(localized)
+			return
+		}
+	}
+}
+
+// localeCandidates returns the locale and, for regional locales, its language, e.g. de-CH and de for de-CH.
+func localeCandidates(locale string) []string {
+	language, _, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if !found || language == "" {
+		return []string{locale}
+	}
+	return []string{locale, language}
+}
@@ -0,0 +1,64 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithLocaleResolver(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":          {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/checkout.html":        {Data: []byte(`{{define "page:main"}}Checkout{{end}}`)},
+		"views/checkout.de.html":     {Data: []byte(`{{define "page:main"}}Kasse{{end}}`)},
+		"views/checkout.fr-CA.html":  {Data: []byte(`{{define "page:main"}}Caisse{{end}}`)},
+		"views/pricing@b.html":       {Data: []byte(`{{define "page:main"}}Pricing B{{end}}`)},
+		"views/pricing@b.de.html":    {Data: []byte(`{{define "page:main"}}Preise B{{end}}`)},
+		"views/pricing.html":         {Data: []byte(`{{define "page:main"}}Pricing{{end}}`)},
+		"views/account/profile.html": {Data: []byte(`{{define "page:main"}}Profile{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithLocaleResolver(func(r *http.Request) string {
+			return r.URL.Query().Get("lang")
+		}),
+		hyperview.WithVariantResolver(func(*http.Request, string) string {
+			return "b"
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		lang string
+		want string
+	}{
+		{"locale", "checkout", "de", "Kasse"},
+		{"regional locale falls back to language", "checkout", "de-AT", "Kasse"},
+		{"regional locale", "checkout", "fr-CA", "Caisse"},
+		{"missing locale", "checkout", "es", "Checkout"},
+		{"no locale", "checkout", "", "Checkout"},
+		{"locale of variant", "pricing", "de", "Preise B"},
+		{"nested template", "account/profile", "de", "Profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/?lang="+tt.lang, nil), response.NewResponse().Path(tt.path))
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package hyperview
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maintenance is the configuration of the maintenance mode (see SetMaintenance).
+type maintenance struct {
+	retryAfter time.Duration
+	allowIPs   []string
+	allowPaths []string
+	prefixes   []netip.Prefix
+}
+
+// MaintenanceOption configures the maintenance mode.
+type MaintenanceOption func(*maintenance)
+
+// MaintenanceRetryAfter sets the Retry-After header of maintenance responses, which tells clients when to retry.
+func MaintenanceRetryAfter(d time.Duration) MaintenanceOption {
+	return func(m *maintenance) {
+		m.retryAfter = d
+	}
+}
+
+// MaintenanceAllowIPs lets requests from the given IP addresses or CIDR prefixes (e.g. "10.0.0.0/8") through, e.g.
+// for administrators. The client IP is taken from the remote address of the request, so requests behind a proxy need
+// a middleware that sets the remote address to the real client IP.
+func MaintenanceAllowIPs(ips ...string) MaintenanceOption {
+	return func(m *maintenance) {
+		m.allowIPs = append(m.allowIPs, ips...)
+	}
+}
+
+// MaintenanceAllowPaths lets requests for the given paths and the paths below them through, e.g. for health checks
+// or an admin area. For example, "/admin" allows "/admin" and "/admin/users", but not "/administrator".
+func MaintenanceAllowPaths(paths ...string) MaintenanceOption {
+	return func(m *maintenance) {
+		m.allowPaths = append(m.allowPaths, paths...)
+	}
+}
+
+// SetMaintenance enables or disables the maintenance mode of the maintenance middleware (see MaintenanceMiddleware).
+// The options replace those of a previous call. It returns an error if an allowed IP address or prefix is invalid.
+func (s *HyperView) SetMaintenance(enabled bool, opts ...MaintenanceOption) error {
+	if !enabled {
+		s.maintenance.Store(nil)
+		return nil
+	}
+
+	m := new(maintenance)
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	for _, ip := range m.allowIPs {
+		prefix, err := parseIPPrefix(ip)
+		if err != nil {
+			return fmt.Errorf("invalid maintenance IP %q: %w", ip, err)
+		}
+		m.prefixes = append(m.prefixes, prefix)
+	}
+
+	s.maintenance.Store(m)
+	return nil
+}
+
+// InMaintenance returns true if the maintenance mode is enabled.
+func (s *HyperView) InMaintenance() bool {
+	return s.maintenance.Load() != nil
+}
+
+// MaintenanceMiddleware returns a middleware that responds to all requests with the maintenance page (see
+// RenderMaintenance) while the maintenance mode is enabled with SetMaintenance, except for the allowed IP addresses
+// and paths. Requests that prefer JSON or accept YAML are rendered with the json or yaml adapter, all other requests
+// with the html adapter.
+func (s *HyperView) MaintenanceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := s.maintenance.Load()
+		if m == nil || m.allows(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if m.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(m.retryAfter.Round(time.Second).Seconds())))
+		}
+
+		s.RenderMaintenanceAs(w, r, requestAdapterKey(r))
+	})
+}
+
+// allows returns true if the request is from an allowed IP address or for an allowed path.
+func (m *maintenance) allows(r *http.Request) bool {
+	for _, path := range m.allowPaths {
+		if r.URL.Path == path || strings.HasPrefix(r.URL.Path, strings.TrimSuffix(path, "/")+"/") {
+			return true
+		}
+	}
+
+	if len(m.prefixes) == 0 {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range m.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPPrefix parses an IP address or a CIDR prefix. An IP address is parsed as a prefix with only that address.
+func parseIPPrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
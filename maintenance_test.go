@@ -0,0 +1,104 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/system/503.html": {Data: []byte(`{{define "page:main"}}Down for maintenance{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	handler := hv.MaintenanceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+
+	err = hv.SetMaintenance(true,
+		hyperview.MaintenanceRetryAfter(2*time.Minute),
+		hyperview.MaintenanceAllowIPs("10.0.0.0/8", "2001:db8::1"),
+		hyperview.MaintenanceAllowPaths("/healthz", "/admin/"),
+	)
+	if err != nil {
+		t.Fatalf("SetMaintenance() error = %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		remoteAddr     string
+		accept         string
+		wantStatus     int
+		wantBody       string
+		wantRetryAfter string
+	}{
+		{"blocked", "/", "192.0.2.1:1234", "", http.StatusServiceUnavailable, "Down for maintenance", "120"},
+		{"blocked json", "/api", "192.0.2.1:1234", "application/json", http.StatusServiceUnavailable, `"code": 503`, "120"},
+		{"allowed path", "/healthz", "192.0.2.1:1234", "", http.StatusOK, "OK", ""},
+		{"allowed sub path", "/admin/users", "192.0.2.1:1234", "", http.StatusOK, "OK", ""},
+		{"path with allowed prefix", "/administrator", "192.0.2.1:1234", "", http.StatusServiceUnavailable, "Down for maintenance", "120"},
+		{"allowed prefix", "/", "10.1.2.3:1234", "", http.StatusOK, "OK", ""},
+		{"allowed ip", "/", "[2001:db8::1]:1234", "", http.StatusOK, "OK", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+
+	if err := hv.SetMaintenance(false); err != nil {
+		t.Fatalf("SetMaintenance() error = %v", err)
+	}
+	if hv.InMaintenance() {
+		t.Error("InMaintenance() = true after disabling the maintenance mode")
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d after disabling the maintenance mode, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestSetMaintenance_InvalidIP(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fstest.MapFS{}))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	if err := hv.SetMaintenance(true, hyperview.MaintenanceAllowIPs("not-an-ip")); err == nil {
+		t.Error("SetMaintenance() error = nil, want an invalid IP error")
+	}
+	if hv.InMaintenance() {
+		t.Error("InMaintenance() = true after an invalid configuration")
+	}
+}
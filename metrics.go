@@ -0,0 +1,140 @@
+package hyperview
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// Metrics receives observations of renders and cache lookups, so that they can be exported to a metrics system
+// such as Prometheus or StatsD. Implementations must be safe for concurrent use and should return quickly, as they are
+// called on the render path.
+type Metrics interface {
+	// ObserveRender is called after a response is rendered with the name of the adapter, the template path of the
+	// response, the duration of the render, the status code written, and the error of the render, if any.
+	ObserveRender(adapter, template string, dur time.Duration, status int, err error)
+	// ObserveCache is called after each lookup in the render cache or the page cache.
+	ObserveCache(hit bool)
+}
+
+// ReinitObserver is an optional interface of Metrics that also observe the reinitializations of the adapters (see
+// HyperView.Reinit and WithDevReload), with the error of the reinitialization, if any.
+type ReinitObserver interface {
+	ObserveReinit(err error)
+}
+
+// WithMetrics sets the metrics that observe the renders of Render, RenderAs, and RenderToWriter, and the render
+// cache lookups of the default html adapter. Use WithCacheMetrics to observe the lookups of a CacheHandler.
+func WithMetrics(metrics Metrics) Option {
+	return func(hgo *HyperView) error {
+		hgo.metrics = metrics
+		return nil
+	}
+}
+
+// RenderTracer traces renders, e.g. with OpenTelemetry spans (see the tracing package). Implementations must be safe
+// for concurrent use.
+type RenderTracer interface {
+	// StartRender is called before the response is rendered by the named adapter. It returns the context of the
+	// render, e.g. with a new span, and a function that is called with the status code and the error of the render,
+	// if any, when the render ends.
+	StartRender(ctx context.Context, adapter string, resp *response.Response) (context.Context, func(status int, err error))
+}
+
+// WithRenderTracer sets the tracer of the renders of Render, RenderAs, and RenderToWriter.
+func WithRenderTracer(tracer RenderTracer) Option {
+	return func(hgo *HyperView) error {
+		hgo.tracer = tracer
+		return nil
+	}
+}
+
+// observed returns true if renders are observed by a tracer, metrics, or the expvar stats.
+func (s *HyperView) observed() bool {
+	return s.metrics != nil || s.tracer != nil || s.expvar
+}
+
+// observe calls render with the request of the render, and reports the status code and error it returns to the
+// tracer, the metrics, and the expvar stats. It returns the error of the render.
+func (s *HyperView) observe(r *http.Request, adapterKey string, resp *response.Response, render func(r *http.Request) (int, error)) error {
+	start := time.Now()
+
+	var end func(status int, err error)
+	if s.tracer != nil {
+		var ctx context.Context
+		ctx, end = s.tracer.StartRender(r.Context(), adapterKey, resp)
+		r = r.WithContext(ctx)
+	}
+
+	status, err := render(r)
+
+	if end != nil {
+		end(status, err)
+	}
+	if s.metrics != nil {
+		s.metrics.ObserveRender(adapterKey, resp.TemplatePath(), time.Since(start), status, err)
+	}
+	if s.expvar {
+		expvarRender(adapterKey, err)
+	}
+
+	return err
+}
+
+// renderErrorKey is the context key of the renderError of an observed render.
+type renderErrorKey struct{}
+
+// renderError holds the error reported by the adapter of an observed render.
+type renderError struct {
+	err error
+}
+
+// withRenderError returns a request with a renderError in its context, which collects the error reported by the
+// adapter (see reportRenderError).
+func withRenderError(r *http.Request) (*http.Request, *renderError) {
+	re := new(renderError)
+	return r.WithContext(context.WithValue(r.Context(), renderErrorKey{}, re)), re
+}
+
+// reportRenderError reports the error of a render to the metrics, if the render is observed. Adapters call it before
+// rendering an error response.
+func reportRenderError(r *http.Request, err error) {
+	if re, ok := r.Context().Value(renderErrorKey{}).(*renderError); ok && re.err == nil {
+		re.err = err
+	}
+}
+
+// statusWriter records the status code written to a ResponseWriter.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// Status returns the status code written, which is 200 if only the body was written or nothing was written at all.
+func (sw *statusWriter) Status() int {
+	if sw.status == 0 {
+		return http.StatusOK
+	}
+	return sw.status
+}
+
+// Unwrap returns the underlying ResponseWriter, for http.ResponseController.
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
@@ -0,0 +1,202 @@
+// Package metrics exports the render metrics of a HyperView to Prometheus. The Collector implements
+// hyperview.Metrics, so it can be set with hyperview.WithMetrics and hyperview.WithCacheMetrics:
+//
+//	collector := metrics.NewCollector()
+//	hv, err := hyperview.NewHyperView(hyperview.WithMetrics(collector))
+//	mux.Handle("GET /metrics", collector.Handler())
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Collector is a prometheus.Collector with the render and cache metrics of a HyperView:
+//
+//   - hyperview_renders_total: renders by adapter, template, and status code
+//   - hyperview_render_errors_total: failed renders by adapter and template
+//   - hyperview_render_duration_seconds: render durations by adapter and template
+//   - hyperview_cache_lookups_total: render and page cache lookups by result (hit or miss)
+//   - hyperview_cache_hit_ratio: ratio of cache lookups that were hits
+//   - hyperview_reinits_total: reinitializations of the adapters by result (success or error)
+//   - hyperview_variant_exposures_total: renders of template variants by template and variant
+type Collector struct {
+	renders   *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+	lookups   *prometheus.CounterVec
+	hitRatio  prometheus.GaugeFunc
+	reinits   *prometheus.CounterVec
+	variants  *prometheus.CounterVec
+	hits      atomic.Uint64 // cache hits, for the hit ratio
+	misses    atomic.Uint64 // cache misses, for the hit ratio
+}
+
+// Option configures a Collector.
+type Option func(*config)
+
+type config struct {
+	namespace string
+	buckets   []float64
+}
+
+// WithNamespace sets the namespace (prefix) of the metric names. Default is "hyperview".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the render duration histogram in seconds. Default is prometheus.DefBuckets.
+func WithBuckets(buckets ...float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// NewCollector creates a new Collector.
+func NewCollector(opts ...Option) *Collector {
+	cfg := config{
+		namespace: "hyperview",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c := &Collector{
+		renders: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "renders_total",
+			Help:      "Number of renders by adapter, template, and status code.",
+		}, []string{"adapter", "template", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "render_errors_total",
+			Help:      "Number of failed renders by adapter and template.",
+		}, []string{"adapter", "template"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "render_duration_seconds",
+			Help:      "Duration of renders by adapter and template.",
+			Buckets:   cfg.buckets,
+		}, []string{"adapter", "template"}),
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Number of render and page cache lookups by result.",
+		}, []string{"result"}),
+		reinits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "reinits_total",
+			Help:      "Number of reinitializations of the adapters by result.",
+		}, []string{"result"}),
+		variants: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "variant_exposures_total",
+			Help:      "Number of renders of template variants by template and variant.",
+		}, []string{"template", "variant"}),
+	}
+
+	c.hitRatio = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: cfg.namespace,
+		Name:      "cache_hit_ratio",
+		Help:      "Ratio of render and page cache lookups that were hits.",
+	}, c.cacheHitRatio)
+
+	// Initialize the results, so that the series exist before the first observation
+	for _, result := range []string{"hit", "miss"} {
+		c.lookups.WithLabelValues(result)
+	}
+	for _, result := range []string{"success", "error"} {
+		c.reinits.WithLabelValues(result)
+	}
+
+	return c
+}
+
+// ObserveRender implements hyperview.Metrics.
+func (c *Collector) ObserveRender(adapter, template string, dur time.Duration, status int, err error) {
+	c.renders.WithLabelValues(adapter, template, strconv.Itoa(status)).Inc()
+	c.durations.WithLabelValues(adapter, template).Observe(dur.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(adapter, template).Inc()
+	}
+}
+
+// ObserveCache implements hyperview.Metrics.
+func (c *Collector) ObserveCache(hit bool) {
+	if hit {
+		c.hits.Add(1)
+		c.lookups.WithLabelValues("hit").Inc()
+	} else {
+		c.misses.Add(1)
+		c.lookups.WithLabelValues("miss").Inc()
+	}
+}
+
+// ObserveReinit implements hyperview.ReinitObserver.
+func (c *Collector) ObserveReinit(err error) {
+	if err != nil {
+		c.reinits.WithLabelValues("error").Inc()
+	} else {
+		c.reinits.WithLabelValues("success").Inc()
+	}
+}
+
+// ObserveVariant implements hyperview.VariantObserver.
+func (c *Collector) ObserveVariant(template, variant string) {
+	c.variants.WithLabelValues(template, variant).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.renders.Describe(ch)
+	c.errors.Describe(ch)
+	c.durations.Describe(ch)
+	c.lookups.Describe(ch)
+	c.hitRatio.Describe(ch)
+	c.reinits.Describe(ch)
+	c.variants.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.renders.Collect(ch)
+	c.errors.Collect(ch)
+	c.durations.Collect(ch)
+	c.lookups.Collect(ch)
+	c.hitRatio.Collect(ch)
+	c.reinits.Collect(ch)
+	c.variants.Collect(ch)
+}
+
+// Handler returns an http.Handler that serves the metrics of the collector, together with the Go runtime and process
+// metrics, in the Prometheus exposition format. To serve the metrics with other metrics, register the collector with
+// a prometheus.Registerer instead.
+func (c *Collector) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		c,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// cacheHitRatio returns the ratio of cache lookups that were hits, or 0 if there were no lookups.
+func (c *Collector) cacheHitRatio() float64 {
+	hits := c.hits.Load()
+	total := hits + c.misses.Load()
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
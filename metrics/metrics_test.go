@@ -0,0 +1,120 @@
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/metrics"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// Compile-time checks that the collector implements the hyperview interfaces.
+var (
+	_ hyperview.Metrics         = (*metrics.Collector)(nil)
+	_ hyperview.ReinitObserver  = (*metrics.Collector)(nil)
+	_ hyperview.VariantObserver = (*metrics.Collector)(nil)
+)
+
+func TestCollector(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello{{end}}`)},
+	}
+
+	collector := metrics.NewCollector()
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithMetrics(collector))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		hv.Render(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("views/home"))
+	}
+	hv.Render(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("views/missing"))
+
+	collector.ObserveCache(true)
+	collector.ObserveCache(true)
+	collector.ObserveCache(true)
+	collector.ObserveCache(false)
+
+	if err := hv.Reinit(); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+	collector.ObserveReinit(errors.New("parse error"))
+
+	want := `
+# HELP hyperview_cache_hit_ratio Ratio of render and page cache lookups that were hits.
+# TYPE hyperview_cache_hit_ratio gauge
+hyperview_cache_hit_ratio 0.75
+# HELP hyperview_cache_lookups_total Number of render and page cache lookups by result.
+# TYPE hyperview_cache_lookups_total counter
+hyperview_cache_lookups_total{result="hit"} 3
+hyperview_cache_lookups_total{result="miss"} 1
+# HELP hyperview_reinits_total Number of reinitializations of the adapters by result.
+# TYPE hyperview_reinits_total counter
+hyperview_reinits_total{result="error"} 1
+hyperview_reinits_total{result="success"} 1
+# HELP hyperview_render_errors_total Number of failed renders by adapter and template.
+# TYPE hyperview_render_errors_total counter
+hyperview_render_errors_total{adapter="html",template="views/missing"} 1
+# HELP hyperview_renders_total Number of renders by adapter, template, and status code.
+# TYPE hyperview_renders_total counter
+hyperview_renders_total{adapter="html",status="200",template="views/home"} 2
+hyperview_renders_total{adapter="html",status="500",template="views/missing"} 1
+`
+	names := []string{
+		"hyperview_cache_hit_ratio",
+		"hyperview_cache_lookups_total",
+		"hyperview_reinits_total",
+		"hyperview_render_errors_total",
+		"hyperview_renders_total",
+	}
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want), names...); err != nil {
+		t.Error(err)
+	}
+
+	if got := testutil.CollectAndCount(collector, "hyperview_render_duration_seconds"); got != 2 {
+		t.Errorf("render duration series = %d, want 2", got)
+	}
+}
+
+func TestCollector_ObserveVariant(t *testing.T) {
+	collector := metrics.NewCollector()
+	collector.ObserveVariant("views/pricing", "a")
+	collector.ObserveVariant("views/pricing", "b")
+	collector.ObserveVariant("views/pricing", "b")
+
+	want := `
+# HELP hyperview_variant_exposures_total Number of renders of template variants by template and variant.
+# TYPE hyperview_variant_exposures_total counter
+hyperview_variant_exposures_total{template="views/pricing",variant="a"} 1
+hyperview_variant_exposures_total{template="views/pricing",variant="b"} 2
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want), "hyperview_variant_exposures_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollector_Handler(t *testing.T) {
+	collector := metrics.NewCollector()
+	collector.ObserveCache(true)
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	for _, want := range []string{`hyperview_cache_lookups_total{result="hit"} 1`, "go_goroutines"} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+}
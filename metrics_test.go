@@ -0,0 +1,135 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/cache"
+	"github.com/hypergopher/hyperview/response"
+)
+
+type renderObservation struct {
+	adapter  string
+	template string
+	status   int
+	err      error
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	renders  []renderObservation
+	hits     int
+	misses   int
+	variants []string
+}
+
+func (m *recordingMetrics) ObserveRender(adapter, template string, _ time.Duration, status int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.renders = append(m.renders, renderObservation{adapter: adapter, template: template, status: status, err: err})
+}
+
+func (m *recordingMetrics) ObserveVariant(template, variant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.variants = append(m.variants, template+"@"+variant)
+}
+
+func (m *recordingMetrics) ObserveCache(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func TestWithMetrics_ObserveRender(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello{{end}}`)},
+		"views/broken.html": {Data: []byte(`{{define "page:main"}}{{template "missing" .}}{{end}}`)},
+	}
+
+	tests := []struct {
+		name       string
+		adapter    string
+		resp       *response.Response
+		wantStatus int
+		wantErr    bool
+	}{
+		{"html", "html", response.NewResponse().Path("views/home"), http.StatusOK, false},
+		{"html created", "html", response.NewResponse().Path("views/home").StatusCreated(), http.StatusCreated, false},
+		{"html missing template", "html", response.NewResponse().Path("views/missing"), http.StatusInternalServerError, true},
+		{"html template error", "html", response.NewResponse().Path("views/broken"), http.StatusInternalServerError, true},
+		{"json", "json", response.NewResponse().Path("views/home").Data(map[string]any{"ok": true}), http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &recordingMetrics{}
+			hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithMetrics(metrics))
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.RenderAs(w, httptest.NewRequest("GET", "/", nil), tt.adapter, tt.resp)
+
+			if len(metrics.renders) != 1 {
+				t.Fatalf("ObserveRender() called %d times, want 1", len(metrics.renders))
+			}
+			got := metrics.renders[0]
+			if got.adapter != tt.adapter || got.template != tt.resp.TemplatePath() {
+				t.Errorf("ObserveRender() adapter, template = %q, %q, want %q, %q", got.adapter, got.template, tt.adapter, tt.resp.TemplatePath())
+			}
+			if got.status != tt.wantStatus || got.status != w.Code {
+				t.Errorf("ObserveRender() status = %d, want %d (written %d)", got.status, tt.wantStatus, w.Code)
+			}
+			if (got.err != nil) != tt.wantErr {
+				t.Errorf("ObserveRender() err = %v, wantErr %v", got.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithMetrics_ObserveCache(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello{{end}}`)},
+	}
+
+	metrics := &recordingMetrics{}
+	store := cache.NewMemoryStore()
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithRenderCache(store), hyperview.WithMetrics(metrics))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := hv.RenderToString(httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("views/home").Cache("home", time.Minute)); err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+	}
+
+	handler := hyperview.NewCacheHandler(store, hyperview.WithCacheTTL(time.Minute), hyperview.WithCacheMetrics(metrics)).
+		Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("page"))
+		}))
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/page", nil))
+	}
+
+	if metrics.hits != 2 || metrics.misses != 2 {
+		t.Errorf("ObserveCache() hits, misses = %d, %d, want 2, 2", metrics.hits, metrics.misses)
+	}
+	if len(metrics.renders) != 2 {
+		t.Errorf("ObserveRender() called %d times, want 2", len(metrics.renders))
+	}
+}
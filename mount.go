@@ -0,0 +1,130 @@
+package hyperview
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/hypergopher/hyperview/constants"
+)
+
+// probeMethods are the methods probed to tell a 405 Method Not Allowed from a 404 Not Found on a ServeMux.
+var probeMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	http.MethodOptions,
+}
+
+// Router is a router that handles requests for patterns, such as http.ServeMux or a chi router.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// chiRouter is implemented by chi-style routers, which take the not found and method not allowed handlers
+// explicitly and match catch-all paths with a "*" wildcard.
+type chiRouter interface {
+	NotFound(h http.HandlerFunc)
+	MethodNotAllowed(h http.HandlerFunc)
+}
+
+// NotFoundHandler returns an http.Handler that renders the not found page, with the adapter for the request (json
+// for requests that prefer JSON, yaml for requests that accept YAML, html otherwise).
+func (s *HyperView) NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.RenderNotFoundAs(w, r, requestAdapterKey(r))
+	})
+}
+
+// MethodNotAllowedHandler returns an http.Handler that renders the method not allowed page, with the adapter for the
+// request (see NotFoundHandler).
+func (s *HyperView) MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.RenderMethodNotAllowedAs(w, r, requestAdapterKey(r))
+	})
+}
+
+// Mount wires the system pages and the static assets into the router under the prefix (e.g. "/"), so that unmatched
+// routes render the same not found and method not allowed pages as the handlers:
+//
+//   - The files of the assets directory of the template filesystem are served under prefix + "assets/" (see
+//     AssetsHandler), including the fingerprinted names of the assetPath function. Missing files and directories
+//     render the not found page.
+//   - For chi-style routers (with NotFound and MethodNotAllowed methods), the system page handlers are set on the
+//     router.
+//   - For an http.ServeMux, a catch-all pattern for the prefix renders the not found page, or the method not allowed
+//     page (with an Allow header) if the path matches a pattern for other methods. The prefix must not already be
+//     registered.
+func (s *HyperView) Mount(router Router, prefix string) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix != "/" {
+		prefix += "/"
+	}
+
+	chi, isChi := router.(chiRouter)
+
+	if assets := s.assetsFS(); assets != nil {
+		assetsPrefix := prefix + "assets/"
+		pattern := assetsPrefix
+		if isChi {
+			pattern += "*"
+		}
+		handler := s.newAssetsHandler(assetsPrefix, assets, s.assetPaths)
+		handler.pipeline = s.assetPipeline
+		router.Handle(pattern, handler)
+	}
+
+	if isChi {
+		chi.NotFound(s.NotFoundHandler().ServeHTTP)
+		chi.MethodNotAllowed(s.MethodNotAllowedHandler().ServeHTTP)
+		return
+	}
+
+	if mux, ok := router.(*http.ServeMux); ok {
+		router.Handle(prefix, s.serveMuxFallback(mux, prefix))
+		return
+	}
+
+	router.Handle(prefix, s.NotFoundHandler())
+}
+
+// serveMuxFallback returns the handler of the catch-all pattern of the mux, which renders the method not allowed page
+// if another method of the request path matches a pattern other than the catch-all pattern, and the not found page
+// otherwise.
+func (s *HyperView) serveMuxFallback(mux *http.ServeMux, catchAll string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, method := range probeMethods {
+			if method == r.Method {
+				continue
+			}
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			if _, pattern := mux.Handler(probe); pattern != "" && pattern != catchAll {
+				allowed = append(allowed, method)
+			}
+		}
+
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			s.MethodNotAllowedHandler().ServeHTTP(w, r)
+			return
+		}
+
+		s.NotFoundHandler().ServeHTTP(w, r)
+	})
+}
+
+// assetsFS returns the assets directory of the root template filesystem, or nil if there is none.
+func (s *HyperView) assetsFS() fs.FS {
+	root, ok := s.filesystemMap[constants.RootFSID]
+	if !ok {
+		return nil
+	}
+	if info, err := fs.Stat(root, constants.AssetsDir); err != nil || !info.IsDir() {
+		return nil
+	}
+	assets, err := fs.Sub(root, constants.AssetsDir)
+	if err != nil {
+		return nil
+	}
+	return assets
+}
@@ -0,0 +1,103 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+)
+
+func mountTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Page not found{{end}}`)},
+		"views/system/405.html": {Data: []byte(`{{define "page:main"}}Method not allowed{{end}}`)},
+		"assets/css/app.css":    {Data: []byte(`body { color: red; }`)},
+	}
+}
+
+func TestMount_ServeMux(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(mountTestFS()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Users"))
+	})
+	hv.Mount(mux, "/")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantAllow  string
+	}{
+		{"route", "GET", "/users", http.StatusOK, "Users", ""},
+		{"not found", "GET", "/missing", http.StatusNotFound, "Page not found", ""},
+		{"method not allowed", "POST", "/users", http.StatusMethodNotAllowed, "Method not allowed", "GET, HEAD"},
+		{"asset", "GET", "/assets/css/app.css", http.StatusOK, "color: red", ""},
+		{"missing asset", "GET", "/assets/css/missing.css", http.StatusNotFound, "Page not found", ""},
+		{"asset directory", "GET", "/assets/css/", http.StatusNotFound, "Page not found", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Allow = %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+// chiStyleRouter records the handlers set by Mount on a chi-style router.
+type chiStyleRouter struct {
+	patterns         []string
+	notFound         http.HandlerFunc
+	methodNotAllowed http.HandlerFunc
+}
+
+func (r *chiStyleRouter) Handle(pattern string, _ http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+func (r *chiStyleRouter) NotFound(h http.HandlerFunc)         { r.notFound = h }
+func (r *chiStyleRouter) MethodNotAllowed(h http.HandlerFunc) { r.methodNotAllowed = h }
+
+func TestMount_ChiStyleRouter(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(mountTestFS()))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	router := &chiStyleRouter{}
+	hv.Mount(router, "/app")
+
+	if len(router.patterns) != 1 || router.patterns[0] != "/app/assets/*" {
+		t.Errorf("patterns = %v, want [/app/assets/*]", router.patterns)
+	}
+	if router.notFound == nil || router.methodNotAllowed == nil {
+		t.Fatal("Mount() did not set the not found and method not allowed handlers")
+	}
+
+	w := httptest.NewRecorder()
+	router.methodNotAllowed(w, httptest.NewRequest("DELETE", "/app/users", nil))
+	if w.Code != http.StatusMethodNotAllowed || !strings.Contains(w.Body.String(), "Method not allowed") {
+		t.Errorf("method not allowed handler = %d %q, want the method not allowed page", w.Code, w.Body.String())
+	}
+}
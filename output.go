@@ -0,0 +1,169 @@
+package hyperview
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool, so that a few large renders
+// don't keep their memory alive for the lifetime of the process.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool pools the buffers that rendered output is captured in before it is written.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool. It must be returned with putBuffer once its content is no longer
+// referenced.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns the buffer to the pool, unless it grew beyond maxPooledBufferSize.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// setCookies adds a Set-Cookie header for each cookie of the response. Cookies are added rather than set, so that the
+// cookies set by middleware (e.g. a session cookie) are kept.
+func setCookies(w http.ResponseWriter, resp *response.Response) {
+	for _, cookie := range resp.Cookies() {
+		http.SetCookie(w, cookie)
+	}
+}
+
+// setDefaultHeaders adds the default response headers (see WithDefaultResponseHeaders) that are not already set to w.
+func (s *HyperView) setDefaultHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	for key, values := range s.defaultHeaders {
+		if _, ok := header[key]; !ok {
+			header[key] = slices.Clone(values)
+		}
+	}
+}
+
+// writeNoContent writes the headers, cookies, and status code of a response without a body (see Response.NoContent).
+func writeNoContent(w http.ResponseWriter, resp *response.Response) {
+	for key, value := range resp.Headers() {
+		w.Header().Set(key, value)
+	}
+	setCookies(w, resp)
+	w.WriteHeader(resp.StatusCode())
+}
+
+// addVary adds the headers to the Vary header, unless it already contains them.
+func addVary(header http.Header, headers ...string) {
+	if len(headers) == 0 {
+		return
+	}
+
+	var existing []string
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			existing = append(existing, strings.ToLower(strings.TrimSpace(field)))
+		}
+	}
+
+	for _, h := range headers {
+		if !slices.Contains(existing, strings.ToLower(h)) && !slices.Contains(existing, "*") {
+			header.Add("Vary", h)
+			existing = append(existing, strings.ToLower(h))
+		}
+	}
+}
+
+// writeOutput writes the rendered body of a response with the status code. The headers of the response must already
+// be set on w. With etags, successful responses to GET and HEAD requests get an ETag computed from the body (unless
+// the handler set one), and requests whose If-None-Match header matches the ETag get a 304 Not Modified response
+// without the body.
+func writeOutput(w http.ResponseWriter, r *http.Request, status int, body []byte, etags bool) error {
+	if etags && status == http.StatusOK && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			h := fnv.New128a()
+			_, _ = h.Write(body)
+			etag = fmt.Sprintf(`"%x"`, h.Sum(nil))
+			w.Header().Set("ETag", etag)
+		}
+
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+
+// etagMatches returns true if the If-None-Match header matches the ETag, using the weak comparison of RFC 9110.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bufferedWriter buffers the status code and body written to a response, so that they can be written with
+// writeOutput once the response is rendered. Headers are written to the underlying ResponseWriter.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// newBufferedWriter creates a new bufferedWriter for w.
+func newBufferedWriter(w http.ResponseWriter) *bufferedWriter {
+	return &bufferedWriter{ResponseWriter: w}
+}
+
+func (bw *bufferedWriter) WriteHeader(status int) {
+	if bw.status == 0 {
+		bw.status = status
+	}
+}
+
+func (bw *bufferedWriter) Write(p []byte) (int, error) {
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+	return bw.body.Write(p)
+}
+
+// flush writes the buffered response with writeOutput.
+func (bw *bufferedWriter) flush(r *http.Request, etags bool) error {
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+	return writeOutput(bw.ResponseWriter, r, bw.status, bw.body.Bytes(), etags)
+}
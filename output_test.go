@@ -0,0 +1,107 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithETags(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}<main>{{template "page:main" .}}</main>{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello {{.Name}}{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithETags())
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		resp func() *response.Response
+	}{
+		{name: "html", resp: func() *response.Response {
+			return response.NewResponse().Path("home").Data(map[string]any{"Name": "Gopher"})
+		}},
+		{name: "json", resp: func() *response.Response {
+			return response.NewResponse().RawJSON(map[string]string{"name": "Gopher"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), tt.resp())
+
+			etag := w.Header().Get("ETag")
+			if w.Code != http.StatusOK || etag == "" || w.Body.Len() == 0 {
+				t.Fatalf("Render() = %d with ETag %q and body %q, want 200 with an ETag and a body", w.Code, etag, w.Body.String())
+			}
+
+			matching := []struct {
+				ifNoneMatch string
+				wantStatus  int
+			}{
+				{ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+				{ifNoneMatch: `"other", W/` + etag, wantStatus: http.StatusNotModified},
+				{ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+				{ifNoneMatch: `"other"`, wantStatus: http.StatusOK},
+			}
+
+			for _, m := range matching {
+				r := httptest.NewRequest("GET", "/", nil)
+				r.Header.Set("If-None-Match", m.ifNoneMatch)
+				w := httptest.NewRecorder()
+				hv.Render(w, r, tt.resp())
+
+				if w.Code != m.wantStatus {
+					t.Errorf("If-None-Match %s: status = %d, want %d", m.ifNoneMatch, w.Code, m.wantStatus)
+				}
+				if m.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+					t.Errorf("If-None-Match %s: body = %q, want empty", m.ifNoneMatch, w.Body.String())
+				}
+				if got := w.Header().Get("ETag"); got != etag {
+					t.Errorf("If-None-Match %s: ETag = %q, want %q", m.ifNoneMatch, got, etag)
+				}
+			}
+		})
+	}
+}
+
+func TestWithETags_HandlerETag(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithETags())
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	resp := response.NewResponse().RawJSON(map[string]int{"version": 7})
+	resp.ETag(`"v7"`)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", `"v7"`)
+	w := httptest.NewRecorder()
+	hv.Render(w, r, resp)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+	}
+}
+
+func TestWithoutETags(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().RawJSON(map[string]string{"name": "Gopher"}))
+
+	if got := w.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag = %q, want none", got)
+	}
+}
@@ -0,0 +1,129 @@
+package hyperview
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// overlayFS is an fs.FS that layers multiple filesystems on top of each other. Files in a higher-priority layer
+// shadow files with the same path in lower-priority layers, and directory listings are merged across all layers.
+type overlayFS struct {
+	layers []fs.FS
+}
+
+// NewOverlayFS creates a filesystem that layers the given filesystems, from the highest priority to the lowest.
+// Templates and partials with the same path in a higher-priority filesystem shadow those in lower-priority ones.
+//
+// Example: NewOverlayFS(appFS, pluginFS) checks appFS first, then pluginFS.
+func NewOverlayFS(layers ...fs.FS) fs.FS {
+	return &overlayFS{layers: layers}
+}
+
+// Open opens the named file from the highest-priority layer that contains it.
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	for _, layer := range o.layers {
+		f, err := layer.Open(name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		// Directories are listed across all layers
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if info.IsDir() {
+			return &overlayDir{File: f, fsys: o, name: name}, nil
+		}
+
+		return f, nil
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir reads the named directory from all layers and returns the merged entries sorted by filename.
+// If an entry exists in multiple layers, the entry from the highest-priority layer is used.
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	found := false
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for _, layer := range o.layers {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		found = true
+		for _, entry := range layerEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return entries, nil
+}
+
+// overlayDir is a directory opened from an overlayFS, which lists the merged entries of all layers.
+type overlayDir struct {
+	fs.File
+	fsys    *overlayFS
+	name    string
+	entries []fs.DirEntry
+	read    bool
+}
+
+// ReadDir reads the merged directory entries, following the semantics of fs.ReadDirFile.
+func (d *overlayDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.read = true
+	}
+
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
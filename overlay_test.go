@@ -0,0 +1,45 @@
+package hyperview_test
+
+import (
+	"io/fs"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestOverlayFS(t *testing.T) {
+	app := fstest.MapFS{
+		"partials/name.html": {Data: []byte(`{{define "@name"}}<i>{{.}}</i>{{end}}`)},
+		"views/about.html":   {Data: []byte(`{{define "page:main"}}About{{end}}`)},
+	}
+	overlay := hyperview.NewOverlayFS(app, testTemplateFS())
+
+	if err := fstest.TestFS(overlay, "layouts/base.html", "partials/name.html", "views/home.html", "views/about.html"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := fs.ReadDir(overlay, "views")
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"about.html", "home.html", "users.html"}; !slices.Equal(names, want) {
+		t.Errorf("ReadDir() = %v, want %v", names, want)
+	}
+
+	adapter := newTestTemplateAdapter(t, overlay)
+	got, err := adapter.RenderToString(httptest.NewRequest("GET", "/", nil), response.NewResponse().Layout("base").Path("home").Data(map[string]any{"Name": "Gopher"}))
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+	if want := "<main>Hello <i>Gopher</i></main>"; got != want {
+		t.Errorf("RenderToString() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package hyperview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultAssetManifest is the name of the manifest of built assets in the assets directory, unless set with
+// AssetPipelineManifest.
+const DefaultAssetManifest = "manifest.json"
+
+// AssetBuilder builds the entry points of the assets, such as JavaScript and CSS bundles, e.g. with the API of
+// esbuild. Builders are called by one goroutine at a time.
+type AssetBuilder interface {
+	// Build builds the entry points into the assets directory of the template filesystem and returns the manifest of
+	// the built files: their names in the assets directory by the logical names of the entry points, e.g.
+	// "js/app.js": "dist/app.js".
+	Build(ctx context.Context) (map[string]string, error)
+}
+
+// AssetBuilderFunc is a function that implements AssetBuilder.
+type AssetBuilderFunc func(ctx context.Context) (map[string]string, error)
+
+// Build calls the function.
+func (f AssetBuilderFunc) Build(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+// AssetPipelineOption configures the asset pipeline (see WithAssetPipeline).
+type AssetPipelineOption func(*assetPipeline)
+
+// AssetPipelineDev builds the assets at startup and rebuilds them on demand, when one of the built files is
+// requested, instead of reading the manifest written by the production build.
+func AssetPipelineDev(dev bool) AssetPipelineOption {
+	return func(p *assetPipeline) {
+		p.dev = dev
+	}
+}
+
+// AssetPipelineManifest sets the name of the manifest of built assets in the assets directory (default:
+// DefaultAssetManifest).
+func AssetPipelineManifest(name string) AssetPipelineOption {
+	return func(p *assetPipeline) {
+		p.manifest = name
+	}
+}
+
+// assetPipeline is the configuration of the asset pipeline.
+type assetPipeline struct {
+	builder  AssetBuilder
+	dev      bool
+	manifest string
+	building singleflight.Group // coalesces concurrent rebuilds
+	built    map[string]string  // manifest of the last build
+}
+
+// WithAssetPipeline sets the pipeline of the built assets, whose logical names the assetPath function maps to their
+// built files, e.g. {{assetPath "js/app.js"}} to /assets/dist/app-3F2A9C1B.js:
+//
+//   - In development (see AssetPipelineDev), the builder builds the assets at startup, and again whenever one of the
+//     built files is requested, so that changes of the sources are picked up on reload. The built files should have
+//     names without hashes, which are served with Cache-Control: no-cache.
+//   - In production, the manifest written by the build, a JSON object of the built files by logical name, is read
+//     from the assets directory (see AssetPipelineManifest). The built files should have fingerprinted names, which
+//     are served with an immutable Cache-Control header. The builder is not called and can be nil.
+//
+// It returns an error in development without a builder. NewHyperView returns an error if the first build fails or
+// the manifest can't be read.
+func WithAssetPipeline(builder AssetBuilder, opts ...AssetPipelineOption) Option {
+	return func(hgo *HyperView) error {
+		p := &assetPipeline{builder: builder, manifest: DefaultAssetManifest}
+		for _, opt := range opts {
+			opt(p)
+		}
+		if p.dev && builder == nil {
+			return errors.New("asset pipeline: a builder is required in development")
+		}
+		hgo.assetPipeline = p
+		return nil
+	}
+}
+
+// assetManifest returns the manifest of the asset pipeline, built in development and read from the assets directory
+// in production, or nil without an asset pipeline.
+func (s *HyperView) assetManifest() (map[string]string, error) {
+	p := s.assetPipeline
+	if p == nil {
+		return nil, nil
+	}
+	if p.dev {
+		return p.build(context.Background())
+	}
+
+	assets := s.assetsFS()
+	if assets == nil {
+		return nil, errors.New("asset pipeline: no assets directory for the manifest")
+	}
+	data, err := fs.ReadFile(assets, p.manifest)
+	if err != nil {
+		return nil, fmt.Errorf("asset pipeline: reading manifest: %w", err)
+	}
+	var manifest map[string]string
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("asset pipeline: decoding manifest %s: %w", p.manifest, err)
+	}
+	return manifest, nil
+}
+
+// rebuildAssets builds the assets of the asset pipeline again. Concurrent rebuilds, e.g. for the built files requested
+// by a page, share a single build. The manifest and the cached assets are only updated if the manifest changed.
+func (s *HyperView) rebuildAssets(ctx context.Context) error {
+	p := s.assetPipeline
+	// The build is shared, so it must not be canceled with the request that started it
+	ctx = context.WithoutCancel(ctx)
+	_, err, _ := p.building.Do("build", func() (any, error) {
+		previous := p.built
+		manifest, err := p.build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !maps.Equal(manifest, previous) {
+			s.assetPaths.SetManifest(manifest)
+			s.assetPaths.Reset()
+			s.assetInliner.Reset()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// build runs the builder and keeps the manifest of the build. It is called once at startup, and by rebuildAssets,
+// which runs one build at a time.
+func (p *assetPipeline) build(ctx context.Context) (map[string]string, error) {
+	manifest, err := p.builder.Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("asset pipeline: build: %w", err)
+	}
+	p.built = maps.Clone(manifest)
+	return manifest, nil
+}
@@ -0,0 +1,168 @@
+package hyperview_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func pipelineTestFS() fstest.MapFS {
+	fsys := mountTestFS()
+	fsys["views/home.html"] = &fstest.MapFile{Data: []byte(`{{define "page:main"}}<script src="{{assetPath "js/app.js"}}"></script>{{end}}`)}
+	return fsys
+}
+
+func TestAssetPipeline_Dev(t *testing.T) {
+	fsys := pipelineTestFS()
+	builds := 0
+	builder := hyperview.AssetBuilderFunc(func(ctx context.Context) (map[string]string, error) {
+		builds++
+		fsys["assets/dist/app.js"] = &fstest.MapFile{Data: []byte(fmt.Sprintf("build %d", builds))}
+		return map[string]string{"js/app.js": "dist/app.js"}, nil
+	})
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys),
+		hyperview.WithAssetPipeline(builder, hyperview.AssetPipelineDev(true)))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	if builds != 1 {
+		t.Fatalf("builds = %d, want a build at startup", builds)
+	}
+
+	w := httptest.NewRecorder()
+	hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("home"))
+	if want := `<script src="/assets/dist/app.js"></script>`; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+
+	mux := http.NewServeMux()
+	hv.Mount(mux, "/")
+	for want := 2; want <= 3; want++ {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/assets/dist/app.js", nil))
+		if body := fmt.Sprintf("build %d", want); w.Body.String() != body {
+			t.Errorf("body = %q, want %q after a rebuild", w.Body.String(), body)
+		}
+		if got := w.Header().Get("Cache-Control"); got != "no-cache" {
+			t.Errorf("Cache-Control = %q, want no-cache", got)
+		}
+	}
+
+	// Other assets are not rebuilt
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/assets/css/app.css", nil))
+	if builds != 3 {
+		t.Errorf("builds = %d, want 3", builds)
+	}
+}
+
+func TestAssetPipeline_ConcurrentRebuilds(t *testing.T) {
+	fsys := pipelineTestFS()
+	fsys["assets/dist/app.js"] = &fstest.MapFile{Data: []byte(`console.log("built")`)}
+	fsys["assets/dist/app.v2.js"] = &fstest.MapFile{Data: []byte(`console.log("built")`)}
+
+	var builds atomic.Int32
+	started := make(chan struct{})
+	var startOnce sync.Once
+	release := make(chan struct{})
+	builder := hyperview.AssetBuilderFunc(func(ctx context.Context) (map[string]string, error) {
+		if builds.Add(1) == 1 {
+			return map[string]string{"js/app.js": "dist/app.js"}, nil
+		}
+		startOnce.Do(func() { close(started) })
+		<-release
+		return map[string]string{"js/app.js": "dist/app.v2.js"}, nil
+	})
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys),
+		hyperview.WithAssetPipeline(builder, hyperview.AssetPipelineDev(true)))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	mux := http.NewServeMux()
+	hv.Mount(mux, "/")
+
+	const requests = 5
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/assets/dist/app.js", nil))
+		}()
+	}
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := builds.Load() - 1; got >= requests {
+		t.Errorf("rebuilds = %d, want the %d concurrent requests to share builds", got, requests)
+	}
+
+	// The changed manifest is picked up
+	w := httptest.NewRecorder()
+	hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("home"))
+	if want := `<script src="/assets/dist/app.v2.js"></script>`; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+}
+
+func TestAssetPipeline_Prod(t *testing.T) {
+	fsys := pipelineTestFS()
+	fsys["assets/manifest.json"] = &fstest.MapFile{Data: []byte(`{"js/app.js": "dist/app-3F2A9C1B.js"}`)}
+	fsys["assets/dist/app-3F2A9C1B.js"] = &fstest.MapFile{Data: []byte(`console.log("built")`)}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys), hyperview.WithAssetPipeline(nil))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("home"))
+	if want := `<script src="/assets/dist/app-3F2A9C1B.js"></script>`; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+
+	mux := http.NewServeMux()
+	hv.Mount(mux, "/")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/assets/dist/app-3F2A9C1B.js", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "public, max-age=31536000, immutable" {
+		t.Errorf("status = %d, Cache-Control = %q, want 200 and immutable", w.Code, w.Header().Get("Cache-Control"))
+	}
+}
+
+func TestAssetPipeline_Errors(t *testing.T) {
+	failing := hyperview.AssetBuilderFunc(func(ctx context.Context) (map[string]string, error) {
+		return nil, errors.New("syntax error")
+	})
+
+	tests := []struct {
+		name string
+		opts []hyperview.Option
+	}{
+		{"dev without builder", []hyperview.Option{hyperview.WithAssetPipeline(nil, hyperview.AssetPipelineDev(true))}},
+		{"failed build", []hyperview.Option{hyperview.WithAssetPipeline(failing, hyperview.AssetPipelineDev(true))}},
+		{"missing manifest", []hyperview.Option{hyperview.WithAssetPipeline(nil)}},
+		{"other manifest", []hyperview.Option{hyperview.WithAssetPipeline(nil, hyperview.AssetPipelineManifest("css/app.css"))}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := hyperview.NewHyperView(append(tt.opts, hyperview.WithTemplateFS(pipelineTestFS()))...); err == nil {
+				t.Error("NewHyperView() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package hyperview
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemDetails is a problem details object as defined by RFC 9457 (formerly RFC 7807), which is rendered as
+// application/problem+json. Extension members are added to the top level of the object.
+type ProblemDetails struct {
+	// Type is a URI reference that identifies the problem type. Default is "about:blank".
+	Type string `json:"type,omitempty"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies this occurrence of the problem, e.g. the request path.
+	Instance string `json:"instance,omitempty"`
+	// Extensions are additional members of the problem details object, e.g. field errors.
+	Extensions map[string]any `json:"-"`
+}
+
+// NewProblemDetails creates a problem details object for the given status with the detail message. The type is
+// "about:blank" and the title is the standard status text, as recommended for problems without a specific type.
+func NewProblemDetails(status int, detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// With adds an extension member to the problem details and returns the problem details.
+func (p *ProblemDetails) With(key string, value any) *ProblemDetails {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// withInstance sets the request path as the instance of the problem details, if there is a request.
+func (p *ProblemDetails) withInstance(r *http.Request) *ProblemDetails {
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+	return p
+}
+
+// MarshalJSON encodes the problem details with the extension members at the top level. Extension members never
+// override the standard members.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	standard := map[string]any{"type": p.Type, "title": p.Title, "detail": p.Detail, "instance": p.Instance}
+	for key, value := range standard {
+		delete(members, key)
+		if value != "" {
+			members[key] = value
+		}
+	}
+
+	delete(members, "status")
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+
+	return json.Marshal(members)
+}
+
+// JSONProblem writes the problem details as application/problem+json with the status of the problem (or 500 if
+// the status is not set). Optional headers can be provided to set additional response headers.
+func JSONProblem(w http.ResponseWriter, problem *ProblemDetails, headers ...http.Header) error {
+	status := problem.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	return writeJSON(w, currentJSONEncoder(), jsonIndent, status, problemContentType, problem, headers...)
+}
@@ -0,0 +1,74 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestJSONAdapter_ProblemDetails(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithViewAdapter("json", hyperview.NewJSONViewAdapter(hyperview.WithProblemDetails())))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		render     func(w http.ResponseWriter, r *http.Request)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "failure",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.Render(w, r, response.NewResponse().Path("users.json").Errors("Invalid user", map[string]string{"email": "is required"}))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "{\n\t\"detail\": \"Invalid user\",\n\t\"errors\": {\n\t\t\"email\": \"is required\"\n\t},\n\t\"instance\": \"/users\",\n\t\"status\": 422,\n\t\"title\": \"Unprocessable Entity\",\n\t\"type\": \"about:blank\"\n}\n",
+		},
+		{
+			name: "not found",
+			render: func(w http.ResponseWriter, r *http.Request) {
+				hv.RenderNotFoundAs(w, r, "json")
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "{\n\t\"detail\": \"Not found\",\n\t\"instance\": \"/users\",\n\t\"status\": 404,\n\t\"title\": \"Not Found\",\n\t\"type\": \"about:blank\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.render(w, httptest.NewRequest("POST", "/users", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", got)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestProblemDetails_MarshalJSON(t *testing.T) {
+	problem := hyperview.NewProblemDetails(http.StatusConflict, "Already exists").
+		With("id", 42).
+		With("status", "ignored")
+
+	got, err := problem.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	want := `{"detail":"Already exists","id":42,"status":409,"title":"Conflict","type":"about:blank"}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package hyperview
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// devReloadInterval is how often the watched template directories are checked for changes.
+const devReloadInterval = 500 * time.Millisecond
+
+// WithDevReload watches the given template directories on disk and calls Reinit automatically when files are added,
+// removed, or changed. This is intended for development only and should be used with a disk-backed filesystem
+// (see WithTemplateFS and os.DirFS), as embedded filesystems do not change at runtime.
+//
+// Directories are polled, so no additional dependencies or OS-specific file notification APIs are required.
+// Renders that are in flight while templates are reloaded complete with the previous templates.
+// Call HyperView.Close to stop watching.
+func WithDevReload(dirs ...string) Option {
+	return func(hgo *HyperView) error {
+		for _, dir := range dirs {
+			if _, err := os.Stat(dir); err != nil {
+				return fmt.Errorf("unable to watch template directory: %w", err)
+			}
+		}
+		hgo.devReloadDirs = append(hgo.devReloadDirs, dirs...)
+		return nil
+	}
+}
+
+// Close stops any background work started by the HyperView instance, such as the dev reload watcher, and removes the
+// instance from the expvar stats (see WithExpvar).
+func (s *HyperView) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.expvar {
+			unpublishExpvar(s)
+		}
+	})
+	return nil
+}
+
+// watchTemplates polls the dev reload directories and reinitializes the adapters when their contents change.
+// The fingerprint of the directories at the time the templates were last loaded is passed in as last.
+func (s *HyperView) watchTemplates(last uint64) {
+	ticker := time.NewTicker(devReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			current := s.templatesFingerprint()
+			if current == last {
+				continue
+			}
+			last = current
+
+			if err := s.Reinit(); err != nil {
+				s.logger.Error("Error reloading templates", slog.String("err", err.Error()))
+				continue
+			}
+			s.logger.Debug("Templates reloaded")
+		}
+	}
+}
+
+// templatesFingerprint returns a hash of the names, sizes, and modification times of all files in the dev reload directories.
+func (s *HyperView) templatesFingerprint() uint64 {
+	h := fnv.New64a()
+	for _, dir := range s.devReloadDirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			_, _ = fmt.Fprintf(h, "%s|%d|%d\n", path, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,59 @@
+package hyperview_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithDevReload(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("layouts/base.html", `{{define "layout:base"}}{{template "page:main" .}}{{end}}`)
+	writeFile("partials/empty.html", `{{define "@empty"}}{{end}}`)
+	writeFile("views/home.html", `{{define "page:main"}}v1{{end}}`)
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(os.DirFS(dir)), hyperview.WithDevReload(dir))
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+	defer func() { _ = hv.Close() }()
+
+	r := httptest.NewRequest("GET", "/", nil)
+	render := func() string {
+		got, err := hv.RenderToString(r, response.NewResponse().Path("home"))
+		if err != nil {
+			t.Fatalf("RenderToString() error = %v", err)
+		}
+		return got
+	}
+
+	if got := render(); got != "v1" {
+		t.Fatalf("render = %q, want %q", got, "v1")
+	}
+
+	writeFile("views/home.html", `{{define "page:main"}}v2 (updated){{end}}`)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for render() != "v2 (updated)" {
+		if time.Now().After(deadline) {
+			t.Fatal("templates were not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
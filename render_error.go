@@ -0,0 +1,172 @@
+package hyperview
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors that RenderError renders with the matching system renderer. Wrap them to add context, e.g.
+// fmt.Errorf("user %d: %w", id, hyperview.ErrNotFound).
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrForbidden        = errors.New("forbidden")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrMaintenance      = errors.New("maintenance")
+)
+
+// StatusError is an error that carries an HTTP status code, which RenderError uses to select the system renderer.
+type StatusError interface {
+	error
+	StatusCode() int
+}
+
+// statusError is an error with an HTTP status code.
+type statusError struct {
+	status int
+	err    error
+}
+
+// NewStatusError returns an error with the given HTTP status code that wraps err. If err is nil, the error message
+// is the status text.
+func NewStatusError(status int, err error) error {
+	if err == nil {
+		err = errors.New(strings.ToLower(http.StatusText(status)))
+	}
+	return &statusError{status: status, err: err}
+}
+
+func (e *statusError) Error() string   { return e.err.Error() }
+func (e *statusError) Unwrap() error   { return e.err }
+func (e *statusError) StatusCode() int { return e.status }
+
+// ValidationError is an error with a message and field errors, which RenderError renders as 422 Unprocessable Entity
+// with the errors added to the view data (see Response.Errors).
+type ValidationError struct {
+	Message string
+	Fields  map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%d field errors)", e.Message, len(e.Fields))
+}
+
+// ErrorRenderer renders the response for an error with the adapter of the given key.
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, adapterKey string, err error)
+
+// errorMapping maps the errors matched by match to a renderer.
+type errorMapping struct {
+	match  func(err error) bool
+	render ErrorRenderer
+}
+
+// WithErrorRenderer registers a renderer for the errors matched by match, which RenderError consults before the
+// built-in mappings. Renderers are consulted in the order they are registered. See ErrorIs and ErrorAs for matchers.
+func WithErrorRenderer(match func(err error) bool, render ErrorRenderer) Option {
+	return func(hgo *HyperView) error {
+		hgo.errorRenderers = append(hgo.errorRenderers, errorMapping{match: match, render: render})
+		return nil
+	}
+}
+
+// ErrorIs returns a matcher for WithErrorRenderer that matches errors that are (or wrap) the target (see errors.Is).
+func ErrorIs(target error) func(err error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// ErrorAs returns a matcher for WithErrorRenderer that matches errors of type T, or that wrap an error of type T
+// (see errors.As).
+func ErrorAs[T error]() func(err error) bool {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// RenderError renders the response for the error with the html adapter. See RenderErrorAs.
+func (s *HyperView) RenderError(w http.ResponseWriter, r *http.Request, err error) {
+	s.RenderErrorAs(w, r, "html", err)
+}
+
+// RenderErrorAs renders the response for the error with the specified adapter, so handlers do not have to pick the
+// system renderer for each error. The renderers registered with WithErrorRenderer are consulted first, followed by the
+// built-in mappings:
+//
+//   - ErrNotFound: RenderNotFound
+//   - ErrForbidden: RenderForbidden
+//   - ErrUnauthorized: RenderUnauthorized
+//   - ErrMethodNotAllowed: RenderMethodNotAllowed
+//   - ErrMaintenance: RenderMaintenance
+//   - *ValidationError: RenderUnprocessable with the errors
+//   - StatusError: RenderStatus with the status code (4xx) or RenderSystemError
+//   - any other error: RenderSystemError
+func (s *HyperView) RenderErrorAs(w http.ResponseWriter, r *http.Request, adapterKey string, err error) {
+	for _, mapping := range s.errorRenderers {
+		if mapping.match(err) {
+			mapping.render(w, r, adapterKey, err)
+			return
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		s.RenderNotFoundAs(w, r, adapterKey)
+		return
+	case errors.Is(err, ErrForbidden):
+		s.RenderForbiddenAs(w, r, adapterKey)
+		return
+	case errors.Is(err, ErrUnauthorized):
+		s.RenderUnauthorizedAs(w, r, adapterKey)
+		return
+	case errors.Is(err, ErrMethodNotAllowed):
+		s.RenderMethodNotAllowedAs(w, r, adapterKey)
+		return
+	case errors.Is(err, ErrMaintenance):
+		s.RenderMaintenanceAs(w, r, adapterKey)
+		return
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		s.renderValidationErrorAs(w, r, adapterKey, validationErr)
+		return
+	}
+
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		s.renderStatusErrorAs(w, r, adapterKey, statusErr)
+		return
+	}
+
+	s.RenderSystemErrorAs(w, r, adapterKey, err)
+}
+
+// renderStatusErrorAs renders the system page of the status code of the error (see RenderStatusAs). Status codes
+// outside of the 4xx range render the system error page.
+func (s *HyperView) renderStatusErrorAs(w http.ResponseWriter, r *http.Request, adapterKey string, err StatusError) {
+	status := err.StatusCode()
+	switch {
+	case status == http.StatusUnprocessableEntity:
+		s.renderValidationErrorAs(w, r, adapterKey, &ValidationError{Message: err.Error()})
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		s.RenderStatusAs(w, r, adapterKey, status)
+	default:
+		s.RenderSystemErrorAs(w, r, adapterKey, err)
+	}
+}
+
+// renderValidationErrorAs renders the 422 system page (see RenderUnprocessableAs) with the errors of the validation
+// error.
+func (s *HyperView) renderValidationErrorAs(w http.ResponseWriter, r *http.Request, adapterKey string, err *ValidationError) {
+	s.setDefaultHeaders(w)
+	if adapter, ok := s.adapterFor(w, adapterKey); ok {
+		renderStatus(w, r, adapter, s.newSystemResponse(adapterKey).Errors(err.Message, err.Fields))
+	}
+}
@@ -0,0 +1,83 @@
+package hyperview_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+)
+
+type teapotError struct{}
+
+func (teapotError) Error() string { return "teapot" }
+
+func TestRenderError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":     {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/system/404.html": {Data: []byte(`{{define "page:main"}}Page not found{{end}}`)},
+		"views/system/422.html": {Data: []byte(`{{define "page:main"}}{{.Error}}: {{index .Errors "email"}}{{end}}`)},
+		"views/system/500.html": {Data: []byte(`{{define "page:main"}}Server error{{end}}`)},
+		"views/system/403.html": {Data: []byte(`{{define "page:main"}}Forbidden page{{end}}`)},
+		"views/system/503.html": {Data: []byte(`{{define "page:main"}}Down for maintenance{{end}}`)},
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"not found", fmt.Errorf("user 1: %w", hyperview.ErrNotFound), http.StatusNotFound, "Page not found"},
+		{"forbidden", hyperview.ErrForbidden, http.StatusForbidden, "Forbidden page"},
+		{"maintenance", hyperview.ErrMaintenance, http.StatusServiceUnavailable, "Down for maintenance"},
+		{"unauthorized without template", hyperview.ErrUnauthorized, http.StatusUnauthorized, "Unauthorized"},
+		{"validation", &hyperview.ValidationError{Message: "Invalid user", Fields: map[string]string{"email": "is required"}}, http.StatusUnprocessableEntity, "Invalid user: is required"},
+		{"status error", hyperview.NewStatusError(http.StatusNotFound, errors.New("no such user")), http.StatusNotFound, "Page not found"},
+		{"status error without renderer", hyperview.NewStatusError(http.StatusConflict, nil), http.StatusConflict, "Conflict"},
+		{"custom renderer", fmt.Errorf("brewing: %w", teapotError{}), http.StatusTeapot, "I'm a teapot"},
+		{"other error", errors.New("database is down"), http.StatusInternalServerError, "Server error"},
+	}
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithErrorRenderer(hyperview.ErrorAs[teapotError](), func(w http.ResponseWriter, r *http.Request, adapterKey string, err error) {
+			http.Error(w, "I'm a teapot", http.StatusTeapot)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.RenderError(w, httptest.NewRequest("GET", "/", nil), tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRenderError_ValidationWithoutTemplate(t *testing.T) {
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fstest.MapFS{}))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	hv.RenderError(w, httptest.NewRequest("GET", "/", nil), &hyperview.ValidationError{Message: "Invalid"})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,23 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/hypergopher/hyperview/bind"
+)
+
+// Bind binds the query, form, and multipart values of the request to the struct dst points to, by the names of the
+// form tags of its fields (see the bind package). Values that can't be converted to the type of their field are
+// returned as bind.FieldErrors, which can be passed to Response.Errors:
+//
+//	var form signupForm
+//	if err := request.Bind(r, &form); err != nil {
+//		var fieldErrs bind.FieldErrors
+//		if !errors.As(err, &fieldErrs) {
+//			return nil, err
+//		}
+//		return resp.Errors("Please correct the errors below.", fieldErrs), nil
+//	}
+func Bind(r *http.Request, dst any) error {
+	return bind.Request(r, dst)
+}
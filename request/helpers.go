@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -107,6 +108,43 @@ func IsJSONRequest(r *http.Request) bool {
 	return r.Header.Get("Content-Type") == "application/json"
 }
 
+// AcceptsYAML returns true if the preferred media type of the Accept header is application/yaml
+// (or one of its aliases application/x-yaml, text/yaml, and text/x-yaml).
+func AcceptsYAML(r *http.Request) bool {
+	switch PreferredMediaType(r) {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+	return false
+}
+
+// PreferredMediaType returns the media type with the highest quality value in the Accept header of the request,
+// or an empty string if there is no Accept header. If multiple media types have the same quality, the first is used.
+func PreferredMediaType(r *http.Request) string {
+	preferred, best := "", -1.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > best {
+			preferred, best = mediaType, q
+		}
+	}
+	return preferred
+}
+
 // IsFormRequest returns true if the request has a Content-Type of application/x-www-form-urlencoded
 func IsFormRequest(r *http.Request) bool {
 	return r.Header.Get("Content-Type") == "application/x-www-form-urlencoded"
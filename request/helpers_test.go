@@ -147,3 +147,27 @@ func TestInPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPreferredMediaType(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		want       string
+		acceptYAML bool
+	}{
+		{name: "empty", accept: "", want: ""},
+		{name: "single", accept: "application/yaml", want: "application/yaml", acceptYAML: true},
+		{name: "first of equal quality", accept: "text/html, application/yaml", want: "text/html"},
+		{name: "quality", accept: "text/html;q=0.8, text/yaml", want: "text/yaml", acceptYAML: true},
+		{name: "browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: "text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			assertEqual(t, tt.want, request.PreferredMediaType(req))
+			assertBool(t, tt.acceptYAML, request.AcceptsYAML(req))
+		})
+	}
+}
@@ -1,73 +1,182 @@
 package request
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
-func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
-	return decodeJSON(w, r, dst, false)
+// DefaultMaxBodySize is the maximum size of JSON request bodies decoded by DecodeJSON, unless set with
+// WithMaxBodySize.
+const DefaultMaxBodySize = 1 << 20
+
+// ErrInvalidTarget is returned by DecodeJSON if the destination is not a non-nil pointer.
+var ErrInvalidTarget = errors.New("request: destination must be a non-nil pointer")
+
+// JSONOption configures DecodeJSON.
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	maxBodySize           int64
+	disallowUnknownFields bool
 }
 
-func DecodeJSONStrict(w http.ResponseWriter, r *http.Request, dst interface{}) error {
-	return decodeJSON(w, r, dst, true)
+// WithMaxBodySize sets the maximum size of the body in bytes (default: DefaultMaxBodySize).
+func WithMaxBodySize(size int64) JSONOption {
+	return func(c *jsonConfig) {
+		c.maxBodySize = size
+	}
 }
 
-func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}, disallowUnknownFields bool) error {
-	maxBytes := 1_048_576
-	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))
+// WithDisallowUnknownFields rejects bodies with keys that don't match a field of the destination.
+func WithDisallowUnknownFields() JSONOption {
+	return func(c *jsonConfig) {
+		c.disallowUnknownFields = true
+	}
+}
 
-	dec := json.NewDecoder(r.Body)
+// JSONError is an error of a JSON request body decoded with DecodeJSON, with a message that can be shown to the
+// client.
+type JSONError struct {
+	Message string // message of the error, e.g. "must be a number"
+	Field   string // path of the field in the body, e.g. "address.zip", if the error is about a field
+	Line    int    // line of the error in the body, starting at 1, or 0 if unknown
+	Column  int    // column of the error in the line, starting at 1, or 0 if unknown
+}
 
-	if disallowUnknownFields {
-		dec.DisallowUnknownFields()
+// Error implements error.
+func (e *JSONError) Error() string {
+	msg := "body " + e.Message
+	if e.Field != "" {
+		msg = fmt.Sprintf("field %q %s", e.Field, e.Message)
+	}
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" (at line %d, column %d)", e.Line, e.Column)
+	}
+	return msg
+}
+
+// FieldErrors returns the message of the field by the path of the field, or an empty map if the error is not about a
+// field, e.g. for Response.Errors, so that the errors of JSON requests have the shape the JSON adapter renders:
+//
+//	var jsonErr *request.JSONError
+//	if errors.As(err, &jsonErr) {
+//		return resp.Errors(jsonErr.Error(), jsonErr.FieldErrors()), nil
+//	}
+func (e *JSONError) FieldErrors() map[string]string {
+	if e.Field == "" {
+		return map[string]string{}
+	}
+	return map[string]string{e.Field: e.Message}
+}
+
+// DecodeJSON decodes the JSON body of the request into dst. The body must hold a single JSON value of at most the
+// maximum body size. Invalid bodies return a *JSONError with a message for the client and, if known, the field and
+// the position of the error. It returns ErrInvalidTarget if dst is not a non-nil pointer.
+func DecodeJSON(r *http.Request, dst any, opts ...JSONOption) error {
+	cfg := jsonConfig{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&cfg)
 	}
 
-	err := dec.Decode(dst)
+	body, err := io.ReadAll(io.LimitReader(r.Body, cfg.maxBodySize+1))
 	if err != nil {
-		var syntaxError *json.SyntaxError
-		var unmarshalTypeError *json.UnmarshalTypeError
-		var invalidUnmarshalError *json.InvalidUnmarshalError
+		return err
+	}
+	if int64(len(body)) > cfg.maxBodySize {
+		return &JSONError{Message: fmt.Sprintf("must not be larger than %d bytes", cfg.maxBodySize)}
+	}
 
-		switch {
-		case errors.As(err, &syntaxError):
-			return fmt.Errorf("body contains badly-formed JSON (at character %d)", syntaxError.Offset)
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if cfg.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
 
-		case errors.Is(err, io.ErrUnexpectedEOF):
-			return errors.New("body contains badly-formed JSON")
+	if err := dec.Decode(dst); err != nil {
+		return jsonError(err, body)
+	}
 
-		case errors.As(err, &unmarshalTypeError):
-			if unmarshalTypeError.Field != "" {
-				return fmt.Errorf("body contains incorrect JSON type for field %q", unmarshalTypeError.Field)
-			}
-			return fmt.Errorf("body contains incorrect JSON type (at character %d)", unmarshalTypeError.Offset)
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return &JSONError{Message: "must only contain a single JSON value"}
+	}
 
-		case errors.Is(err, io.EOF):
-			return errors.New("body must not be empty")
+	return nil
+}
 
-		case strings.HasPrefix(err.Error(), "json: unknown field "):
-			fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
-			return fmt.Errorf("body contains unknown key %s", fieldName)
+// DecodeJSONStrict decodes the JSON body of the request into dst like DecodeJSON, rejecting unknown fields.
+func DecodeJSONStrict(r *http.Request, dst any, opts ...JSONOption) error {
+	return DecodeJSON(r, dst, append(opts, WithDisallowUnknownFields())...)
+}
 
-		case err.Error() == "http: request body too large":
-			return fmt.Errorf("body must not be larger than %d bytes", maxBytes)
+// jsonError converts an error of the JSON decoder into a *JSONError.
+func jsonError(err error, body []byte) error {
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	var invalidUnmarshalError *json.InvalidUnmarshalError
+
+	switch {
+	case errors.As(err, &syntaxError):
+		line, column := position(body, syntaxError.Offset-1)
+		return &JSONError{Message: "contains badly-formed JSON", Line: line, Column: column}
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &JSONError{Message: "contains badly-formed JSON"}
+
+	case errors.As(err, &unmarshalTypeError):
+		line, column := position(body, unmarshalTypeError.Offset-1)
+		return &JSONError{
+			Message: "must be " + jsonTypeName(unmarshalTypeError.Type.Kind().String()),
+			Field:   unmarshalTypeError.Field,
+			Line:    line,
+			Column:  column,
+		}
 
-		case errors.As(err, &invalidUnmarshalError):
-			panic(err)
+	case errors.Is(err, io.EOF):
+		return &JSONError{Message: "must not be empty"}
 
-		default:
-			return err
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		jsonErr := &JSONError{Message: "is not allowed", Field: field}
+		if i := bytes.Index(body, []byte(strconv.Quote(field))); i >= 0 {
+			jsonErr.Line, jsonErr.Column = position(body, int64(i))
 		}
+		return jsonErr
+
+	case errors.As(err, &invalidUnmarshalError):
+		return fmt.Errorf("%w: %w", ErrInvalidTarget, err)
+
+	default:
+		return err
 	}
+}
 
-	err = dec.Decode(&struct{}{})
-	if !errors.Is(err, io.EOF) {
-		return errors.New("body must only contain a single JSON value")
+// jsonTypeName returns the JSON type with an article of a Go kind, e.g. "a number" for int.
+func jsonTypeName(kind string) string {
+	switch {
+	case kind == "string":
+		return "a string"
+	case kind == "bool":
+		return "true or false"
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "a number"
+	case kind == "slice", kind == "array":
+		return "an array"
+	case kind == "map", kind == "struct":
+		return "an object"
 	}
+	return "a valid value"
+}
 
-	return nil
+// position returns the line and column of the byte at the offset in the body, starting at 1.
+func position(body []byte, offset int64) (line, column int) {
+	offset = min(max(offset, 0), int64(len(body)))
+	before := body[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
 }
@@ -0,0 +1,130 @@
+package request_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+type order struct {
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+	Address  struct {
+		Zip string `json:"zip"`
+	} `json:"address"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"product": "beans", "quantity": 2, "address": {"zip": "10115"}}`))
+
+	var got order
+	if err := request.DecodeJSON(r, &got); err != nil {
+		t.Fatalf("DecodeJSON() error = %v", err)
+	}
+	if got.Product != "beans" || got.Quantity != 2 || got.Address.Zip != "10115" {
+		t.Errorf("DecodeJSON() = %+v", got)
+	}
+}
+
+func TestDecodeJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		opts       []request.JSONOption
+		want       request.JSONError
+		wantFields map[string]string
+	}{
+		{
+			name: "syntax",
+			body: "{\n  \"product\": \"beans\",,\n}",
+			want: request.JSONError{Message: "contains badly-formed JSON", Line: 2, Column: 22},
+		},
+		{
+			name: "unexpected end",
+			body: `{"product": "beans"`,
+			want: request.JSONError{Message: "contains badly-formed JSON"},
+		},
+		{
+			name:       "type",
+			body:       "{\n  \"quantity\": \"two\"\n}",
+			want:       request.JSONError{Message: "must be a number", Field: "quantity", Line: 2, Column: 19},
+			wantFields: map[string]string{"quantity": "must be a number"},
+		},
+		{
+			name:       "nested type",
+			body:       `{"address": {"zip": 10115}}`,
+			want:       request.JSONError{Message: "must be a string", Field: "address.zip", Line: 1, Column: 25},
+			wantFields: map[string]string{"address.zip": "must be a string"},
+		},
+		{
+			name: "empty",
+			body: ``,
+			want: request.JSONError{Message: "must not be empty"},
+		},
+		{
+			name: "multiple values",
+			body: `{"product": "beans"} {"product": "cups"}`,
+			want: request.JSONError{Message: "must only contain a single JSON value"},
+		},
+		{
+			name:       "unknown field",
+			body:       `{"product": "beans", "price": 1}`,
+			opts:       []request.JSONOption{request.WithDisallowUnknownFields()},
+			want:       request.JSONError{Message: "is not allowed", Field: "price", Line: 1, Column: 22},
+			wantFields: map[string]string{"price": "is not allowed"},
+		},
+		{
+			name: "too large",
+			body: `{"product": "beans"}`,
+			opts: []request.JSONOption{request.WithMaxBodySize(10)},
+			want: request.JSONError{Message: "must not be larger than 10 bytes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/orders", strings.NewReader(tt.body))
+
+			var dst order
+			err := request.DecodeJSON(r, &dst, tt.opts...)
+
+			var jsonErr *request.JSONError
+			if !errors.As(err, &jsonErr) {
+				t.Fatalf("DecodeJSON() error = %v, want *JSONError", err)
+			}
+			if *jsonErr != tt.want {
+				t.Errorf("DecodeJSON() error = %+v, want %+v", *jsonErr, tt.want)
+			}
+
+			wantFields := tt.wantFields
+			if wantFields == nil {
+				wantFields = map[string]string{}
+			}
+			if got := jsonErr.FieldErrors(); !reflect.DeepEqual(got, wantFields) {
+				t.Errorf("FieldErrors() = %v, want %v", got, wantFields)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONStrict(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"price": 1}`))
+
+	err := request.DecodeJSONStrict(r, &order{})
+	if want := `field "price" is not allowed (at line 1, column 2)`; err == nil || err.Error() != want {
+		t.Errorf("DecodeJSONStrict() error = %v, want %q", err, want)
+	}
+}
+
+func TestDecodeJSON_InvalidTarget(t *testing.T) {
+	for _, dst := range []any{order{}, (*order)(nil), nil} {
+		r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"quantity": 1}`))
+		if err := request.DecodeJSON(r, dst); !errors.Is(err, request.ErrInvalidTarget) {
+			t.Errorf("DecodeJSON(%T) error = %v, want ErrInvalidTarget", dst, err)
+		}
+	}
+}
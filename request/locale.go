@@ -0,0 +1,103 @@
+package request
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Locales returns the language tags of the Accept-Language header of the request, e.g. [de-CH de en] for
+// "de-CH, de;q=0.9, en;q=0.8", sorted by their quality value. Tags with the same quality keep their order, and tags
+// with a quality of 0 are omitted.
+func Locales(r *http.Request) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				q = v
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	slices.SortStableFunc(tags, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	locales := make([]string, len(tags))
+	for i, tag := range tags {
+		locales[i] = tag.tag
+	}
+	return locales
+}
+
+// PreferredLocale returns the supported locale that best matches the Accept-Language header of the request, or an
+// empty string if none matches. For each accepted tag, in order of preference, a supported locale matches if it is
+// the same tag (ignoring case), the language of the tag (de for de-CH), or a locale of the same language (de-DE for
+// de). The wildcard tag "*" matches the first supported locale.
+//
+// It can be used as a locale resolver:
+//
+//	hyperview.WithLocaleResolver(func(r *http.Request) string {
+//		return request.PreferredLocale(r, "en", "de", "fr")
+//	})
+func PreferredLocale(r *http.Request, supported ...string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	for _, tag := range Locales(r) {
+		if tag == "*" {
+			return supported[0]
+		}
+
+		for _, locale := range supported {
+			if strings.EqualFold(locale, tag) {
+				return locale
+			}
+		}
+
+		language := localeLanguage(tag)
+		for _, locale := range supported {
+			if strings.EqualFold(locale, language) {
+				return locale
+			}
+		}
+		for _, locale := range supported {
+			if strings.EqualFold(localeLanguage(locale), language) {
+				return locale
+			}
+		}
+	}
+
+	return ""
+}
+
+// localeLanguage returns the language of a language tag, e.g. de for de-CH.
+func localeLanguage(tag string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	return language
+}
@@ -0,0 +1,65 @@
+package request_test
+
+import (
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{"none", "", []string{}},
+		{"single", "de", []string{"de"}},
+		{"sorted by quality", "en;q=0.5, de-CH, de;q=0.9", []string{"de-CH", "de", "en"}},
+		{"same quality keeps order", "fr;q=0.8, it;q=0.8, en", []string{"en", "fr", "it"}},
+		{"zero quality omitted", "de, en;q=0", []string{"de"}},
+		{"wildcard", "de, *;q=0.1", []string{"de", "*"}},
+		{"malformed quality", "de;q=x, en;q=0.5", []string{"de", "en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept-Language", tt.header)
+
+			if got := request.Locales(r); !slices.Equal(got, tt.want) {
+				t.Errorf("Locales() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreferredLocale(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		supported []string
+		want      string
+	}{
+		{"exact", "de", []string{"en", "de"}, "de"},
+		{"ignores case", "de-ch", []string{"en", "de-CH"}, "de-CH"},
+		{"language of tag", "de-AT", []string{"en", "de"}, "de"},
+		{"locale of same language", "de", []string{"en", "de-DE"}, "de-DE"},
+		{"preference order", "fr, de;q=0.9", []string{"de", "fr"}, "fr"},
+		{"skips unsupported", "es, de;q=0.5", []string{"en", "de"}, "de"},
+		{"wildcard", "es, *;q=0.1", []string{"en", "de"}, "en"},
+		{"no match", "es", []string{"en", "de"}, ""},
+		{"no header", "", []string{"en", "de"}, ""},
+		{"no supported locales", "de", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept-Language", tt.header)
+
+			assertEqual(t, tt.want, request.PreferredLocale(r, tt.supported...))
+		})
+	}
+}
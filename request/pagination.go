@@ -0,0 +1,32 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Defaults of ParsePagination.
+const (
+	DefaultPerPage = 20  // items per page if the request has no per_page parameter
+	MaxPerPage     = 100 // maximum items per page a request can ask for
+)
+
+// ParsePagination returns the page and the number of items per page from the page and per_page query parameters of
+// the request, e.g. /users?page=3&per_page=50. The page defaults to 1 and the number of items per page to
+// DefaultPerPage, and is capped at MaxPerPage. Invalid values use the defaults.
+func ParsePagination(r *http.Request) (page, perPage int) {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err = strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	perPage = min(perPage, MaxPerPage)
+
+	return page, perPage
+}
@@ -0,0 +1,33 @@
+package request_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		wantPage    int
+		wantPerPage int
+	}{
+		{"defaults", "/users", 1, request.DefaultPerPage},
+		{"page and per page", "/users?page=3&per_page=50", 3, 50},
+		{"capped per page", "/users?per_page=1000", 1, request.MaxPerPage},
+		{"invalid page", "/users?page=abc", 1, request.DefaultPerPage},
+		{"negative values", "/users?page=-2&per_page=-5", 1, request.DefaultPerPage},
+		{"zero page", "/users?page=0&per_page=10", 1, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, perPage := request.ParsePagination(httptest.NewRequest("GET", tt.target, nil))
+			if page != tt.wantPage || perPage != tt.wantPerPage {
+				t.Errorf("ParsePagination() = %d, %d, want %d, %d", page, perPage, tt.wantPage, tt.wantPerPage)
+			}
+		})
+	}
+}
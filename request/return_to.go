@@ -0,0 +1,65 @@
+package request
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ReturnToParam is the query or form parameter with the URL to return to, e.g. /login?return_to=/settings.
+const ReturnToParam = "return_to"
+
+// ReturnTo returns the URL to return to, e.g. after login: the return_to query or form parameter if it is a
+// same-origin URL, or else the Referer header if it is a same-origin URL, or else the fallback. URLs pointing to the
+// path of the request itself (e.g. the login page) are skipped.
+//
+// The returned URL is always a path with the query and fragment, without a scheme and host, so that redirecting to it
+// cannot send the client to another site (an open redirect).
+func ReturnTo(r *http.Request, fallback string) string {
+	for _, candidate := range []string{r.FormValue(ReturnToParam), r.Referer()} {
+		if u, ok := sameOriginURL(r, candidate); ok && u.Path != r.URL.Path {
+			return u.String()
+		}
+	}
+	return fallback
+}
+
+// sameOriginURL returns the path, query, and fragment of the URL if it is an absolute path (e.g. "/settings") or an
+// absolute URL with the scheme, host, and port of the request.
+func sameOriginURL(r *http.Request, raw string) (*url.URL, bool) {
+	// Browsers treat backslashes as slashes, so "/\evil.example" is a protocol-relative URL to them
+	if raw == "" || strings.ContainsAny(raw, "\\\x00\t\r\n") {
+		return nil, false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Opaque != "" || u.User != nil {
+		return nil, false
+	}
+
+	if u.Scheme != "" || u.Host != "" {
+		scheme, host, port := SchemeHostPort(r)
+		if !strings.EqualFold(u.Scheme, scheme) || !strings.EqualFold(u.Hostname(), host) {
+			return nil, false
+		}
+		uPort := u.Port()
+		if uPort == "" {
+			uPort = map[string]string{"http": "80", "https": "443"}[strings.ToLower(u.Scheme)]
+		}
+		if uPort != port {
+			return nil, false
+		}
+	} else if !strings.HasPrefix(u.Path, "/") {
+		return nil, false
+	}
+
+	if strings.HasPrefix(u.Path, "//") {
+		return nil, false
+	}
+
+	path := &url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery, Fragment: u.Fragment, RawFragment: u.RawFragment}
+	if path.Path == "" {
+		path.Path = "/"
+	}
+	return path, true
+}
@@ -0,0 +1,58 @@
+package request_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+func TestReturnTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		form    string
+		referer string
+		forward string
+		want    string
+	}{
+		{name: "no return URL", target: "/login", want: "/home"},
+		{name: "query path", target: "/login?return_to=%2Fsettings%3Ftab%3D2", want: "/settings?tab=2"},
+		{name: "form path", target: "/login", form: "/settings", want: "/settings"},
+		{name: "referer", target: "/login", referer: "http://example.com/cart#items", want: "/cart#items"},
+		{name: "query before referer", target: "/login?return_to=/settings", referer: "http://example.com/cart", want: "/settings"},
+		{name: "invalid query falls back to referer", target: "/login?return_to=https://evil.example/", referer: "http://example.com/cart", want: "/cart"},
+		{name: "other host", target: "/login?return_to=http://evil.example/settings", want: "/home"},
+		{name: "other scheme", target: "/login?return_to=https://example.com/settings", want: "/home"},
+		{name: "other port", target: "/login?return_to=http://example.com:8080/settings", want: "/home"},
+		{name: "default port", target: "/login?return_to=http://example.com:80/settings", want: "/settings"},
+		{name: "forwarded origin", target: "/login?return_to=https://example.com/settings", forward: "https", want: "/settings"},
+		{name: "protocol-relative", target: "/login?return_to=//evil.example/", want: "/home"},
+		{name: "backslash", target: "/login?return_to=/%5Cevil.example/", want: "/home"},
+		{name: "encoded slashes", target: "/login?return_to=/%252F%252Fevil.example", want: "/home"},
+		{name: "relative path", target: "/login?return_to=settings", want: "/home"},
+		{name: "javascript", target: "/login?return_to=javascript:alert(1)", want: "/home"},
+		{name: "userinfo", target: "/login?return_to=http://evil.example@example.com/", want: "/home"},
+		{name: "same path", target: "/login?return_to=/login%3Fnext%3D1", referer: "http://example.com/login", want: "/home"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://example.com"+tt.target, nil)
+			if tt.form != "" {
+				r = httptest.NewRequest("POST", "http://example.com"+tt.target, strings.NewReader(url.Values{request.ReturnToParam: {tt.form}}.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tt.referer != "" {
+				r.Header.Set("Referer", tt.referer)
+			}
+			if tt.forward != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.forward)
+			}
+
+			assertEqual(t, tt.want, request.ReturnTo(r, "/home"))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// locations caches the time zones loaded by name, since loading a time zone reads the time zone database.
+var locations sync.Map
+
+// LoadLocation returns the time zone with the IANA name, e.g. "Europe/Berlin", or nil if the name is unknown. Unlike
+// time.LoadLocation, it returns nil for an empty name and "Local", which would be the time zone of the server rather
+// than of the viewer, and caches the loaded time zones, so that it can be called on every request with names sent by
+// clients.
+func LoadLocation(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" || len(name) > 64 {
+		return nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// LocationFromCookie returns the time zone named by the cookie, e.g. set by a script with
+// Intl.DateTimeFormat().resolvedOptions().timeZone, or nil if there is no cookie or the time zone is unknown.
+func LocationFromCookie(r *http.Request, name string) *time.Location {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return nil
+	}
+	return LoadLocation(cookie.Value)
+}
+
+// LocationFromHeader returns the time zone named by the header, e.g. a header set by the client on fetch and htmx
+// requests, or nil if there is no header or the time zone is unknown.
+func LocationFromHeader(r *http.Request, name string) *time.Location {
+	return LoadLocation(r.Header.Get(name))
+}
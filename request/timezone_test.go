@@ -0,0 +1,60 @@
+package request_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Europe/Berlin", "Europe/Berlin"},
+		{" America/New_York ", "America/New_York"},
+		{"UTC", "UTC"},
+		{"", ""},
+		{"Local", ""},
+		{"Mars/Olympus_Mons", ""},
+		{"../../etc/passwd", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := request.LoadLocation(tt.name)
+			got := ""
+			if loc != nil {
+				got = loc.String()
+			}
+			if got != tt.want {
+				t.Errorf("LoadLocation(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if request.LoadLocation("Europe/Berlin") != request.LoadLocation("Europe/Berlin") {
+		t.Error("LoadLocation() returned different locations for the same name, want the cached location")
+	}
+}
+
+func TestLocationFromCookieAndHeader(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "tz", Value: "Asia/Tokyo"})
+	r.Header.Set("X-Timezone", "Europe/Paris")
+
+	if loc := request.LocationFromCookie(r, "tz"); loc == nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("LocationFromCookie() = %v, want Asia/Tokyo", loc)
+	}
+	if loc := request.LocationFromCookie(r, "missing"); loc != nil {
+		t.Errorf("LocationFromCookie() = %v, want nil for a missing cookie", loc)
+	}
+	if loc := request.LocationFromHeader(r, "X-Timezone"); loc == nil || loc.String() != "Europe/Paris" {
+		t.Errorf("LocationFromHeader() = %v, want Europe/Paris", loc)
+	}
+	if loc := request.LocationFromHeader(r, "X-Missing"); loc != nil {
+		t.Errorf("LocationFromHeader() = %v, want nil for a missing header", loc)
+	}
+}
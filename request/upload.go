@@ -0,0 +1,158 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hypergopher/hyperview/bind"
+)
+
+// UploadOption configures UploadedFiles.
+type UploadOption func(*uploadConfig)
+
+type uploadConfig struct {
+	maxSize      int64
+	maxFiles     int
+	allowedTypes []string
+}
+
+// WithMaxFileSize sets the maximum size of each file in bytes.
+func WithMaxFileSize(size int64) UploadOption {
+	return func(c *uploadConfig) {
+		c.maxSize = size
+	}
+}
+
+// WithMaxFiles sets the maximum number of files of the field.
+func WithMaxFiles(n int) UploadOption {
+	return func(c *uploadConfig) {
+		c.maxFiles = n
+	}
+}
+
+// WithAllowedTypes sets the allowed media types of the files, e.g. "application/pdf", or "image/*" for all images.
+// The type of a file is detected from its content (see http.DetectContentType), not taken from the client.
+func WithAllowedTypes(types ...string) UploadOption {
+	return func(c *uploadConfig) {
+		c.allowedTypes = append(c.allowedTypes, types...)
+	}
+}
+
+// UploadedFiles returns the files uploaded with the field of the multipart form of the request, parsing the form if
+// needed. Files that are too large, too many files, and files of types that are not allowed are reported as
+// bind.FieldErrors with a message for the field, which can be passed to Response.Errors:
+//
+//	files, err := request.UploadedFiles(r, "photos",
+//		request.WithMaxFileSize(5<<20), request.WithAllowedTypes("image/png", "image/jpeg"))
+//
+// A field without files returns no files and no error, so that optional fields need no check.
+func UploadedFiles(r *http.Request, field string, opts ...UploadOption) ([]*multipart.FileHeader, error) {
+	var cfg uploadConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if r.MultipartForm == nil {
+		if err := r.ParseMultipartForm(bind.MaxMemory); err != nil {
+			if errors.Is(err, http.ErrNotMultipart) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("error parsing multipart form: %w", err)
+		}
+	}
+
+	files := r.MultipartForm.File[field]
+	if cfg.maxFiles > 0 && len(files) > cfg.maxFiles {
+		return nil, bind.FieldErrors{field: fmt.Sprintf("must not have more than %d files", cfg.maxFiles)}
+	}
+
+	for _, file := range files {
+		if cfg.maxSize > 0 && file.Size > cfg.maxSize {
+			return nil, bind.FieldErrors{field: fmt.Sprintf("%s must not be larger than %s", file.Filename, formatSize(cfg.maxSize))}
+		}
+
+		if len(cfg.allowedTypes) > 0 {
+			mediaType, err := detectContentType(file)
+			if err != nil {
+				return nil, err
+			}
+			if !allowedType(mediaType, cfg.allowedTypes) {
+				return nil, bind.FieldErrors{field: fmt.Sprintf("%s must be of type %s", file.Filename, strings.Join(cfg.allowedTypes, ", "))}
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// SaveUploadedFile saves the uploaded file to the path, replacing an existing file. The directory of the path must
+// exist. The path should not be built from the file name of the client, which can contain path separators.
+func SaveUploadedFile(file *multipart.FileHeader, path string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// detectContentType returns the media type of the file detected from the first 512 bytes of its content.
+func detectContentType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	return mediaType, nil
+}
+
+// allowedType returns true if the media type matches one of the allowed types, which can end with "/*".
+func allowedType(mediaType string, allowed []string) bool {
+	for _, t := range allowed {
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if strings.EqualFold(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSize formats a size in bytes with a binary unit, e.g. 5 MB for 5<<20.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<30 && size%(1<<30) == 0:
+		return fmt.Sprintf("%d GB", size>>30)
+	case size >= 1<<20 && size%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", size>>20)
+	case size >= 1<<10 && size%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", size>>10)
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
@@ -0,0 +1,125 @@
+package request_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/bind"
+	"github.com/hypergopher/hyperview/request"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newUploadRequest(t *testing.T, files map[string][][]byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for field, contents := range files {
+		for i, content := range contents {
+			fw, err := mw.CreateFormFile(field, field+string(rune('a'+i))+".bin")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = fw.Write(content)
+		}
+	}
+	_ = mw.Close()
+
+	r := httptest.NewRequest("POST", "/upload", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestUploadedFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string][][]byte
+		opts       []request.UploadOption
+		wantFiles  int
+		wantErrors bind.FieldErrors
+	}{
+		{
+			name:      "allowed",
+			files:     map[string][][]byte{"photos": {pngData, pngData}},
+			opts:      []request.UploadOption{request.WithAllowedTypes("image/*"), request.WithMaxFileSize(1 << 10), request.WithMaxFiles(2)},
+			wantFiles: 2,
+		},
+		{
+			name:      "no files",
+			files:     map[string][][]byte{"other": {pngData}},
+			opts:      []request.UploadOption{request.WithAllowedTypes("image/png")},
+			wantFiles: 0,
+		},
+		{
+			name:       "type not allowed",
+			files:      map[string][][]byte{"photos": {[]byte("just some text")}},
+			opts:       []request.UploadOption{request.WithAllowedTypes("image/png", "image/jpeg")},
+			wantErrors: bind.FieldErrors{"photos": "photosa.bin must be of type image/png, image/jpeg"},
+		},
+		{
+			name:       "too large",
+			files:      map[string][][]byte{"photos": {bytes.Repeat([]byte("x"), 2048)}},
+			opts:       []request.UploadOption{request.WithMaxFileSize(1 << 10)},
+			wantErrors: bind.FieldErrors{"photos": "photosa.bin must not be larger than 1 KB"},
+		},
+		{
+			name:       "too many files",
+			files:      map[string][][]byte{"photos": {pngData, pngData, pngData}},
+			opts:       []request.UploadOption{request.WithMaxFiles(2)},
+			wantErrors: bind.FieldErrors{"photos": "must not have more than 2 files"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := request.UploadedFiles(newUploadRequest(t, tt.files), "photos", tt.opts...)
+
+			var fieldErrs bind.FieldErrors
+			errors.As(err, &fieldErrs)
+			if tt.wantErrors == nil && err != nil {
+				t.Fatalf("UploadedFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(fieldErrs, tt.wantErrors) {
+				t.Errorf("UploadedFiles() error = %v, want %v", fieldErrs, tt.wantErrors)
+			}
+			if len(files) != tt.wantFiles {
+				t.Errorf("UploadedFiles() = %d files, want %d", len(files), tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestUploadedFiles_NotMultipart(t *testing.T) {
+	r := httptest.NewRequest("POST", "/upload", strings.NewReader("name=ada"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	files, err := request.UploadedFiles(r, "photos")
+	if err != nil || files != nil {
+		t.Errorf("UploadedFiles() = %v, %v, want no files and no error", files, err)
+	}
+}
+
+func TestSaveUploadedFile(t *testing.T) {
+	files, err := request.UploadedFiles(newUploadRequest(t, map[string][][]byte{"photos": {pngData}}), "photos")
+	if err != nil {
+		t.Fatalf("UploadedFiles() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "photo.png")
+	if err := request.SaveUploadedFile(files[0], path); err != nil {
+		t.Fatalf("SaveUploadedFile() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(got, pngData) {
+		t.Errorf("saved file = %q, %v, want the uploaded content", got, err)
+	}
+}
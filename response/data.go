@@ -3,12 +3,17 @@ package response
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hypergopher/hyperview/constants"
 	"github.com/hypergopher/hyperview/htmx"
 	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/unpoly"
 )
 
 // Data is the struct that all view models must implement. It provides common data for all templates
@@ -27,6 +32,7 @@ import (
 //goland:noinspection GoNameStartsWithPackageName
 type Data struct {
 	title       string
+	meta        *Meta
 	request     *http.Request
 	pageData    map[string]any
 	csrfToken   string
@@ -43,11 +49,25 @@ func NewData(pageData map[string]any) *Data {
 	}
 }
 
+// Clone returns a copy of the view data model. The data map is copied, but the values in it are shared.
+func (v *Data) Clone() *Data {
+	c := *v
+	c.pageData = maps.Clone(v.pageData)
+	c.meta = v.meta.Clone()
+	delete(c.pageData, "View")
+	return &c
+}
+
 // SetTitle sets the title of the page.
 func (v *Data) SetTitle(title string) {
 	v.title = title
 }
 
+// SetMeta sets the meta tags of the page.
+func (v *Data) SetMeta(meta *Meta) {
+	v.meta = meta
+}
+
 // SetRequest sets the request for the Data instance.
 func (v *Data) SetRequest(r *http.Request) {
 	v.request = r
@@ -116,11 +136,201 @@ func (v *Data) GetString(key string) string {
 	return ""
 }
 
+// Lookup returns the value at the dot-separated path in the view data model, e.g. "User.Profile.Email", and whether it
+// exists. Each segment is a key of a map with string keys, an exported field of a struct, or an index of a slice or
+// array, following pointers and interfaces. Templates use LookupValue, as they can't call methods with a bool result.
+func (v *Data) Lookup(path string) (any, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	segments := strings.Split(path, ".")
+	current, ok := v.pageData[segments[0]]
+	if !ok {
+		return nil, false
+	}
+
+	for _, segment := range segments[1:] {
+		val := reflect.ValueOf(current)
+		for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil, false
+			}
+			val = val.Elem()
+		}
+
+		switch val.Kind() {
+		case reflect.Map:
+			if val.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			elem := val.MapIndex(reflect.ValueOf(segment).Convert(val.Type().Key()))
+			if !elem.IsValid() {
+				return nil, false
+			}
+			current = elem.Interface()
+		case reflect.Struct:
+			field, ok := val.Type().FieldByName(segment)
+			if !ok || !field.IsExported() {
+				return nil, false
+			}
+			elem, err := val.FieldByIndexErr(field.Index)
+			if err != nil || !elem.CanInterface() {
+				return nil, false
+			}
+			current = elem.Interface()
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= val.Len() {
+				return nil, false
+			}
+			current = val.Index(i).Interface()
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// LookupValue returns the value at the dot-separated path in the view data model (see Lookup), or nil if it doesn't
+// exist:
+//
+//	{{with .View.LookupValue "User.Profile.Email"}}<a href="mailto:{{.}}">{{.}}</a>{{end}}
+func (v *Data) LookupValue(path string) any {
+	val, _ := v.Lookup(path)
+	return val
+}
+
+// GetInt returns the value of the specified key from the view data model as an int. Integers, floats, and numeric
+// strings are converted, other values return 0.
+func (v *Data) GetInt(key string) int {
+	return int(v.GetInt64(key))
+}
+
+// GetInt64 returns the value of the specified key from the view data model as an int64. Integers, floats, and numeric
+// strings are converted, other values return 0.
+func (v *Data) GetInt64(key string) int64 {
+	switch val := reflect.ValueOf(v.Get(key)); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return int64(val.Float())
+	case reflect.String:
+		i, _ := strconv.ParseInt(strings.TrimSpace(val.String()), 10, 64)
+		return i
+	}
+
+	return 0
+}
+
+// GetFloat returns the value of the specified key from the view data model as a float64. Integers, floats, and
+// numeric strings are converted, other values return 0.
+func (v *Data) GetFloat(key string) float64 {
+	switch val := reflect.ValueOf(v.Get(key)); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+	case reflect.String:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(val.String()), 64)
+		return f
+	}
+
+	return 0
+}
+
+// GetBool returns the value of the specified key from the view data model as a bool. Strings such as "true" and "1"
+// are converted (see strconv.ParseBool), other values return false.
+func (v *Data) GetBool(key string) bool {
+	switch val := v.Get(key).(type) {
+	case bool:
+		return val
+	case string:
+		b, _ := strconv.ParseBool(strings.TrimSpace(val))
+		return b
+	}
+
+	return false
+}
+
+// GetTime returns the value of the specified key from the view data model as a time.Time. Pointers to times and
+// RFC 3339 strings are converted, other values return the zero time.
+func (v *Data) GetTime(key string) time.Time {
+	switch val := v.Get(key).(type) {
+	case time.Time:
+		return val
+	case *time.Time:
+		if val != nil {
+			return *val
+		}
+	case string:
+		t, _ := time.Parse(time.RFC3339, val)
+		return t
+	}
+
+	return time.Time{}
+}
+
+// GetStringSlice returns the value of the specified key from the view data model as a slice of strings. Slices of
+// other values are converted if all of their elements are strings, other values return nil.
+func (v *Data) GetStringSlice(key string) []string {
+	switch val := v.Get(key).(type) {
+	case []string:
+		return val
+	case []any:
+		strs := make([]string, len(val))
+		for i, item := range val {
+			str, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			strs[i] = str
+		}
+		return strs
+	}
+
+	return nil
+}
+
+// GetMap returns the value of the specified key from the view data model as a map. Maps with string keys and other
+// value types are copied into a new map, other values return nil.
+func (v *Data) GetMap(key string) map[string]any {
+	if m, ok := v.Get(key).(map[string]any); ok {
+		return m
+	}
+
+	val := reflect.ValueOf(v.Get(key))
+	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	m := make(map[string]any, val.Len())
+	iter := val.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m
+}
+
 // Title returns the title of the page.
 func (v *Data) Title() string {
 	return v.title
 }
 
+// Meta returns the meta tags of the page, set with Response.Meta. It is never nil, so layouts can render it without
+// checking, e.g. with {{.View.Meta.HTML}}.
+func (v *Data) Meta() *Meta {
+	if v.meta == nil {
+		return NewMeta()
+	}
+	return v.meta
+}
+
 // ------ Error Helpers --------
 
 // HasError returns true if the view data model contains an error message.
@@ -148,6 +358,22 @@ func (v *Data) Errors() map[string]string {
 	return map[string]string{}
 }
 
+// OldInput returns the submitted values of a failed form submission by field name, e.g. re-hydrated after a redirect
+// (see hyperview.RedirectWithErrors) or set with Response.OldInput.
+func (v *Data) OldInput() map[string]string {
+	val, ok := v.Get("OldInput").(map[string]string)
+	if ok {
+		return val
+	}
+
+	return map[string]string{}
+}
+
+// Old returns the submitted value of the form field, or an empty string if there is none.
+func (v *Data) Old(field string) string {
+	return v.OldInput()[field]
+}
+
 // ------ Common Helpers --------
 
 // BaseURL returns the base URL of the request.
@@ -160,6 +386,17 @@ func (v *Data) Context() context.Context {
 	return v.request.Context()
 }
 
+// CanonicalURL returns the canonical URL of the page: the path of the request on the canonical scheme and host of the
+// view service (see hyperview.WithCanonicalURL), or on the base URL of the request if none is set. The query is
+// omitted, so that filtered and sorted variants of a page share its canonical URL.
+func (v *Data) CanonicalURL() string {
+	base, ok := v.request.Context().Value(constants.CanonicalURLContextKey).(string)
+	if !ok {
+		base = request.BaseURL(v.request)
+	}
+	return base + v.request.URL.EscapedPath()
+}
+
 // CurrentYear returns the current year.
 func (v *Data) CurrentYear() int {
 	return time.Now().Year()
@@ -175,6 +412,33 @@ func (v *Data) Nonce() string {
 	return ""
 }
 
+// Session returns the value of the key in the session of the request, read with the session provider of the view
+// service (see hyperview.WithSessionProvider), or nil if there is no session provider or value.
+func (v *Data) Session(key string) any {
+	reader, ok := v.request.Context().Value(constants.SessionContextKey).(SessionReader)
+	if !ok {
+		return nil
+	}
+
+	return reader.SessionValue(v.request, key)
+}
+
+// Locale returns the locale of the request, e.g. "de" or "de-CH", resolved with the locale resolver of the view
+// service (see hyperview.WithLocaleResolver), or an empty string if there is none.
+func (v *Data) Locale() string {
+	locale, _ := v.request.Context().Value(constants.LocaleContextKey).(string)
+	return locale
+}
+
+// Location returns the time zone of the viewer of the request, resolved with the time zone resolver of the view
+// service (see hyperview.WithTimezoneResolver), or UTC if there is none.
+func (v *Data) Location() *time.Location {
+	if loc, ok := v.request.Context().Value(constants.LocationContextKey).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
 // HTMXNonce returns the HTMX nonce value from the request context, if available.
 // This adds the inlineScriptNonce key to a JSON object with the nonce value and can be used in an HTMX meta tag.
 func (v *Data) HTMXNonce() string {
@@ -186,6 +450,12 @@ func (v *Data) RequestPath() string {
 	return request.URLPath(v.request)
 }
 
+// RequestURL returns the path and query of the request, e.g. "/posts?sort=name&page=2", as the base URL of links that
+// change some of its query parameters (see the urlWithQuery function).
+func (v *Data) RequestURL() string {
+	return v.request.URL.RequestURI()
+}
+
 // RequestMethod returns the method of the request.
 func (v *Data) RequestMethod() string {
 	return request.Method(v.request)
@@ -200,3 +470,8 @@ func (v *Data) IsHtmxRequest() bool {
 func (v *Data) IsBoostedRequest() bool {
 	return htmx.IsBoostedRequest(v.request)
 }
+
+// IsUnpolyRequest returns true if the request was sent by Unpoly.
+func (v *Data) IsUnpolyRequest() bool {
+	return unpoly.IsUnpolyRequest(v.request)
+}
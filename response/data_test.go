@@ -0,0 +1,162 @@
+package response_test
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestData_TypedGetters(t *testing.T) {
+	created := time.Date(2025, 12, 31, 10, 30, 0, 0, time.UTC)
+	data := response.NewData(map[string]any{
+		"int":      42,
+		"uint8":    uint8(7),
+		"float":    2.5,
+		"numeric":  " 12 ",
+		"decimal":  "1.25",
+		"bool":     true,
+		"boolStr":  "1",
+		"time":     created,
+		"timePtr":  &created,
+		"timeStr":  "2025-12-31T10:30:00Z",
+		"strings":  []string{"a", "b"},
+		"anys":     []any{"c", "d"},
+		"mixed":    []any{"e", 1},
+		"map":      map[string]any{"a": 1},
+		"strMap":   map[string]string{"b": "2"},
+		"text":     "hello",
+		"nilValue": nil,
+	})
+
+	t.Run("int", func(t *testing.T) {
+		tests := map[string]int{"int": 42, "uint8": 7, "float": 2, "numeric": 12, "decimal": 0, "text": 0, "bool": 0, "missing": 0, "nilValue": 0}
+		for key, want := range tests {
+			if got := data.GetInt(key); got != want {
+				t.Errorf("GetInt(%q) = %d, want %d", key, got, want)
+			}
+			if got := data.GetInt64(key); got != int64(want) {
+				t.Errorf("GetInt64(%q) = %d, want %d", key, got, want)
+			}
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		tests := map[string]float64{"int": 42, "float": 2.5, "numeric": 12, "decimal": 1.25, "text": 0, "missing": 0}
+		for key, want := range tests {
+			if got := data.GetFloat(key); got != want {
+				t.Errorf("GetFloat(%q) = %v, want %v", key, got, want)
+			}
+		}
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		tests := map[string]bool{"bool": true, "boolStr": true, "int": false, "text": false, "missing": false}
+		for key, want := range tests {
+			if got := data.GetBool(key); got != want {
+				t.Errorf("GetBool(%q) = %v, want %v", key, got, want)
+			}
+		}
+	})
+
+	t.Run("time", func(t *testing.T) {
+		tests := map[string]time.Time{"time": created, "timePtr": created, "timeStr": created, "text": {}, "missing": {}}
+		for key, want := range tests {
+			if got := data.GetTime(key); !got.Equal(want) {
+				t.Errorf("GetTime(%q) = %v, want %v", key, got, want)
+			}
+		}
+	})
+
+	t.Run("string slice", func(t *testing.T) {
+		tests := map[string][]string{"strings": {"a", "b"}, "anys": {"c", "d"}, "mixed": nil, "text": nil, "missing": nil}
+		for key, want := range tests {
+			if got := data.GetStringSlice(key); !reflect.DeepEqual(got, want) {
+				t.Errorf("GetStringSlice(%q) = %v, want %v", key, got, want)
+			}
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		tests := map[string]map[string]any{"map": {"a": 1}, "strMap": {"b": "2"}, "text": nil, "missing": nil}
+		for key, want := range tests {
+			if got := data.GetMap(key); !reflect.DeepEqual(got, want) {
+				t.Errorf("GetMap(%q) = %v, want %v", key, got, want)
+			}
+		}
+	})
+}
+
+func TestData_Lookup(t *testing.T) {
+	type profile struct {
+		Email string
+		phone string
+	}
+	type user struct {
+		Name    string
+		Profile *profile
+		Tags    []string
+		Meta    map[string]any
+	}
+
+	data := response.NewData(map[string]any{
+		"User": &user{
+			Name:    "Ada",
+			Profile: &profile{Email: "ada@example.com", phone: "555"},
+			Tags:    []string{"admin", "editor"},
+			Meta:    map[string]any{"plan": map[string]string{"name": "pro"}},
+		},
+		"Guest":    &user{},
+		"Settings": map[string]any{"theme": "dark", "nil": nil},
+		"Count":    3,
+	})
+
+	tests := []struct {
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{"Count", 3, true},
+		{"User.Name", "Ada", true},
+		{"User.Profile.Email", "ada@example.com", true},
+		{"User.Tags.1", "editor", true},
+		{"User.Meta.plan.name", "pro", true},
+		{"Settings.theme", "dark", true},
+		{"Settings.nil", nil, true},
+		{"User.Profile.phone", nil, false},
+		{"User.Profile.Missing", nil, false},
+		{"User.Tags.2", nil, false},
+		{"User.Tags.first", nil, false},
+		{"Guest.Profile.Email", nil, false},
+		{"Settings.nil.value", nil, false},
+		{"Count.value", nil, false},
+		{"Missing", nil, false},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := data.Lookup(tt.path)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+			if got := data.LookupValue(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LookupValue(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestData_RequestURL(t *testing.T) {
+	data := response.NewData(nil)
+	data.SetRequest(httptest.NewRequest("GET", "https://example.com/posts?sort=name&page=2", nil))
+
+	if got, want := data.RequestURL(), "/posts?sort=name&page=2"; got != want {
+		t.Errorf("RequestURL() = %q, want %q", got, want)
+	}
+	if got, want := data.RequestPath(), "/posts"; got != want {
+		t.Errorf("RequestPath() = %q, want %q", got, want)
+	}
+}
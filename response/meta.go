@@ -0,0 +1,152 @@
+package response
+
+import (
+	"html"
+	"html/template"
+	"slices"
+	"strings"
+)
+
+// Twitter card types of Meta.TwitterCard.
+const (
+	TwitterCardSummary           = "summary"
+	TwitterCardSummaryLargeImage = "summary_large_image"
+)
+
+// MetaTag is a meta tag of the head of a page.
+type MetaTag struct {
+	Name     string // name attribute, e.g. description or twitter:card
+	Property string // property attribute of OpenGraph tags, e.g. og:title
+	Content  string // content attribute
+}
+
+// Meta builds the meta tags of the head of a page, for search engines and link previews. It is set on the response
+// with Response.Meta and read by layouts from the view data with .View.Meta:
+//
+//	resp.Meta(response.NewMeta().
+//		Description("Fresh coffee beans, roasted weekly.").
+//		Canonical("https://example.com/shop").
+//		OpenGraph("type", "website").
+//		OpenGraph("image", "https://example.com/shop.jpg").
+//		TwitterCard(response.TwitterCardSummaryLargeImage))
+//
+// Layouts render all tags with {{.View.Meta.HTML}}, or single tags with {{.View.Meta.Content "description"}}.
+type Meta struct {
+	canonical string
+	tags      []MetaTag
+}
+
+// NewMeta creates a new Meta without tags.
+func NewMeta() *Meta {
+	return &Meta{}
+}
+
+// Clone returns a copy of the meta tags, e.g. of the meta tags of a prototype response.
+func (m *Meta) Clone() *Meta {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.tags = slices.Clone(m.tags)
+	return &c
+}
+
+// Description sets the description of the page.
+func (m *Meta) Description(description string) *Meta {
+	return m.Tag("description", description)
+}
+
+// Canonical sets the canonical URL of the page, rendered as a link tag.
+func (m *Meta) Canonical(url string) *Meta {
+	m.canonical = url
+	return m
+}
+
+// Robots sets the directives of search engine crawlers, e.g. "noindex" and "nofollow".
+func (m *Meta) Robots(directives ...string) *Meta {
+	return m.Tag("robots", strings.Join(directives, ", "))
+}
+
+// NoIndex tells search engine crawlers not to index the page and not to follow its links.
+func (m *Meta) NoIndex() *Meta {
+	return m.Robots("noindex", "nofollow")
+}
+
+// OpenGraph sets an OpenGraph property of link previews, e.g. "title", "type", "image", or "url". The og: prefix is
+// added if it is missing.
+func (m *Meta) OpenGraph(property, content string) *Meta {
+	if !strings.Contains(property, ":") {
+		property = "og:" + property
+	}
+	m.set(MetaTag{Property: property, Content: content})
+	return m
+}
+
+// Twitter sets a property of Twitter (X) cards, e.g. "title", "site", or "image". The twitter: prefix is added if it
+// is missing.
+func (m *Meta) Twitter(name, content string) *Meta {
+	if !strings.HasPrefix(name, "twitter:") {
+		name = "twitter:" + name
+	}
+	return m.Tag(name, content)
+}
+
+// TwitterCard sets the type of Twitter (X) card, e.g. TwitterCardSummary.
+func (m *Meta) TwitterCard(card string) *Meta {
+	return m.Twitter("card", card)
+}
+
+// Tag sets the meta tag with the name, replacing a tag with the same name.
+func (m *Meta) Tag(name, content string) *Meta {
+	m.set(MetaTag{Name: name, Content: content})
+	return m
+}
+
+// set adds the tag, or replaces the tag with the same name and property.
+func (m *Meta) set(tag MetaTag) {
+	for i, existing := range m.tags {
+		if existing.Name == tag.Name && existing.Property == tag.Property {
+			m.tags[i] = tag
+			return
+		}
+	}
+	m.tags = append(m.tags, tag)
+}
+
+// CanonicalURL returns the canonical URL of the page, if set.
+func (m *Meta) CanonicalURL() string {
+	return m.canonical
+}
+
+// Tags returns the meta tags in the order they were first set.
+func (m *Meta) Tags() []MetaTag {
+	return slices.Clone(m.tags)
+}
+
+// Content returns the content of the meta tag with the name or property, e.g. "description" or "og:image", or an
+// empty string if it is not set.
+func (m *Meta) Content(key string) string {
+	for _, tag := range m.tags {
+		if tag.Name == key || tag.Property == key {
+			return tag.Content
+		}
+	}
+	return ""
+}
+
+// HTML returns the meta tags and the canonical link of the page as HTML, with escaped values.
+func (m *Meta) HTML() template.HTML {
+	var sb strings.Builder
+	if m.canonical != "" {
+		sb.WriteString(`<link rel="canonical" href="` + html.EscapeString(m.canonical) + `">` + "\n")
+	}
+	for _, tag := range m.tags {
+		if tag.Property != "" {
+			sb.WriteString(`<meta property="` + html.EscapeString(tag.Property) + `"`)
+		} else {
+			sb.WriteString(`<meta name="` + html.EscapeString(tag.Name) + `"`)
+		}
+		sb.WriteString(` content="` + html.EscapeString(tag.Content) + `">` + "\n")
+	}
+	return template.HTML(sb.String())
+}
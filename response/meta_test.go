@@ -0,0 +1,76 @@
+package response_test
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestMeta(t *testing.T) {
+	meta := response.NewMeta().
+		Description("Fresh <coffee> & beans").
+		Canonical("https://example.com/shop?a=1&b=2").
+		OpenGraph("title", "Shop").
+		OpenGraph("og:image", "https://example.com/shop.jpg").
+		TwitterCard(response.TwitterCardSummaryLargeImage).
+		Twitter("site", "@example").
+		NoIndex().
+		Description("Fresh coffee beans")
+
+	wantTags := []response.MetaTag{
+		{Name: "description", Content: "Fresh coffee beans"},
+		{Property: "og:title", Content: "Shop"},
+		{Property: "og:image", Content: "https://example.com/shop.jpg"},
+		{Name: "twitter:card", Content: "summary_large_image"},
+		{Name: "twitter:site", Content: "@example"},
+		{Name: "robots", Content: "noindex, nofollow"},
+	}
+	if got := meta.Tags(); !reflect.DeepEqual(got, wantTags) {
+		t.Errorf("Tags() = %+v, want %+v", got, wantTags)
+	}
+
+	if got := meta.Content("og:image"); got != "https://example.com/shop.jpg" {
+		t.Errorf("Content(og:image) = %q, want the image URL", got)
+	}
+	if got := meta.Content("keywords"); got != "" {
+		t.Errorf("Content(keywords) = %q, want empty", got)
+	}
+
+	wantHTML := `<link rel="canonical" href="https://example.com/shop?a=1&amp;b=2">
+<meta name="description" content="Fresh coffee beans">
+<meta property="og:title" content="Shop">
+<meta property="og:image" content="https://example.com/shop.jpg">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:site" content="@example">
+<meta name="robots" content="noindex, nofollow">
+`
+	if got := string(meta.HTML()); got != wantHTML {
+		t.Errorf("HTML() = %q, want %q", got, wantHTML)
+	}
+
+	escaped := response.NewMeta().Description(`"quoted" <b>`).HTML()
+	if want := `<meta name="description" content="&#34;quoted&#34; &lt;b&gt;">` + "\n"; string(escaped) != want {
+		t.Errorf("HTML() = %q, want %q", escaped, want)
+	}
+}
+
+func TestResponse_Meta(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+
+	if got := response.NewResponse().ViewData(r).Meta().HTML(); got != "" {
+		t.Errorf("Meta().HTML() without meta = %q, want empty", got)
+	}
+
+	proto := response.NewResponse().Meta(response.NewMeta().Description("Default"))
+	resp := proto.Clone()
+	resp.ViewData(r).Meta().Description("Page")
+
+	if got := resp.ViewData(r).Meta().Content("description"); got != "Page" {
+		t.Errorf("Meta().Content(description) = %q, want Page", got)
+	}
+	if got := proto.ViewData(r).Meta().Content("description"); got != "Default" {
+		t.Errorf("prototype Meta().Content(description) = %q, want Default", got)
+	}
+}
@@ -0,0 +1,156 @@
+package response
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/hypergopher/hyperview/request"
+)
+
+// Pagination is a page of a list of items, with the calculations of list pages and paginators:
+//
+//	page, perPage := request.ParsePagination(r)
+//	users, total := store.ListUsers(r.Context(), perPage, (page-1)*perPage)
+//	resp.Data(map[string]any{"Users": users, "Pagination": response.NewPagination(page, perPage, total)})
+type Pagination struct {
+	Page    int // current page, starting at 1
+	PerPage int // items per page
+	Total   int // total number of items
+}
+
+// PageLink is a link of a paginator (see Pagination.Links).
+type PageLink struct {
+	Page    int    // page number, or 0 for a gap
+	URL     string // URL of the page, or an empty string for a gap
+	Current bool   // whether the link is the current page
+	Gap     bool   // whether the link is a gap between pages, e.g. rendered as an ellipsis
+}
+
+// NewPagination creates a new Pagination. Pages below 1 are page 1, and PerPage defaults to request.DefaultPerPage.
+// Pages after the last page are kept, so that handlers can respond with not found (see Pagination.OutOfRange).
+func NewPagination(page, perPage, total int) Pagination {
+	if perPage < 1 {
+		perPage = request.DefaultPerPage
+	}
+	return Pagination{
+		Page:    max(page, 1),
+		PerPage: perPage,
+		Total:   max(total, 0),
+	}
+}
+
+// TotalPages returns the number of pages, which is at least 1, even for an empty list.
+func (p Pagination) TotalPages() int {
+	if p.Total == 0 || p.PerPage < 1 {
+		return 1
+	}
+	return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+// Offset returns the number of items before the current page, e.g. for the OFFSET of a query.
+func (p Pagination) Offset() int {
+	return (max(p.Page, 1) - 1) * p.PerPage
+}
+
+// FirstItem returns the position of the first item of the current page, starting at 1, or 0 if the page is empty.
+func (p Pagination) FirstItem() int {
+	if p.Offset() >= p.Total {
+		return 0
+	}
+	return p.Offset() + 1
+}
+
+// LastItem returns the position of the last item of the current page, or 0 if the page is empty.
+func (p Pagination) LastItem() int {
+	if p.Offset() >= p.Total {
+		return 0
+	}
+	return min(p.Offset()+p.PerPage, p.Total)
+}
+
+// HasPrev returns true if there is a page before the current page.
+func (p Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext returns true if there is a page after the current page.
+func (p Pagination) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// PrevPage returns the number of the previous page, or 1 on the first page.
+func (p Pagination) PrevPage() int {
+	return max(min(p.Page-1, p.TotalPages()), 1)
+}
+
+// NextPage returns the number of the next page, or the last page on the last page.
+func (p Pagination) NextPage() int {
+	return min(p.Page+1, p.TotalPages())
+}
+
+// OutOfRange returns true if the current page is after the last page.
+func (p Pagination) OutOfRange() bool {
+	return p.Page > p.TotalPages()
+}
+
+// Window returns the page numbers of a paginator: up to size pages around the current page, plus the first and the
+// last page, with 0 for each gap between them. For example, page 6 of 20 with a size of 3 is [1 0 5 6 7 0 20]. A gap
+// of a single page is replaced by the page itself.
+func (p Pagination) Window(size int) []int {
+	total := p.TotalPages()
+	size = min(max(size, 1), total)
+
+	start := min(max(p.Page-size/2, 1), total-size+1)
+	end := start + size - 1
+
+	pages := make([]int, 0, size+4)
+	switch {
+	case start == 2:
+		pages = append(pages, 1)
+	case start == 3:
+		pages = append(pages, 1, 2)
+	case start > 3:
+		pages = append(pages, 1, 0)
+	}
+	for page := start; page <= end; page++ {
+		pages = append(pages, page)
+	}
+	switch {
+	case end == total-1:
+		pages = append(pages, total)
+	case end == total-2:
+		pages = append(pages, total-1, total)
+	case end < total-2:
+		pages = append(pages, 0, total)
+	}
+
+	return pages
+}
+
+// Links returns the links of the pages of Window, with the URL of each page being the base URL with the page query
+// parameter set. Other query parameters of the base URL, e.g. filters, are kept.
+func (p Pagination) Links(baseURL string, size int) []PageLink {
+	pages := p.Window(size)
+	links := make([]PageLink, len(pages))
+	for i, page := range pages {
+		if page == 0 {
+			links[i] = PageLink{Gap: true}
+			continue
+		}
+		links[i] = PageLink{Page: page, URL: p.PageURL(baseURL, page), Current: page == p.Page}
+	}
+	return links
+}
+
+// PageURL returns the base URL with the page query parameter set to the page, e.g. for the previous and next links.
+func (p Pagination) PageURL(baseURL string, page int) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL
+	}
+
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,86 @@
+package response_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestNewPagination(t *testing.T) {
+	p := response.NewPagination(0, 0, -5)
+	want := response.Pagination{Page: 1, PerPage: request.DefaultPerPage, Total: 0}
+	if p != want {
+		t.Errorf("NewPagination() = %+v, want %+v", p, want)
+	}
+}
+
+func TestPagination_Calculations(t *testing.T) {
+	tests := []struct {
+		name                            string
+		p                               response.Pagination
+		totalPages, offset, first, last int
+		prev, next                      int
+		hasPrev, hasNext, outOfRange    bool
+	}{
+		{"first page", response.NewPagination(1, 10, 95), 10, 0, 1, 10, 1, 2, false, true, false},
+		{"middle page", response.NewPagination(4, 10, 95), 10, 30, 31, 40, 3, 5, true, true, false},
+		{"last page", response.NewPagination(10, 10, 95), 10, 90, 91, 95, 9, 10, true, false, false},
+		{"out of range", response.NewPagination(12, 10, 95), 10, 110, 0, 0, 10, 10, true, false, true},
+		{"empty list", response.NewPagination(1, 10, 0), 1, 0, 0, 0, 1, 1, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.p
+			got := []any{p.TotalPages(), p.Offset(), p.FirstItem(), p.LastItem(), p.PrevPage(), p.NextPage(), p.HasPrev(), p.HasNext(), p.OutOfRange()}
+			want := []any{tt.totalPages, tt.offset, tt.first, tt.last, tt.prev, tt.next, tt.hasPrev, tt.hasNext, tt.outOfRange}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TotalPages, Offset, FirstItem, LastItem, PrevPage, NextPage, HasPrev, HasNext, OutOfRange = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPagination_Window(t *testing.T) {
+	tests := []struct {
+		name  string
+		page  int
+		total int
+		size  int
+		want  []int
+	}{
+		{"middle", 6, 200, 3, []int{1, 0, 5, 6, 7, 0, 20}},
+		{"start", 1, 200, 3, []int{1, 2, 3, 0, 20}},
+		{"end", 20, 200, 3, []int{1, 0, 18, 19, 20}},
+		{"single page gaps", 5, 200, 5, []int{1, 2, 3, 4, 5, 6, 7, 0, 20}},
+		{"fewer pages than size", 2, 30, 5, []int{1, 2, 3}},
+		{"one page", 1, 0, 5, []int{1}},
+		{"size below 1", 3, 50, 0, []int{1, 2, 3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := response.NewPagination(tt.page, 10, tt.total)
+			if got := p.Window(tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Window(%d) = %v, want %v", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagination_Links(t *testing.T) {
+	p := response.NewPagination(2, 10, 100)
+	got := p.Links("/users?sort=name&page=2", 3)
+	want := []response.PageLink{
+		{Page: 1, URL: "/users?page=1&sort=name"},
+		{Page: 2, URL: "/users?page=2&sort=name", Current: true},
+		{Page: 3, URL: "/users?page=3&sort=name"},
+		{Gap: true},
+		{Page: 10, URL: "/users?page=10&sort=name"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Links() = %+v, want %+v", got, want)
+	}
+}
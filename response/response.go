@@ -1,23 +1,90 @@
 package response
 
 import (
+	"io"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hypergopher/hyperview/constants"
 	"github.com/hypergopher/hyperview/htmx"
 	"github.com/hypergopher/hyperview/htmx/trigger"
+	"github.com/hypergopher/hyperview/unpoly"
+)
+
+// PageMode determines whether a response is rendered within its layout or as a partial page without the layout.
+type PageMode int
+
+const (
+	// PageModeDefault lets the view service decide how to render the response (e.g. based on the HTMX partial mode).
+	PageModeDefault PageMode = iota
+	// PageModeFull always renders the response within its layout.
+	PageModeFull
+	// PageModePartial always renders only the page template, without the layout.
+	PageModePartial
+)
+
+// JSONFormat determines whether JSON output is indented or compact.
+type JSONFormat int
+
+const (
+	// JSONFormatDefault lets the adapter decide how to format the output (e.g. based on its options).
+	JSONFormatDefault JSONFormat = iota
+	// JSONFormatPretty always indents the output.
+	JSONFormatPretty
+	// JSONFormatCompact always renders the output without whitespace.
+	JSONFormatCompact
 )
 
+// Node is an HTML node that renders itself to a writer. It is compatible with gomponents (g.Node), so that
+// gomponents trees can be rendered with Response.Node without depending on gomponents.
+type Node interface {
+	Render(w io.Writer) error
+}
+
 // Response represents a view response to an HTTP request
 // It uses a fluent interface to allow for chaining of methods, so that methods can be called in any order.
 type Response struct {
+	// The body to stream instead of rendering a template (default: nil)
+	body io.Reader
+	// The key under which the rendered output is cached (default: empty, not cached)
+	cacheKey string
+	// The tags of the cached output and of CDN caches (default: empty)
+	cacheTags []string
+	// How long the rendered output is cached (default: 0, until it is deleted)
+	cacheTTL time.Duration
+	// The cookies to be set on the response (default: empty)
+	cookies []*http.Cookie
+	// The path of the file on disk to stream instead of rendering a template (default: empty)
+	filePath string
+	// The named template (fragment) to render instead of the layout (default: empty)
+	fragment string
 	// The headers to be passed to the response (default: empty)
 	headers map[string]string
 	// The layout template to be used (required, no default)
 	layout string
+	// The chain of layouts to be used, from the outermost to the innermost layout (default: empty)
+	layouts []string
+	// The meta tags of the head of the page (default: nil)
+	meta *Meta
+	// Whether the response is rendered with or without its layout (default: PageModeDefault)
+	pageMode PageMode
+	// Whether the response is written without a body and without rendering a template (default: false)
+	noContent bool
+	// The node to render instead of a template, e.g. a gomponents tree (default: nil)
+	node Node
+	// The Protocol Buffers message to render instead of the view data (default: nil)
+	protoMessage proto.Message
+	// Whether JSON output is indented or compact (default: JSONFormatDefault)
+	jsonFormat JSONFormat
 	// The view template path to be used (required, no default)
 	path string
+	// The value to render as JSON without an envelope (default: nil)
+	rawJSON any
 	// The status code to be passed to the response (default: http.StatusOK)
 	request *http.Request
 	// The status code to be passed to the response (default: http.StatusOK)
@@ -26,6 +93,10 @@ type Response struct {
 	title string
 	// The triggers to be passed to the response (default: empty)
 	triggers *trigger.Triggers
+	// The Unpoly events to be passed to the response (default: empty)
+	upEvents []unpoly.Event
+	// The request headers the output depends on, emitted in the Vary header (default: empty)
+	vary []string
 	// The view data to be passed to the template (default: ViewData{})
 	data *Data
 }
@@ -42,10 +113,44 @@ func NewResponse() *Response {
 	}
 }
 
+// Clone returns a copy of the response, e.g. of a prototype response with default headers, layout, and cache policy
+// that is shared by handlers, so that the copy can be changed without changing the original. The headers, cookies,
+// triggers, events, and view data are copied, but the values in the view data, the body, node, and Protocol Buffers
+// message are shared.
+func (resp *Response) Clone() *Response {
+	c := *resp
+	c.cacheTags = slices.Clone(resp.cacheTags)
+	c.headers = maps.Clone(resp.headers)
+	c.layouts = slices.Clone(resp.layouts)
+	c.meta = resp.meta.Clone()
+	c.vary = slices.Clone(resp.vary)
+
+	c.cookies = make([]*http.Cookie, 0, len(resp.cookies))
+	for _, cookie := range resp.cookies {
+		copied := *cookie
+		c.cookies = append(c.cookies, &copied)
+	}
+
+	c.upEvents = make([]unpoly.Event, 0, len(resp.upEvents))
+	for _, event := range resp.upEvents {
+		c.upEvents = append(c.upEvents, maps.Clone(event))
+	}
+
+	if resp.triggers != nil {
+		c.triggers = resp.triggers.Clone()
+	}
+	if resp.data != nil {
+		c.data = resp.data.Clone()
+	}
+
+	return &c
+}
+
 // ViewData returns the view data model. The request is set here to ensure
 // the request is available in the template and that it is not overwritten until later in the process.
 func (resp *Response) ViewData(r *http.Request) *Data {
 	resp.data.SetTitle(resp.title)
+	resp.data.SetMeta(resp.meta)
 	resp.data.SetRequest(r)
 	return resp.data
 }
@@ -79,6 +184,18 @@ func (resp *Response) Headers() map[string]string {
 		}
 	}
 
+	if len(resp.cacheTags) > 0 {
+		resp.headers[SurrogateKeyHeader] = strings.Join(resp.cacheTags, " ")
+		resp.headers[CacheTagHeader] = strings.Join(resp.cacheTags, ",")
+	}
+
+	if len(resp.upEvents) > 0 {
+		val, err := unpoly.EncodeEvents(resp.upEvents)
+		if err == nil {
+			resp.headers[unpoly.XUpEvents] = val
+		}
+	}
+
 	return resp.headers
 }
 
@@ -101,11 +218,60 @@ func (resp *Response) TemplateLayout() string {
 	return resp.layout
 }
 
+// TemplateLayouts returns the chain of template layouts, from the outermost to the innermost layout. If no chain
+// was set with Layouts, it contains only the template layout, if any.
+func (resp *Response) TemplateLayouts() []string {
+	if len(resp.layouts) > 0 {
+		return resp.layouts
+	}
+
+	if resp.layout == "" {
+		return nil
+	}
+
+	return []string{resp.layout}
+}
+
+// TemplateFragment returns the name of the fragment to render, if any
+func (resp *Response) TemplateFragment() string {
+	return resp.fragment
+}
+
 // TemplatePath returns the path used in templates, if any
 func (resp *Response) TemplatePath() string {
 	return resp.path
 }
 
+// TemplateNode returns the node to render instead of a template, if any
+func (resp *Response) TemplateNode() Node {
+	return resp.node
+}
+
+// RawJSONValue returns the value to render as JSON without an envelope, if any
+func (resp *Response) RawJSONValue() any {
+	return resp.rawJSON
+}
+
+// ProtoMessage returns the Protocol Buffers message to render, if any
+func (resp *Response) ProtoMessage() proto.Message {
+	return resp.protoMessage
+}
+
+// PageMode returns the page mode, which determines whether the response is rendered with or without its layout.
+func (resp *Response) PageMode() PageMode {
+	return resp.pageMode
+}
+
+// IsPartial returns true if the response should be rendered without its layout.
+func (resp *Response) IsPartial() bool {
+	return resp.pageMode == PageModePartial
+}
+
+// JSONFormat returns the JSON format, which determines whether JSON output is indented or compact.
+func (resp *Response) JSONFormat() JSONFormat {
+	return resp.jsonFormat
+}
+
 // PageTitle returns the page title
 func (resp *Response) PageTitle() string {
 	return resp.title
@@ -158,12 +324,25 @@ func (resp *Response) Errors(msg string, fieldErrors map[string]string) *Respons
 	return resp
 }
 
+// OldInput adds the submitted values of a failed form submission by field name to the view data model, so that the
+// form can be rendered with the values the user entered (see Data.Old).
+func (resp *Response) OldInput(input map[string]string) *Response {
+	resp.data.AddDataItem("OldInput", input)
+	return resp
+}
+
 // Title sets the page title
 func (resp *Response) Title(title string) *Response {
 	resp.title = title
 	return resp
 }
 
+// Meta sets the meta tags of the head of the page, read by layouts with .View.Meta (see Meta).
+func (resp *Response) Meta(meta *Meta) *Response {
+	resp.meta = meta
+	return resp
+}
+
 // Path sets the template path
 func (resp *Response) Path(path string) *Response {
 	// If the path contains a colon, it's part of a plugin path, so we need to
@@ -190,6 +369,80 @@ func (resp *Response) Path(path string) *Response {
 // Then it returns the updated Response struct itself for method chaining.
 func (resp *Response) Layout(layout string) *Response {
 	resp.layout = layout
+	resp.layouts = nil
+	return resp
+}
+
+// Layouts sets a chain of layouts, from the outermost (root) layout to the innermost layout. Each layout can fill the
+// blocks of its parent layout, e.g. resp.Layouts("base", "admin") renders the "admin" layout within the "base" layout.
+// The innermost layout becomes the template layout of the response.
+//
+// Layouts can also declare their parent in the layout file with an extends directive, e.g. {{/* extends "base" */}},
+// in which case setting the innermost layout with Layout is enough.
+func (resp *Response) Layouts(layouts ...string) *Response {
+	if len(layouts) == 0 {
+		return resp.Layout("")
+	}
+
+	resp.layout = layouts[len(layouts)-1]
+	resp.layouts = layouts
+	return resp
+}
+
+// Fragment sets the name of a single defined template (e.g. {{define "row"}}) from the page template to render,
+// instead of the layout. This allows swapping a single row or card with HTMX without separate partial files.
+//
+// Example: resp.Path("views/users/show").Fragment("row")
+func (resp *Response) Fragment(name string) *Response {
+	resp.fragment = name
+	return resp
+}
+
+// Node sets a node to render instead of a template, e.g. a gomponents tree. Responses with a node are rendered by
+// the node adapter.
+func (resp *Response) Node(node Node) *Response {
+	resp.node = node
+	return resp
+}
+
+// Proto sets a Protocol Buffers message to render instead of the view data. Responses with a message are rendered by
+// the proto adapter, as application/x-protobuf for clients that accept it and as JSON otherwise.
+func (resp *Response) Proto(msg proto.Message) *Response {
+	resp.protoMessage = msg
+	return resp
+}
+
+// RawJSON sets a value to render as JSON as is, without the envelope of the JSON adapter, for consumers that require
+// an exact top-level shape. Responses with a raw JSON value are rendered by the json adapter, with any status code.
+func (resp *Response) RawJSON(v any) *Response {
+	resp.rawJSON = v
+	return resp
+}
+
+// PrettyJSON forces the JSON output of the response to be indented, regardless of the adapter options.
+func (resp *Response) PrettyJSON() *Response {
+	resp.jsonFormat = JSONFormatPretty
+	return resp
+}
+
+// CompactJSON forces the JSON output of the response to be rendered without whitespace, regardless of the adapter
+// options.
+func (resp *Response) CompactJSON() *Response {
+	resp.jsonFormat = JSONFormatCompact
+	return resp
+}
+
+// FullPage forces the response to be rendered within its layout, even for HTMX requests when the HTMX partial mode
+// of the view service is enabled.
+func (resp *Response) FullPage() *Response {
+	resp.pageMode = PageModeFull
+	return resp
+}
+
+// PartialOnly forces the response to be rendered without its layout, regardless of the type of request.
+// Only the page template (by default "page:main") is rendered.
+func (resp *Response) PartialOnly() *Response {
+	resp.pageMode = PageModePartial
 	return resp
 }
 
@@ -200,6 +453,7 @@ func (resp *Response) Layout(layout string) *Response {
 //   - hxLayout is the layout to use for HTMX requests.
 //   - layout is the default layout to use if the request is not an HTMX request.
 func (resp *Response) HxLayout(r *http.Request, hxLayout, layout string) *Response {
+	resp.Vary(htmx.HXRequest, htmx.HXBoosted)
 	if htmx.IsHtmxRequest(r) {
 		resp.Layout(hxLayout)
 	} else {
@@ -284,6 +538,68 @@ func (resp *Response) StatusUnauthorized() *Response {
 	return resp
 }
 
+// NoContent writes the response without a body: Render writes only the headers (including HTMX triggers) and cookies
+// with the status code 204 No Content, without looking up or rendering a template.
+//
+// htmx processes the headers of 204 responses, e.g. to trigger events, but does not swap them. To remove the target
+// element with hx-swap="delete", change the status code to 200 OK with StatusOK after calling NoContent.
+func (resp *Response) NoContent() *Response {
+	resp.noContent = true
+	resp.statusCode = http.StatusNoContent
+	return resp
+}
+
+// IsNoContent returns true if the response is written without a body (see NoContent).
+func (resp *Response) IsNoContent() bool {
+	return resp.noContent
+}
+
+// StatusBadRequest sets the status code to BadRequest (400)
+func (resp *Response) StatusBadRequest() *Response {
+	resp.statusCode = http.StatusBadRequest
+	return resp
+}
+
+// StatusMethodNotAllowed sets the status code to MethodNotAllowed (405)
+func (resp *Response) StatusMethodNotAllowed() *Response {
+	resp.statusCode = http.StatusMethodNotAllowed
+	return resp
+}
+
+// StatusConflict sets the status code to Conflict (409)
+func (resp *Response) StatusConflict() *Response {
+	resp.statusCode = http.StatusConflict
+	return resp
+}
+
+// StatusGone sets the status code to Gone (410)
+func (resp *Response) StatusGone() *Response {
+	resp.statusCode = http.StatusGone
+	return resp
+}
+
+// StatusPreconditionFailed sets the status code to PreconditionFailed (412)
+func (resp *Response) StatusPreconditionFailed() *Response {
+	resp.statusCode = http.StatusPreconditionFailed
+	return resp
+}
+
+// StatusTooManyRequests sets the status code to TooManyRequests (429)
+func (resp *Response) StatusTooManyRequests() *Response {
+	resp.statusCode = http.StatusTooManyRequests
+	return resp
+}
+
+// Location sets the Location header to the URL, e.g. of the resource created by a 201 Created response.
+func (resp *Response) Location(url string) *Response {
+	return resp.Header("Location", url)
+}
+
+// Created sets the status code to Created (201) and the Location header to the URL of the created resource.
+func (resp *Response) Created(url string) *Response {
+	return resp.StatusCreated().Location(url)
+}
+
 // StatusStopPolling sets the status code to 286 and returns the Response object.
 // This is useful when working HTMX and polling. Responding with a status of 286 will tell HTMX to stop polling.
 // SEE: https://htmx.org/docs/#polling
@@ -1,5 +1,18 @@
 package response
 
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	// SurrogateKeyHeader is the header with the space-separated cache tags of a response, as used by Fastly.
+	SurrogateKeyHeader = "Surrogate-Key"
+	// CacheTagHeader is the header with the comma-separated cache tags of a response, as used by Cloudflare and Akamai.
+	CacheTagHeader = "Cache-Tag"
+)
+
 // NoCacheStrict sets the Cache-Control header to "no-cache, no-store, must-revalidate".
 func (resp *Response) NoCacheStrict() {
 	resp.headers["Cache-Control"] = "no-cache, no-store, must-revalidate"
@@ -19,3 +32,58 @@ func (resp *Response) ETag(etag string) {
 func (resp *Response) LastModified(lastModified string) {
 	resp.headers["Last-Modified"] = lastModified
 }
+
+// Cache caches the rendered output of the response under the key (e.g. "page:users:42") for the ttl, so that it is
+// only rendered again when the cached output has expired. The output is cached in the render cache of the adapter;
+// without one, the response is rendered as usual. Headers and the status code of the response are not cached.
+func (resp *Response) Cache(key string, ttl time.Duration) *Response {
+	resp.cacheKey = key
+	resp.cacheTTL = ttl
+	return resp
+}
+
+// CacheKey returns the key under which the rendered output is cached, if any.
+func (resp *Response) CacheKey() string {
+	return resp.cacheKey
+}
+
+// CacheTTL returns how long the rendered output is cached.
+func (resp *Response) CacheTTL() time.Duration {
+	return resp.cacheTTL
+}
+
+// CacheTags tags the response (e.g. "products", "product:42"), so that caches can be purged by tag. The tags are
+// emitted in the Surrogate-Key and Cache-Tag headers for CDNs and the page cache, and stored with the output cached
+// in the render cache (see Response.Cache), so that local and CDN caches share the same tags.
+func (resp *Response) CacheTags(tags ...string) *Response {
+	for _, tag := range tags {
+		if tag != "" && !slices.Contains(resp.cacheTags, tag) {
+			resp.cacheTags = append(resp.cacheTags, tag)
+		}
+	}
+	return resp
+}
+
+// Vary adds request headers that the output of the response depends on to the Vary header, so that caches store a
+// separate response per value (e.g. HX-Request for responses that are rendered differently for HTMX requests).
+// HyperView adds the HTMX headers automatically when it renders HTMX requests differently (see Response.HxLayout and
+// WithHtmxPartialMode).
+func (resp *Response) Vary(headers ...string) *Response {
+	for _, header := range headers {
+		header = http.CanonicalHeaderKey(header)
+		if header != "" && !slices.Contains(resp.vary, header) {
+			resp.vary = append(resp.vary, header)
+		}
+	}
+	return resp
+}
+
+// VaryHeaders returns the request headers that the output of the response depends on.
+func (resp *Response) VaryHeaders() []string {
+	return resp.vary
+}
+
+// Tags returns the cache tags of the response.
+func (resp *Response) Tags() []string {
+	return resp.cacheTags
+}
@@ -0,0 +1,56 @@
+package response_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestResponse_CacheTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		tags             [][]string
+		wantSurrogateKey string
+		wantCacheTag     string
+	}{
+		{name: "no tags"},
+		{name: "single call", tags: [][]string{{"products", "product:42"}}, wantSurrogateKey: "products product:42", wantCacheTag: "products,product:42"},
+		{name: "multiple calls", tags: [][]string{{"products"}, {"product:42", "products", ""}}, wantSurrogateKey: "products product:42", wantCacheTag: "products,product:42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := response.NewResponse()
+			for _, tags := range tt.tags {
+				resp.CacheTags(tags...)
+			}
+
+			headers := resp.Headers()
+			if got := headers[response.SurrogateKeyHeader]; got != tt.wantSurrogateKey {
+				t.Errorf("Surrogate-Key = %q, want %q", got, tt.wantSurrogateKey)
+			}
+			if got := headers[response.CacheTagHeader]; got != tt.wantCacheTag {
+				t.Errorf("Cache-Tag = %q, want %q", got, tt.wantCacheTag)
+			}
+			if got := strings.Join(resp.Tags(), ","); got != tt.wantCacheTag {
+				t.Errorf("Tags() = %v, want %q", resp.Tags(), tt.wantCacheTag)
+			}
+		})
+	}
+}
+
+func TestResponse_Vary(t *testing.T) {
+	resp := response.NewResponse().Vary("hx-request", "Accept-Language").Vary("HX-Request", "")
+
+	got := resp.VaryHeaders()
+	want := []string{"Hx-Request", "Accept-Language"}
+	if len(got) != len(want) {
+		t.Fatalf("VaryHeaders() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("VaryHeaders()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
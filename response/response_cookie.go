@@ -0,0 +1,42 @@
+package response
+
+import (
+	"net/http"
+	"time"
+)
+
+// SetCookie adds a Set-Cookie header with the cookie to the response. A cookie with the same name, path, and domain
+// replaces the one set before, so that a handler can overwrite a cookie set by an earlier step. Invalid cookies are
+// dropped when the headers are written (see http.SetCookie).
+func (resp *Response) SetCookie(cookie *http.Cookie) *Response {
+	if cookie == nil {
+		return resp
+	}
+
+	for i, c := range resp.cookies {
+		if c.Name == cookie.Name && c.Path == cookie.Path && c.Domain == cookie.Domain {
+			resp.cookies[i] = cookie
+			return resp
+		}
+	}
+
+	resp.cookies = append(resp.cookies, cookie)
+	return resp
+}
+
+// DeleteCookie adds a Set-Cookie header that tells the client to delete the cookie with the name and the path "/".
+// Cookies set with another path or a domain must be deleted with SetCookie and a cookie with the same path and domain
+// and a negative MaxAge.
+func (resp *Response) DeleteCookie(name string) *Response {
+	return resp.SetCookie(&http.Cookie{
+		Name:    name,
+		Path:    "/",
+		Expires: time.Unix(0, 0),
+		MaxAge:  -1,
+	})
+}
+
+// Cookies returns the cookies to be set on the response.
+func (resp *Response) Cookies() []*http.Cookie {
+	return resp.cookies
+}
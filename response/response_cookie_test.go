@@ -0,0 +1,28 @@
+package response_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestResponse_SetCookie(t *testing.T) {
+	resp := response.NewResponse().
+		SetCookie(&http.Cookie{Name: "theme", Value: "light"}).
+		SetCookie(&http.Cookie{Name: "theme", Value: "light", Path: "/admin"}).
+		SetCookie(nil).
+		SetCookie(&http.Cookie{Name: "theme", Value: "dark"}).
+		DeleteCookie("flash")
+
+	cookies := resp.Cookies()
+	if len(cookies) != 3 {
+		t.Fatalf("Cookies() = %v, want 3 cookies", cookies)
+	}
+	if cookies[0].Value != "dark" || cookies[1].Path != "/admin" {
+		t.Errorf("Cookies() = %v, want theme=dark and theme=light with path /admin", cookies)
+	}
+	if cookies[2].Name != "flash" || cookies[2].MaxAge >= 0 || cookies[2].Path != "/" {
+		t.Errorf("DeleteCookie() = %v, want an expired flash cookie with path /", cookies[2])
+	}
+}
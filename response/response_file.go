@@ -0,0 +1,136 @@
+package response
+
+import (
+	"io"
+	"mime"
+	"path"
+	"strings"
+)
+
+// Download sets the Content-Disposition header, so that browsers save the response as a file with the filename
+// instead of displaying it, and sets the Content-Type header from the extension of the filename unless it is set.
+// Non-ASCII filenames are encoded as described in RFC 6266 and RFC 5987, with an ASCII fallback for old clients.
+func (resp *Response) Download(filename string) *Response {
+	return resp.disposition("attachment", filename)
+}
+
+// Inline sets the Content-Disposition header, so that browsers display the response (e.g. a PDF) and use the filename
+// when it is saved, and sets the Content-Type header from the extension of the filename unless it is set (see
+// Download).
+func (resp *Response) Inline(filename string) *Response {
+	return resp.disposition("inline", filename)
+}
+
+// disposition sets the Content-Disposition header with the type and filename, and the Content-Type header from the
+// extension of the filename unless it is set.
+func (resp *Response) disposition(dispositionType, filename string) *Response {
+	// Only the base name is sent, so that filenames with directories don't leak paths
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "." || filename == "/" {
+		filename = ""
+	}
+
+	resp.Header("Content-Disposition", ContentDisposition(dispositionType, filename))
+
+	if _, ok := resp.headers["Content-Type"]; !ok {
+		if contentType := mime.TypeByExtension(path.Ext(filename)); contentType != "" {
+			resp.Header("Content-Type", contentType)
+		}
+	}
+
+	return resp
+}
+
+// ContentDisposition returns the value of a Content-Disposition header with the type ("attachment" or "inline") and
+// the filename, if any. Filenames with characters other than printable ASCII get an ASCII fallback in the filename
+// parameter and the UTF-8 encoded filename in the filename* parameter (RFC 6266 and RFC 5987).
+func ContentDisposition(dispositionType, filename string) string {
+	if filename == "" {
+		return dispositionType
+	}
+
+	var fallback strings.Builder
+	ascii := true
+	for _, r := range filename {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			// Control characters are dropped, they could split the header
+			ascii = false
+		case r > 0x7f:
+			fallback.WriteByte('_')
+			ascii = false
+		case r == '"' || r == '\\':
+			fallback.WriteByte('\\')
+			fallback.WriteRune(r)
+		default:
+			fallback.WriteRune(r)
+		}
+	}
+
+	value := dispositionType + `; filename="` + fallback.String() + `"`
+	if !ascii {
+		value += "; filename*=UTF-8''" + encodeRFC5987(filename)
+	}
+	return value
+}
+
+// encodeRFC5987 percent-encodes the UTF-8 bytes of the value that are not attr-chars (RFC 5987).
+func encodeRFC5987(value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		if c < 0x20 || c == 0x7f {
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// isAttrChar returns true if the byte is an attr-char of RFC 5987, which is not percent-encoded.
+func isAttrChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
+
+// Body streams the body to the client instead of rendering a template, with the file adapter. Bodies that implement
+// io.ReadSeeker (e.g. *os.File or *bytes.Reader) support Range requests and get a Content-Length, and bodies that
+// implement io.Closer are closed after the response is written. The Content-Type header is set to the content type
+// unless it is empty, in which case it is detected from the filename of Download or Inline or from the content.
+func (resp *Response) Body(body io.Reader, contentType string) *Response {
+	resp.body = body
+	if contentType != "" {
+		resp.Header("Content-Type", contentType)
+	}
+	return resp
+}
+
+// File streams the file on disk at the path to the client instead of rendering a template, with the file adapter. The
+// file supports Range requests and conditional requests by modification time, is sent with sendfile where the
+// platform supports it, and responds with the not found page if it does not exist. The Content-Type header is
+// detected from the extension of the file unless it is set. The path must not be taken from the request unchecked.
+func (resp *Response) File(path string) *Response {
+	resp.filePath = path
+	return resp
+}
+
+// BodyReader returns the body to stream instead of rendering a template, if any.
+func (resp *Response) BodyReader() io.Reader {
+	return resp.body
+}
+
+// FilePath returns the path of the file on disk to stream instead of rendering a template, if any.
+func (resp *Response) FilePath() string {
+	return resp.filePath
+}
@@ -0,0 +1,64 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestResponse_Download(t *testing.T) {
+	tests := []struct {
+		name            string
+		resp            *response.Response
+		wantDisposition string
+		wantType        string
+	}{
+		{
+			name:            "ascii",
+			resp:            response.NewResponse().Download("report.json"),
+			wantDisposition: `attachment; filename="report.json"`,
+			wantType:        "application/json",
+		},
+		{
+			name:            "non-ascii",
+			resp:            response.NewResponse().Download("résumé 2024.pdf"),
+			wantDisposition: `attachment; filename="r_sum_ 2024.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9%202024.pdf`,
+			wantType:        "application/pdf",
+		},
+		{
+			name:            "quotes and directories",
+			resp:            response.NewResponse().Download(`../exports\say "hi".png`),
+			wantDisposition: `attachment; filename="say \"hi\".png"`,
+			wantType:        "image/png",
+		},
+		{
+			name:            "control characters",
+			resp:            response.NewResponse().Download("a\r\nb.pdf"),
+			wantDisposition: `attachment; filename="ab.pdf"; filename*=UTF-8''ab.pdf`,
+			wantType:        "application/pdf",
+		},
+		{
+			name:            "inline with content type",
+			resp:            response.NewResponse().Header("Content-Type", "application/octet-stream").Inline("invoice.pdf"),
+			wantDisposition: `inline; filename="invoice.pdf"`,
+			wantType:        "application/octet-stream",
+		},
+		{
+			name:            "unknown extension",
+			resp:            response.NewResponse().Download("data.unknown-ext"),
+			wantDisposition: `attachment; filename="data.unknown-ext"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := tt.resp.Headers()
+			if got := headers["Content-Disposition"]; got != tt.wantDisposition {
+				t.Errorf("Content-Disposition = %q, want %q", got, tt.wantDisposition)
+			}
+			if got := headers["Content-Type"]; got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package response_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestResponse_Status(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *response.Response
+		want int
+	}{
+		{"created", response.NewResponse().StatusCreated(), http.StatusCreated},
+		{"accepted", response.NewResponse().StatusAccepted(), http.StatusAccepted},
+		{"no content", response.NewResponse().StatusNoContent(), http.StatusNoContent},
+		{"bad request", response.NewResponse().StatusBadRequest(), http.StatusBadRequest},
+		{"method not allowed", response.NewResponse().StatusMethodNotAllowed(), http.StatusMethodNotAllowed},
+		{"conflict", response.NewResponse().StatusConflict(), http.StatusConflict},
+		{"gone", response.NewResponse().StatusGone(), http.StatusGone},
+		{"precondition failed", response.NewResponse().StatusPreconditionFailed(), http.StatusPreconditionFailed},
+		{"unprocessable", response.NewResponse().StatusUnprocessable(), http.StatusUnprocessableEntity},
+		{"too many requests", response.NewResponse().StatusTooManyRequests(), http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.StatusCode(); got != tt.want {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponse_Created(t *testing.T) {
+	resp := response.NewResponse().Created("/users/42")
+
+	if resp.StatusCode() != http.StatusCreated {
+		t.Errorf("StatusCode() = %d, want %d", resp.StatusCode(), http.StatusCreated)
+	}
+	if got := resp.Headers()["Location"]; got != "/users/42" {
+		t.Errorf("Location = %q, want %q", got, "/users/42")
+	}
+}
+
+func TestResponse_Clone(t *testing.T) {
+	base := response.NewResponse().
+		Layout("app").
+		Header("X-Frame-Options", "DENY").
+		CacheTags("pages").
+		SetCookie(&http.Cookie{Name: "theme", Value: "light"}).
+		HxTrigger("loaded", nil).
+		Data(map[string]any{"Site": "HyperView"})
+
+	clone := base.Clone().
+		Layout("admin").
+		Header("X-Frame-Options", "SAMEORIGIN").
+		CacheTags("admin").
+		HxTrigger("saved", nil).
+		AddData(map[string]any{"User": "Gopher"})
+	clone.Cookies()[0].Value = "dark"
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"base layout", base.TemplateLayout(), "app"},
+		{"clone layout", clone.TemplateLayout(), "admin"},
+		{"base header", base.Headers()["X-Frame-Options"], "DENY"},
+		{"clone header", clone.Headers()["X-Frame-Options"], "SAMEORIGIN"},
+		{"base cache tags", base.Headers()[response.SurrogateKeyHeader], "pages"},
+		{"clone cache tags", clone.Headers()[response.SurrogateKeyHeader], "pages admin"},
+		{"base cookie", base.Cookies()[0].Value, "light"},
+		{"clone cookie", clone.Cookies()[0].Value, "dark"},
+		{"base trigger", base.Headers()["HX-Trigger"], `{"loaded":""}`},
+		{"base data", base.ViewData(nil).Get("User"), ""},
+		{"clone data", clone.ViewData(nil).Get("User"), "Gopher"},
+		{"clone shared data", clone.ViewData(nil).Get("Site"), "HyperView"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
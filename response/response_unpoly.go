@@ -0,0 +1,92 @@
+package response
+
+import (
+	"encoding/json"
+
+	"github.com/hypergopher/hyperview/unpoly"
+)
+
+// UpTarget sets the X-Up-Target header, which instructs Unpoly to update a different fragment than the one requested.
+// Use ":none" to not update any fragment.
+//
+// For more information, see: https://unpoly.com/X-Up-Target
+func (resp *Response) UpTarget(target string) *Response {
+	resp.headers[unpoly.XUpTarget] = target
+	return resp
+}
+
+// UpAcceptLayer sets the X-Up-Accept-Layer header, which instructs Unpoly to accept the current overlay with the
+// given value. The value is encoded as JSON.
+//
+// For more information, see: https://unpoly.com/X-Up-Accept-Layer
+func (resp *Response) UpAcceptLayer(value any) *Response {
+	resp.headers[unpoly.XUpAcceptLayer] = encodeUpValue(value)
+	return resp
+}
+
+// UpDismissLayer sets the X-Up-Dismiss-Layer header, which instructs Unpoly to dismiss the current overlay with the
+// given value. The value is encoded as JSON.
+//
+// For more information, see: https://unpoly.com/X-Up-Dismiss-Layer
+func (resp *Response) UpDismissLayer(value any) *Response {
+	resp.headers[unpoly.XUpDismissLayer] = encodeUpValue(value)
+	return resp
+}
+
+// UpEvents adds events to the X-Up-Events header, which instructs Unpoly to emit the events on the document.
+//
+// For more information, see: https://unpoly.com/X-Up-Events
+func (resp *Response) UpEvents(events ...unpoly.Event) *Response {
+	resp.upEvents = append(resp.upEvents, events...)
+	return resp
+}
+
+// UpEvent adds an event with the given type and properties to the X-Up-Events header.
+//
+// For more information, see: https://unpoly.com/X-Up-Events
+func (resp *Response) UpEvent(eventType string, props map[string]any) *Response {
+	return resp.UpEvents(unpoly.NewEvent(eventType, props))
+}
+
+// UpExpireCache sets the X-Up-Expire-Cache header, which instructs Unpoly to expire the cached responses matching the
+// given URL pattern (e.g. "/users/*"). Use "*" to expire all cached responses.
+//
+// For more information, see: https://unpoly.com/X-Up-Expire-Cache
+func (resp *Response) UpExpireCache(pattern string) *Response {
+	resp.headers[unpoly.XUpExpireCache] = pattern
+	return resp
+}
+
+// UpEvictCache sets the X-Up-Evict-Cache header, which instructs Unpoly to evict the cached responses matching the
+// given URL pattern (e.g. "/users/*"). Use "*" to evict all cached responses.
+//
+// For more information, see: https://unpoly.com/X-Up-Evict-Cache
+func (resp *Response) UpEvictCache(pattern string) *Response {
+	resp.headers[unpoly.XUpEvictCache] = pattern
+	return resp
+}
+
+// UpLocation sets the X-Up-Location header, which instructs Unpoly to use the given URL as the URL of the response.
+//
+// For more information, see: https://unpoly.com/X-Up-Location
+func (resp *Response) UpLocation(url string) *Response {
+	resp.headers[unpoly.XUpLocation] = url
+	return resp
+}
+
+// UpTitle sets the X-Up-Title header, which instructs Unpoly to set the document title.
+//
+// For more information, see: https://unpoly.com/X-Up-Title
+func (resp *Response) UpTitle(title string) *Response {
+	resp.headers[unpoly.XUpTitle] = encodeUpValue(title)
+	return resp
+}
+
+// encodeUpValue encodes the value as JSON for Unpoly headers, falling back to null if it cannot be encoded.
+func encodeUpValue(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
@@ -0,0 +1,31 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/response"
+	"github.com/hypergopher/hyperview/unpoly"
+)
+
+func TestResponse_UnpolyHeaders(t *testing.T) {
+	resp := response.NewResponse().
+		UpTarget(".users").
+		UpDismissLayer(map[string]int{"id": 1}).
+		UpTitle("Users").
+		UpEvent("user:created", map[string]any{"id": 1}).
+		UpEvents(unpoly.NewEvent("flash", nil))
+
+	want := map[string]string{
+		unpoly.XUpTarget:       ".users",
+		unpoly.XUpDismissLayer: `{"id":1}`,
+		unpoly.XUpTitle:        `"Users"`,
+		unpoly.XUpEvents:       `[{"id":1,"type":"user:created"},{"type":"flash"}]`,
+	}
+
+	headers := resp.Headers()
+	for key, value := range want {
+		if headers[key] != value {
+			t.Errorf("header %s = %q, want %q", key, headers[key], value)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package response
+
+import "net/http"
+
+// SessionReader reads values from the session of a request, so that templates can read them with Data.Session
+// regardless of the session library, e.g. scs, gorilla/sessions, or a custom store.
+type SessionReader interface {
+	// SessionValue returns the value of the key in the session of the request, or nil if there is none.
+	SessionValue(r *http.Request, key string) any
+}
+
+// SessionReaderFunc is a function that implements SessionReader, e.g. to read from an scs session manager:
+//
+//	response.SessionReaderFunc(func(r *http.Request, key string) any {
+//		return sessionManager.Get(r.Context(), key)
+//	})
+type SessionReaderFunc func(r *http.Request, key string) any
+
+// SessionValue calls the function.
+func (f SessionReaderFunc) SessionValue(r *http.Request, key string) any {
+	return f(r, key)
+}
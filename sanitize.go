@@ -0,0 +1,22 @@
+package hyperview
+
+import (
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// WithSanitizer sets the policy of the sanitizeHTML function of the default template adapter, which removes unsafe
+// markup from untrusted HTML (default: sanitize.UGCPolicy). The sanitizer can be a *sanitize.Policy or a bluemonday
+// policy:
+//
+//	hyperview.WithSanitizer(sanitize.UGCPolicy().AllowGlobalAttrs("class"))
+//
+//	{{.Comment.Body | sanitizeHTML}}
+//
+// Functions set with WithFuncMap override the sanitizeHTML function. Other adapters get it with
+// funcs.SanitizeHTMLFunc.
+func WithSanitizer(sanitizer funcs.Sanitizer) Option {
+	return func(hgo *HyperView) error {
+		hgo.sanitizer = sanitizer
+		return nil
+	}
+}
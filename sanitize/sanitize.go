@@ -0,0 +1,339 @@
+// Package sanitize removes unsafe markup from untrusted HTML, such as user comments, with an allowlist of elements
+// and attributes, so that it can be rendered without safeHTML:
+//
+//	policy := sanitize.UGCPolicy().AllowAttrs("span", "class")
+//	clean := policy.Sanitize(`<p onclick="steal()">Hi <script>alert(1)</script><a href="javascript:x">there</a></p>`)
+//	// <p>Hi <a rel="nofollow">there</a></p>
+//
+// Elements that are not allowed are removed with their attributes, but their text is kept, except for script,
+// style, and other elements whose content is not HTML, which are removed completely. Comments, doctypes, and
+// processing instructions are always removed. The output is serialized from the allowed elements and attributes
+// with escaped text and attribute values, so it never contains markup that was not allowed, even for malformed
+// input.
+//
+// A *Policy has the same Sanitize method as the policies of github.com/microcosm-cc/bluemonday, so either can be
+// used with hyperview.WithSanitizer.
+package sanitize
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// urlAttrs are the attributes whose values are URLs, which are only kept with an allowed scheme or without a scheme.
+var urlAttrs = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"formaction": true,
+	"href":       true,
+	"longdesc":   true,
+	"poster":     true,
+	"src":        true,
+	"usemap":     true,
+}
+
+// rawTextElements are the elements whose content is not parsed as HTML, which are removed with their content unless
+// they are allowed.
+var rawTextElements = map[string]bool{
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"noscript":  true,
+	"plaintext": true,
+	"script":    true,
+	"style":     true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+}
+
+// voidElements are the elements without content and end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// Policy is an allowlist of elements, attributes, and URL schemes. A Policy is configured with its Allow methods
+// before use, and is then safe for concurrent use by Sanitize.
+type Policy struct {
+	elements    map[string]map[string]bool // allowed attributes by allowed element
+	globalAttrs map[string]bool            // attributes allowed on all allowed elements
+	schemes     map[string]bool            // allowed schemes of URL attributes
+	noFollow    bool                       // add rel="nofollow" to links
+}
+
+// NewPolicy returns a policy that allows no elements, so that only the text of the HTML is kept, and the URL schemes
+// http, https, and mailto.
+func NewPolicy() *Policy {
+	return &Policy{
+		elements:    make(map[string]map[string]bool),
+		globalAttrs: make(map[string]bool),
+		schemes:     map[string]bool{"http": true, "https": true, "mailto": true},
+	}
+}
+
+// StrictPolicy returns a policy that removes all elements and keeps only the text, e.g. for titles and names.
+func StrictPolicy() *Policy {
+	return NewPolicy()
+}
+
+// UGCPolicy returns a policy for user-generated content, such as comments and posts, with text formatting,
+// headings, lists, quotes, code, tables, links, and images. Links get rel="nofollow".
+func UGCPolicy() *Policy {
+	return NewPolicy().
+		AllowElements("p", "br", "hr", "b", "strong", "i", "em", "u", "s", "del", "ins", "mark", "small", "sub", "sup",
+			"h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "li", "dl", "dt", "dd", "blockquote", "pre", "code", "kbd",
+			"table", "thead", "tbody", "tfoot", "tr", "caption", "figure", "figcaption", "details", "summary", "span",
+			"div").
+		AllowAttrs("a", "href", "title").
+		AllowAttrs("img", "src", "alt", "title", "width", "height").
+		AllowAttrs("blockquote", "cite").
+		AllowAttrs("ol", "start", "reversed").
+		AllowAttrs("td", "colspan", "rowspan").
+		AllowAttrs("th", "colspan", "rowspan", "scope").
+		RequireNoFollowLinks()
+}
+
+// AllowElements allows the elements without attributes, other than the global attributes.
+func (p *Policy) AllowElements(elements ...string) *Policy {
+	for _, element := range elements {
+		element = strings.ToLower(element)
+		if _, ok := p.elements[element]; !ok {
+			p.elements[element] = make(map[string]bool)
+		}
+	}
+	return p
+}
+
+// AllowAttrs allows the element with the attributes. Attributes whose values are URLs, such as href and src, are only
+// kept if their URL has an allowed scheme (see AllowURLSchemes) or is relative.
+func (p *Policy) AllowAttrs(element string, attrs ...string) *Policy {
+	p.AllowElements(element)
+	for _, attr := range attrs {
+		p.elements[strings.ToLower(element)][strings.ToLower(attr)] = true
+	}
+	return p
+}
+
+// AllowGlobalAttrs allows the attributes on all allowed elements, e.g. "class" or "lang".
+func (p *Policy) AllowGlobalAttrs(attrs ...string) *Policy {
+	for _, attr := range attrs {
+		p.globalAttrs[strings.ToLower(attr)] = true
+	}
+	return p
+}
+
+// AllowURLSchemes allows URLs with the schemes in URL attributes, in addition to http, https, and mailto.
+func (p *Policy) AllowURLSchemes(schemes ...string) *Policy {
+	for _, scheme := range schemes {
+		p.schemes[strings.ToLower(scheme)] = true
+	}
+	return p
+}
+
+// RequireNoFollowLinks adds rel="nofollow" to allowed a elements with an href attribute, so that search engines don't
+// follow links of untrusted content.
+func (p *Policy) RequireNoFollowLinks() *Policy {
+	p.noFollow = true
+	return p
+}
+
+// Sanitize returns the HTML with only the allowed elements and attributes.
+func (p *Policy) Sanitize(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			writeText(&sb, s)
+			break
+		}
+		writeText(&sb, s[:i])
+		s = s[i:]
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			s = skipPast(s[4:], "-->")
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			s = skipPast(s[2:], ">")
+		case strings.HasPrefix(s, "</") && len(s) > 2 && isLetter(s[2]):
+			name, rest := readName(s[2:])
+			s = skipPast(rest, ">")
+			if _, ok := p.elements[name]; ok && !voidElements[name] {
+				sb.WriteString("</" + name + ">")
+			}
+		case len(s) > 1 && isLetter(s[1]):
+			s = p.startTag(&sb, s[1:])
+		default:
+			sb.WriteString("&lt;")
+			s = s[1:]
+		}
+	}
+
+	return sb.String()
+}
+
+// startTag writes the start tag at the beginning of s, after its "<", if its element is allowed, and returns the rest
+// of s. The content of raw text elements is written as text if the element is allowed, and skipped otherwise.
+func (p *Policy) startTag(sb *strings.Builder, s string) string {
+	name, s := readName(s)
+	attrs, s, ok := readAttrs(s)
+	if !ok {
+		// Tags without their ">" at the end of the input are dropped, like browsers do
+		return ""
+	}
+
+	allowedAttrs, allowed := p.elements[name]
+	if allowed {
+		sb.WriteString("<" + name)
+		hasHref := false
+		for _, attr := range attrs {
+			if !allowedAttrs[attr.name] && !p.globalAttrs[attr.name] {
+				continue
+			}
+			if attr.name == "rel" && p.noFollow && name == "a" {
+				continue
+			}
+			if urlAttrs[attr.name] && !p.allowedURL(attr.value) {
+				continue
+			}
+			hasHref = hasHref || attr.name == "href"
+			sb.WriteString(" " + attr.name + `="` + html.EscapeString(attr.value) + `"`)
+		}
+		if name == "a" && hasHref && p.noFollow {
+			sb.WriteString(` rel="nofollow"`)
+		}
+		sb.WriteString(">")
+	}
+
+	if !rawTextElements[name] {
+		return s
+	}
+
+	// The content of raw text elements ends with their end tag
+	end := indexFold(s, "</"+name)
+	content := s
+	if end < 0 {
+		s = ""
+	} else {
+		content = s[:end]
+		s = skipPast(s[end:], ">")
+	}
+	if allowed {
+		writeText(sb, content)
+		sb.WriteString("</" + name + ">")
+	}
+	return s
+}
+
+// allowedURL returns true if the URL is relative or has an allowed scheme.
+func (p *Policy) allowedURL(value string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || p.schemes[strings.ToLower(u.Scheme)]
+}
+
+type attr struct {
+	name  string
+	value string
+}
+
+// readAttrs reads the attributes of a start tag up to and including its ">", and returns them with the rest of s, or
+// false if the tag has no ">". Attribute values are unescaped.
+func readAttrs(s string) ([]attr, string, bool) {
+	var attrs []attr
+	for {
+		s = strings.TrimLeft(s, " \t\n\r\f/")
+		if s == "" {
+			return nil, "", false
+		}
+		if s[0] == '>' {
+			return attrs, s[1:], true
+		}
+
+		i := strings.IndexAny(s, " \t\n\r\f/=>")
+		if i < 0 {
+			return nil, "", false
+		}
+		if i == 0 {
+			// A stray "=", which is not the start of an attribute name
+			s = s[1:]
+			continue
+		}
+		a := attr{name: strings.ToLower(s[:i])}
+		s = strings.TrimLeft(s[i:], " \t\n\r\f")
+
+		if strings.HasPrefix(s, "=") {
+			s = strings.TrimLeft(s[1:], " \t\n\r\f")
+			var value string
+			if s != "" && (s[0] == '"' || s[0] == '\'') {
+				end := strings.IndexByte(s[1:], s[0])
+				if end < 0 {
+					return nil, "", false
+				}
+				value, s = s[1:end+1], s[end+2:]
+			} else {
+				end := strings.IndexAny(s, " \t\n\r\f>")
+				if end < 0 {
+					end = len(s)
+				}
+				value, s = s[:end], s[end:]
+			}
+			a.value = html.UnescapeString(value)
+		}
+
+		attrs = append(attrs, a)
+	}
+}
+
+// readName returns the lowercased tag name at the beginning of s and the rest of s.
+func readName(s string) (string, string) {
+	i := 0
+	for i < len(s) && (isLetter(s[i]) || s[i] >= '0' && s[i] <= '9' || s[i] == '-') {
+		i++
+	}
+	return strings.ToLower(s[:i]), s[i:]
+}
+
+// writeText writes the text with its character references normalized and the special characters escaped.
+func writeText(sb *strings.Builder, text string) {
+	sb.WriteString(html.EscapeString(html.UnescapeString(text)))
+}
+
+// skipPast returns the rest of s after the first occurrence of sep, or an empty string if there is none.
+func skipPast(s, sep string) string {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[i+len(sep):]
+	}
+	return ""
+}
+
+// indexFold returns the index of the first case-insensitive occurrence of the ASCII substr in s, or -1.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
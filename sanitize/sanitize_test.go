@@ -0,0 +1,86 @@
+package sanitize_test
+
+import (
+	"testing"
+
+	"github.com/hypergopher/hyperview/sanitize"
+)
+
+func TestUGCPolicy(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"text", "Hello & welcome", "Hello &amp; welcome"},
+		{"formatting", "<p>Some <b>bold</b> and <EM>em</EM></p>", "<p>Some <b>bold</b> and <em>em</em></p>"},
+		{"event handler", `<p onclick="steal()" class="x">Hi</p>`, "<p>Hi</p>"},
+		{"script", "a<script>alert('<b>x</b>')</script>b", "ab"},
+		{"script without end tag", "a<script>alert(1)", "a"},
+		{"style", "<style>body{display:none}</style>text", "text"},
+		{"unknown element keeps text", "<custom-el>text</custom-el>", "text"},
+		{"comment", "a<!-- <script>alert(1)</script> -->b", "ab"},
+		{"doctype", "<!DOCTYPE html>text", "text"},
+		{"link", `<a href="https://example.com/?a=1&amp;b=2" title="Ex">x</a>`, `<a href="https://example.com/?a=1&amp;b=2" title="Ex" rel="nofollow">x</a>`},
+		{"relative link", `<a href="/about">x</a>`, `<a href="/about" rel="nofollow">x</a>`},
+		{"rel is replaced", `<a href="/about" rel="noopener">x</a>`, `<a href="/about" rel="nofollow">x</a>`},
+		{"javascript url", `<a href="javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"obfuscated javascript url", `<a href="java&#09;script:alert(1)">x</a>`, "<a>x</a>"},
+		{"uppercase javascript url", `<a href="JAVASCRIPT:alert(1)">x</a>`, "<a>x</a>"},
+		{"data url image", `<img src="data:image/png;base64,AAAA" alt="x">`, `<img alt="x">`},
+		{"image", `<img src="/cat.png" alt='A "cat"' onerror=alert(1)>`, `<img src="/cat.png" alt="A &#34;cat&#34;">`},
+		{"self-closing", "line<br/>break", "line<br>break"},
+		{"void end tag", "a</br>b", "ab"},
+		{"unquoted attribute", "<td colspan=2 rowspan=x>c</td>", `<td colspan="2" rowspan="x">c</td>`},
+		{"attribute breaking out", `<img alt="x" " onerror="alert(1)">`, `<img alt="x">`},
+		{"lone less-than", "1 < 2 and 3 <4", "1 &lt; 2 and 3 &lt;4"},
+		{"unterminated tag", `<b>x</b><img src="/a.png`, "<b>x</b>"},
+		{"iframe", `<iframe src="https://evil.example"></iframe>ok`, "ok"},
+		{"textarea content", "<textarea></textarea><script>alert(1)</script></textarea>", ""},
+		{"entities", "&lt;script&gt; &copy;", "&lt;script&gt; ©"},
+	}
+
+	policy := sanitize.UGCPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictPolicy(t *testing.T) {
+	got := sanitize.StrictPolicy().Sanitize(`<h1 class="x">Title <i>here</i></h1><script>x</script>`)
+	if want := "Title here"; got != want {
+		t.Errorf("Sanitize() = %q, want %q", got, want)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	policy := sanitize.NewPolicy().
+		AllowElements("P").
+		AllowAttrs("a", "href").
+		AllowGlobalAttrs("class", "lang").
+		AllowURLSchemes("tel")
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"global attributes", `<p class="lead" lang="en" id="x">Hi</p>`, `<p class="lead" lang="en">Hi</p>`},
+		{"global attributes on allowed elements only", `<div class="x">Hi</div>`, "Hi"},
+		{"allowed scheme", `<a href="tel:+123">call</a>`, `<a href="tel:+123">call</a>`},
+		{"no nofollow", `<a href="https://example.com">x</a>`, `<a href="https://example.com">x</a>`},
+		{"other scheme", `<a href="ftp://example.com">x</a>`, "<a>x</a>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
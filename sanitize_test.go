@@ -0,0 +1,44 @@
+package hyperview_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+	"github.com/hypergopher/hyperview/sanitize"
+)
+
+func TestWithSanitizer(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":  {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/comment.html": {Data: []byte(`{{define "page:main"}}{{.Body | sanitizeHTML}}{{end}}`)},
+	}
+	body := `<p class="lead">Hi <img src="/cat.png"><script>alert(1)</script></p>`
+
+	tests := []struct {
+		name string
+		opts []hyperview.Option
+		want string
+	}{
+		{"default policy", nil, `<p>Hi <img src="/cat.png"></p>`},
+		{"custom policy", []hyperview.Option{hyperview.WithSanitizer(sanitize.NewPolicy().AllowAttrs("p", "class"))}, `<p class="lead">Hi </p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append(tt.opts, hyperview.WithTemplateFS(fsys))...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, httptest.NewRequest("GET", "/", nil), response.NewResponse().Path("comment").Data(map[string]any{"Body": body}))
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package hyperview
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hypergopher/hyperview/constants"
+	"github.com/hypergopher/hyperview/response"
+)
+
+// WithSessionProvider sets the reader of session values, so that templates can read the current user, preferences,
+// and other session values with .View.Session (see Data.Session) regardless of the session library.
+func WithSessionProvider(reader response.SessionReader) Option {
+	return func(hgo *HyperView) error {
+		hgo.session = reader
+		return nil
+	}
+}
+
+// withSession stores the session reader in the request context, if a session provider is configured.
+func (s *HyperView) withSession(r *http.Request) *http.Request {
+	if s.session == nil {
+		return r
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), constants.SessionContextKey, s.session))
+}
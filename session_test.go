@@ -0,0 +1,58 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithSessionProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{with .View.Session "user"}}{{.}}: {{end}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Home{{end}}`)},
+	}
+
+	sessions := map[string]map[string]any{"abc": {"user": "Gopher"}}
+	reader := response.SessionReaderFunc(func(r *http.Request, key string) any {
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			return nil
+		}
+		return sessions[cookie.Value][key]
+	})
+
+	tests := []struct {
+		name     string
+		opts     []hyperview.Option
+		cookie   string
+		wantBody string
+	}{
+		{"no provider", nil, "abc", "Home"},
+		{"session", []hyperview.Option{hyperview.WithSessionProvider(reader)}, "abc", "Gopher: Home"},
+		{"no session", []hyperview.Option{hyperview.WithSessionProvider(reader)}, "", "Home"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hv, err := hyperview.NewHyperView(append([]hyperview.Option{hyperview.WithTemplateFS(fsys), hyperview.WithLayouts("base", "base")}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "session", Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path("home"))
+
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package hyperview
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxSitemapURLs is the maximum number of URLs in a single sitemap file, as defined by the sitemaps protocol.
+const MaxSitemapURLs = 50000
+
+// sitemapPageParam is the query parameter that selects a sitemap file of a sitemap index.
+const sitemapPageParam = "page"
+
+// sitemapNamespace is the XML namespace of the sitemaps protocol.
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Change frequencies of sitemap entries.
+const (
+	ChangeFreqAlways  = "always"
+	ChangeFreqHourly  = "hourly"
+	ChangeFreqDaily   = "daily"
+	ChangeFreqWeekly  = "weekly"
+	ChangeFreqMonthly = "monthly"
+	ChangeFreqYearly  = "yearly"
+	ChangeFreqNever   = "never"
+)
+
+// SitemapEntry is a URL entry of a sitemap. Only Loc is required.
+type SitemapEntry struct {
+	// Loc is the absolute URL of the page.
+	Loc string
+	// LastMod is the date of the last modification of the page.
+	LastMod time.Time
+	// ChangeFreq is how frequently the page is likely to change, e.g. ChangeFreqDaily.
+	ChangeFreq string
+	// Priority is the priority of the page relative to other pages of the site, from 0.0 to 1.0.
+	// A zero priority is omitted, so that the default priority of 0.5 applies.
+	Priority float64
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name         `xml:"sitemapindex"`
+	XMLNS    string           `xml:"xmlns,attr"`
+	Sitemaps []sitemapLocator `xml:"sitemap"`
+}
+
+type sitemapLocator struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// RenderSitemap renders a sitemap.xml for the given entries.
+//
+// If there are more than MaxSitemapURLs entries, a sitemap index is rendered instead, which links to the sitemap
+// files at the request URL with a "page" query parameter (e.g. /sitemap.xml?page=2). Requests with a page parameter
+// render the entries of that page, so the same handler serves both the index and the sitemap files.
+func (s *HyperView) RenderSitemap(w http.ResponseWriter, r *http.Request, entries []SitemapEntry) {
+	pages := (len(entries) + MaxSitemapURLs - 1) / MaxSitemapURLs
+
+	var doc any
+	if p := r.URL.Query().Get(sitemapPageParam); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 1 || page > max(pages, 1) {
+			s.RenderNotFound(w, r)
+			return
+		}
+
+		start := (page - 1) * MaxSitemapURLs
+		doc = newSitemapURLSet(entries[start:min(start+MaxSitemapURLs, len(entries))])
+	} else if pages > 1 {
+		doc = newSitemapIndex(r, entries, pages)
+	} else {
+		doc = newSitemapURLSet(entries)
+	}
+
+	out, err := xml.Marshal(doc)
+	if err != nil {
+		s.RenderSystemError(w, r, fmt.Errorf("error encoding sitemap: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(out)
+}
+
+func newSitemapURLSet(entries []SitemapEntry) sitemapURLSet {
+	set := sitemapURLSet{XMLNS: sitemapNamespace, URLs: make([]sitemapURL, 0, len(entries))}
+	for _, entry := range entries {
+		u := sitemapURL{Loc: entry.Loc, ChangeFreq: entry.ChangeFreq}
+		if !entry.LastMod.IsZero() {
+			u.LastMod = entry.LastMod.UTC().Format(time.RFC3339)
+		}
+		if entry.Priority > 0 {
+			u.Priority = strconv.FormatFloat(min(entry.Priority, 1), 'f', 1, 64)
+		}
+		set.URLs = append(set.URLs, u)
+	}
+	return set
+}
+
+// newSitemapIndex returns a sitemap index that links to each page of the entries. The last modification date of a
+// sitemap file is the latest last modification date of its entries.
+func newSitemapIndex(r *http.Request, entries []SitemapEntry, pages int) sitemapIndex {
+	scheme := "https"
+	if r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+
+	index := sitemapIndex{XMLNS: sitemapNamespace, Sitemaps: make([]sitemapLocator, 0, pages)}
+	for page := 1; page <= pages; page++ {
+		u := url.URL{Scheme: scheme, Host: r.Host, Path: r.URL.Path, RawQuery: sitemapPageParam + "=" + strconv.Itoa(page)}
+
+		var lastMod time.Time
+		start := (page - 1) * MaxSitemapURLs
+		for _, entry := range entries[start:min(start+MaxSitemapURLs, len(entries))] {
+			if entry.LastMod.After(lastMod) {
+				lastMod = entry.LastMod
+			}
+		}
+
+		locator := sitemapLocator{Loc: u.String()}
+		if !lastMod.IsZero() {
+			locator.LastMod = lastMod.UTC().Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, locator)
+	}
+	return index
+}
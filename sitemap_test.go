@@ -0,0 +1,98 @@
+package hyperview_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+)
+
+func TestHyperView_RenderSitemap(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	entries := []hyperview.SitemapEntry{
+		{
+			Loc:        "https://example.com/",
+			LastMod:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ChangeFreq: hyperview.ChangeFreqDaily,
+			Priority:   1,
+		},
+		{Loc: "https://example.com/about?a=1&b=2"},
+	}
+
+	w := httptest.NewRecorder()
+	hv.RenderSitemap(w, httptest.NewRequest("GET", "/sitemap.xml", nil), entries)
+
+	if got := w.Header().Get("Content-Type"); got != "application/xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want application/xml; charset=utf-8", got)
+	}
+
+	want := xml.Header + `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+		`<url><loc>https://example.com/</loc><lastmod>2024-01-02T03:04:05Z</lastmod><changefreq>daily</changefreq><priority>1.0</priority></url>` +
+		`<url><loc>https://example.com/about?a=1&amp;b=2</loc></url>` +
+		`</urlset>`
+	if got := w.Body.String(); got != want {
+		t.Errorf("RenderSitemap() = %q, want %q", got, want)
+	}
+}
+
+func TestHyperView_RenderSitemapIndex(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("error creating HyperView: %v", err)
+	}
+
+	entries := make([]hyperview.SitemapEntry, hyperview.MaxSitemapURLs+1)
+	for i := range entries {
+		entries[i] = hyperview.SitemapEntry{Loc: fmt.Sprintf("https://example.com/%d", i)}
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantCount  int
+		wantPrefix string
+	}{
+		{name: "index", target: "/sitemap.xml", wantStatus: http.StatusOK, wantCount: 2, wantPrefix: "<sitemapindex"},
+		{name: "first page", target: "/sitemap.xml?page=1", wantStatus: http.StatusOK, wantCount: hyperview.MaxSitemapURLs, wantPrefix: "<urlset"},
+		{name: "last page", target: "/sitemap.xml?page=2", wantStatus: http.StatusOK, wantCount: 1, wantPrefix: "<urlset"},
+		{name: "out of range", target: "/sitemap.xml?page=3", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hv.RenderSitemap(w, httptest.NewRequest("GET", tt.target, nil), entries)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			body := strings.TrimPrefix(w.Body.String(), xml.Header)
+			if !strings.HasPrefix(body, tt.wantPrefix) {
+				t.Errorf("RenderSitemap() = %.40q, want prefix %q", body, tt.wantPrefix)
+			}
+			if got := strings.Count(body, "<loc>"); got != tt.wantCount {
+				t.Errorf("RenderSitemap() has %d locations, want %d", got, tt.wantCount)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	hv.RenderSitemap(w, httptest.NewRequest("GET", "http://example.com/sitemap.xml", nil), entries)
+	if !strings.Contains(w.Body.String(), "<loc>http://example.com/sitemap.xml?page=2</loc>") {
+		t.Errorf("RenderSitemap() index does not link to the second page: %s", w.Body.String())
+	}
+}
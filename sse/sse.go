@@ -0,0 +1,268 @@
+// Package sse provides a Server-Sent Events stream for use with the htmx SSE extension
+// (https://htmx.org/extensions/sse/) or any other EventSource client.
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// ErrClosed is returned when writing to a stream that was closed or whose client disconnected.
+var ErrClosed = errors.New("sse: stream closed")
+
+// Event is a single Server-Sent Event. Only Data is required.
+type Event struct {
+	// ID is the event ID, which the client sends back in the Last-Event-ID header when reconnecting.
+	ID string
+	// Event is the event name. With the htmx SSE extension, this is the name used in sse-swap.
+	Event string
+	// Data is the event payload. Multi-line data is sent as multiple data lines.
+	Data string
+	// Retry is the reconnection time the client should use if the connection is lost.
+	Retry time.Duration
+}
+
+// Renderer renders a response to a string. Both hyperview.HyperView and hyperview.TemplateAdapter implement it.
+type Renderer interface {
+	RenderToString(r *http.Request, resp *response.Response) (string, error)
+}
+
+// Option configures a Stream.
+type Option func(*Stream)
+
+// WithHeartbeat sends a comment to the client at the given interval, which keeps the connection open through
+// proxies that close idle connections and detects disconnected clients early.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(s *Stream) {
+		s.heartbeat = interval
+	}
+}
+
+// WithRetry sets the reconnection time of the client when the stream is opened.
+func WithRetry(retry time.Duration) Option {
+	return func(s *Stream) {
+		s.retry = retry
+	}
+}
+
+// Stream writes Server-Sent Events to a client. It is safe for concurrent use.
+type Stream struct {
+	w         http.ResponseWriter
+	r         *http.Request
+	rc        *http.ResponseController
+	heartbeat time.Duration
+	retry     time.Duration
+	mu        sync.Mutex    // serializes writes to the client
+	done      chan struct{} // closed when the stream is closed
+	closeOnce sync.Once
+	err       error // first write error
+}
+
+// NewStream starts a Server-Sent Events stream for the request. It writes the event stream headers and flushes
+// them to the client. The stream ends when the client disconnects (see Stream.Done) or Stream.Close is called.
+// The handler should return once the stream is done.
+//
+// Example:
+//
+//	stream, err := sse.NewStream(w, r, sse.WithHeartbeat(15*time.Second))
+//	if err != nil {
+//		hv.RenderSystemError(w, r, err)
+//		return
+//	}
+//	defer stream.Close()
+//
+//	for {
+//		select {
+//		case <-stream.Done():
+//			return
+//		case msg := <-messages:
+//			_ = stream.Render("message", hv, response.NewResponse().Path("chat").Fragment("message").Data(msg))
+//		}
+//	}
+func NewStream(w http.ResponseWriter, r *http.Request, opts ...Option) (*Stream, error) {
+	s := &Stream{
+		w:    w,
+		r:    r,
+		rc:   http.NewResponseController(w),
+		done: make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Check before writing the header, so that the caller can still render an error response
+	if !canFlush(w) {
+		return nil, fmt.Errorf("sse: streaming not supported: %w", http.ErrNotSupported)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if s.retry > 0 {
+		_, _ = fmt.Fprintf(w, "retry: %d\n\n", s.retry.Milliseconds())
+	}
+
+	if err := s.rc.Flush(); err != nil {
+		return nil, fmt.Errorf("sse: streaming not supported: %w", err)
+	}
+
+	go s.watch()
+
+	return s, nil
+}
+
+// canFlush returns true if the writer, or a writer it wraps (see http.ResponseController), can flush.
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case http.Flusher, interface{ FlushError() error }:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
+
+// watch closes the stream when the client disconnects and sends heartbeats, if enabled.
+func (s *Stream) watch() {
+	var tick <-chan time.Time
+	if s.heartbeat > 0 {
+		ticker := time.NewTicker(s.heartbeat)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.r.Context().Done():
+			s.Close()
+			return
+		case <-tick:
+			_ = s.Comment("heartbeat")
+		}
+	}
+}
+
+// Done returns a channel that is closed when the stream is closed or the client disconnects.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close closes the stream. Further writes return ErrClosed. It does not close the underlying connection, which is
+// closed by the server once the handler returns.
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		// Wait for any write in progress, so that nothing is written once Close returns
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		close(s.done)
+	})
+}
+
+// Err returns the first error that occurred while writing to the client, if any.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Send writes the event to the client and flushes it.
+func (s *Stream) Send(event Event) error {
+	var b strings.Builder
+	if event.ID != "" {
+		b.WriteString("id: " + singleLine(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + singleLine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		_, _ = fmt.Fprintf(&b, "retry: %d\n", event.Retry.Milliseconds())
+	}
+	for _, line := range strings.Split(normalizeNewlines(event.Data), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// SendData writes an unnamed event with the given data to the client.
+func (s *Stream) SendData(data string) error {
+	return s.Send(Event{Data: data})
+}
+
+// Comment writes a comment to the client, which is ignored by EventSource clients.
+func (s *Stream) Comment(text string) error {
+	var b strings.Builder
+	for _, line := range strings.Split(normalizeNewlines(text), "\n") {
+		b.WriteString(": " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// Render renders the response (typically a template fragment, see response.Response.Fragment) with the renderer
+// and sends the output as the data of the named event. With the htmx SSE extension, the output is swapped into
+// the elements that listen for the event with sse-swap.
+func (s *Stream) Render(event string, renderer Renderer, resp *response.Response) error {
+	data, err := renderer.RenderToString(s.r, resp)
+	if err != nil {
+		return err
+	}
+
+	return s.Send(Event{Event: event, Data: data})
+}
+
+// write writes the raw event to the client and flushes it.
+func (s *Stream) write(raw string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return ErrClosed
+	default:
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if _, err := io.WriteString(s.w, raw); err != nil {
+		s.err = err
+		return err
+	}
+
+	if err := s.rc.Flush(); err != nil {
+		s.err = err
+		return err
+	}
+
+	return nil
+}
+
+// singleLine replaces newlines in field values, which would otherwise end the field.
+func singleLine(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
+
+// normalizeNewlines converts CRLF and CR line endings to LF.
+func normalizeNewlines(value string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(value)
+}
@@ -0,0 +1,119 @@
+package sse_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+	"github.com/hypergopher/hyperview/sse"
+)
+
+type stubRenderer struct{}
+
+func (stubRenderer) RenderToString(_ *http.Request, resp *response.Response) (string, error) {
+	return "<li>\n" + resp.TemplateFragment() + "\n</li>", nil
+}
+
+func TestStream_Send(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(s *sse.Stream) error
+		want  string
+	}{
+		{
+			name:  "data",
+			write: func(s *sse.Stream) error { return s.SendData("hello") },
+			want:  "data: hello\n\n",
+		},
+		{
+			name: "all fields",
+			write: func(s *sse.Stream) error {
+				return s.Send(sse.Event{ID: "1", Event: "update", Data: "a\r\nb", Retry: 3 * time.Second})
+			},
+			want: "id: 1\nevent: update\nretry: 3000\ndata: a\ndata: b\n\n",
+		},
+		{
+			name:  "comment",
+			write: func(s *sse.Stream) error { return s.Comment("ping") },
+			want:  ": ping\n\n",
+		},
+		{
+			name: "render",
+			write: func(s *sse.Stream) error {
+				return s.Render("message", stubRenderer{}, response.NewResponse().Fragment("row"))
+			},
+			want: "event: message\ndata: <li>\ndata: row\ndata: </li>\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s, err := sse.NewStream(w, httptest.NewRequest("GET", "/events", nil))
+			if err != nil {
+				t.Fatalf("NewStream() error = %v", err)
+			}
+
+			if err := tt.write(s); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			s.Close()
+
+			if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+				t.Errorf("Content-Type = %q, want text/event-stream", got)
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStream_Disconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest("GET", "/events", nil).WithContext(ctx)
+
+	s, err := sse.NewStream(httptest.NewRecorder(), r, sse.WithHeartbeat(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewStream() error = %v", err)
+	}
+
+	cancel()
+
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed when the client disconnected")
+	}
+
+	if err := s.SendData("late"); !errors.Is(err, sse.ErrClosed) {
+		t.Errorf("SendData() error = %v, want %v", err, sse.ErrClosed)
+	}
+}
+
+// plainWriter is a ResponseWriter that can't flush.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestNewStream_NotSupported(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/events", nil)
+
+	if _, err := sse.NewStream(plainWriter{rec}, r); !errors.Is(err, http.ErrNotSupported) {
+		t.Fatalf("NewStream() error = %v, want http.ErrNotSupported", err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "" {
+		t.Errorf("Content-Type = %q, want no event-stream headers", got)
+	}
+
+	// The caller can still respond with an error
+	http.Error(plainWriter{rec}, "streaming not supported", http.StatusInternalServerError)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
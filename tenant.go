@@ -0,0 +1,105 @@
+package hyperview
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+
+	"github.com/hypergopher/hyperview/constants"
+)
+
+// TenantAdapter is an optional interface for adapters that support tenant-scoped template filesystems.
+type TenantAdapter interface {
+	// AddFS registers a tenant filesystem under the given ID.
+	AddFS(id string, fsys fs.FS) error
+	// RemoveFS removes the tenant filesystem with the given ID.
+	RemoveFS(id string)
+}
+
+// ContextWithTenant returns a copy of the context that carries the given tenant ID. Templates of the tenant are
+// preferred over the root templates when rendering requests with this context.
+func ContextWithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, constants.TenantContextKey, id)
+}
+
+// TenantFromContext returns the tenant ID from the context, if any.
+func TenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(constants.TenantContextKey).(string)
+	return id
+}
+
+// WithTenantResolver sets a function that resolves the tenant ID of a request, e.g. from the request host.
+// The resolved tenant is stored in the request context before rendering, so that "views/home" transparently
+// resolves to "acme:views/home" for requests of the "acme" tenant, if the tenant has its own version of the view.
+func WithTenantResolver(resolver func(r *http.Request) string) Option {
+	return func(hgo *HyperView) error {
+		hgo.tenantResolver = resolver
+		return nil
+	}
+}
+
+// RegisterTenantFS registers a tenant filesystem with all adapters that support tenants. The views of the tenant
+// can be rendered as "id:views/..." or resolved transparently for requests of the tenant. Tenants are kept when
+// the adapters are reinitialized. If an adapter fails to register the tenant, all adapters keep their previous
+// filesystem of the tenant, if any.
+func (s *HyperView) RegisterTenantFS(id string, fsys fs.FS) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var registered []TenantAdapter
+	for name, adapter := range s.adapterMap() {
+		if ta, ok := adapter.(TenantAdapter); ok {
+			if err := ta.AddFS(id, fsys); err != nil {
+				s.rollbackTenantFS(id, registered)
+				return fmt.Errorf("error registering tenant %s with adapter %s: %w", id, name, err)
+			}
+			registered = append(registered, ta)
+		}
+	}
+
+	s.tenants[id] = fsys
+	return nil
+}
+
+// rollbackTenantFS restores the tenant of the adapters that registered it before another adapter failed: the
+// previously registered filesystem of the tenant, if any, or no tenant.
+func (s *HyperView) rollbackTenantFS(id string, registered []TenantAdapter) {
+	previous, ok := s.tenants[id]
+	for _, ta := range registered {
+		ta.RemoveFS(id)
+		if ok {
+			if err := ta.AddFS(id, previous); err != nil {
+				s.logger.Error("Error restoring tenant", slog.String("tenant", id), slog.String("err", err.Error()))
+			}
+		}
+	}
+}
+
+// RemoveTenantFS removes a tenant filesystem and its templates from all adapters that support tenants.
+func (s *HyperView) RemoveTenantFS(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, adapter := range s.adapterMap() {
+		if ta, ok := adapter.(TenantAdapter); ok {
+			ta.RemoveFS(id)
+		}
+	}
+
+	delete(s.tenants, id)
+}
+
+// withTenant stores the tenant resolved for the request in the request context, if a tenant resolver is configured.
+func (s *HyperView) withTenant(r *http.Request) *http.Request {
+	if s.tenantResolver == nil {
+		return r
+	}
+
+	if id := s.tenantResolver(r); id != "" {
+		return r.WithContext(ContextWithTenant(r.Context(), id))
+	}
+
+	return r
+}
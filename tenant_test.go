@@ -0,0 +1,65 @@
+package hyperview_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+)
+
+// tenantAdapter is a mock adapter that keeps the registered tenants, or fails to register them.
+type tenantAdapter struct {
+	mockViewAdapter
+	tenants map[string]fs.FS
+	fail    bool
+}
+
+func (ta *tenantAdapter) AddFS(id string, fsys fs.FS) error {
+	if ta.fail {
+		return errors.New("tenant not supported")
+	}
+	ta.tenants[id] = fsys
+	return nil
+}
+
+func (ta *tenantAdapter) RemoveFS(id string) {
+	delete(ta.tenants, id)
+}
+
+func TestRegisterTenantFS_Rollback(t *testing.T) {
+	hv, err := hyperview.NewHyperView()
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	ok := &tenantAdapter{tenants: map[string]fs.FS{}}
+	failing := &tenantAdapter{tenants: map[string]fs.FS{}}
+	if err := hv.RegisterAdapter("ok", ok); err != nil {
+		t.Fatalf("RegisterAdapter() error = %v", err)
+	}
+	if err := hv.RegisterAdapter("failing", failing); err != nil {
+		t.Fatalf("RegisterAdapter() error = %v", err)
+	}
+
+	// The previous filesystem of a tenant is restored
+	previous := fstest.MapFS{"views/home.html": {Data: []byte("previous")}}
+	if err := hv.RegisterTenantFS("acme", previous); err != nil {
+		t.Fatalf("RegisterTenantFS() error = %v", err)
+	}
+
+	failing.fail = true
+	for _, id := range []string{"acme", "globex"} {
+		if err := hv.RegisterTenantFS(id, fstest.MapFS{}); err == nil {
+			t.Fatalf("RegisterTenantFS(%s) error = nil, want an error", id)
+		}
+	}
+
+	if _, registered := ok.tenants["globex"]; registered {
+		t.Error("tenant globex is registered with an adapter, want it removed after the failure")
+	}
+	if fsys, _ := ok.tenants["acme"].(fstest.MapFS); fsys == nil || string(fsys["views/home.html"].Data) != "previous" {
+		t.Errorf("tenant acme = %v, want the previous filesystem", ok.tenants["acme"])
+	}
+}
@@ -0,0 +1,58 @@
+package hyperview
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hypergopher/hyperview/constants"
+)
+
+// TimezoneResolver returns the time zone of the viewer of the request, e.g. from a cookie, a header set by the client,
+// or the profile of the signed-in user, or nil to render times in UTC. Resolvers are called on every render, so they
+// must be safe for concurrent use.
+type TimezoneResolver func(r *http.Request) *time.Location
+
+// ContextWithLocation returns a copy of the context with the time zone of the viewer, e.g. to set the time zone of a
+// request in a middleware instead of a time zone resolver.
+func ContextWithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, constants.LocationContextKey, loc)
+}
+
+// LocationFromContext returns the time zone of the viewer from the context, or nil if there is none.
+func LocationFromContext(ctx context.Context) *time.Location {
+	loc, _ := ctx.Value(constants.LocationContextKey).(*time.Location)
+	return loc
+}
+
+// WithTimezoneResolver sets the resolver of the time zone of the viewer of the request. The time zone is stored in the
+// request context before rendering, where templates read it with .View.Location and convert times to it with inTZ:
+//
+//	hyperview.WithTimezoneResolver(func(r *http.Request) *time.Location {
+//		if user := auth.User(r); user != nil {
+//			return user.Location
+//		}
+//		return request.LocationFromCookie(r, "tz")
+//	})
+//
+//	{{.CreatedAt | inTZ .View | dateFormat "datetime"}}
+func WithTimezoneResolver(resolver TimezoneResolver) Option {
+	return func(hgo *HyperView) error {
+		hgo.timezones = resolver
+		return nil
+	}
+}
+
+// withTimezoneContext stores the time zone resolved for the request in the request context, if a time zone resolver
+// is configured and the context has no time zone yet.
+func (s *HyperView) withTimezoneContext(r *http.Request) *http.Request {
+	if s.timezones == nil || LocationFromContext(r.Context()) != nil {
+		return r
+	}
+
+	if loc := s.timezones(r); loc != nil {
+		return r.WithContext(ContextWithLocation(r.Context(), loc))
+	}
+
+	return r
+}
@@ -0,0 +1,63 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithTimezoneResolver(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/event.html":  {Data: []byte(`{{define "page:main"}}{{.View.Location}} {{.Start | inTZ .View | dateFormat "2006-01-02 15:04"}}{{end}}`)},
+	}
+
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithTimezoneResolver(func(r *http.Request) *time.Location {
+			return request.LocationFromCookie(r, "tz")
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	start := time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cookie string
+		ctxLoc string
+		want   string
+	}{
+		{"cookie", "Asia/Tokyo", "", "Asia/Tokyo 2026-01-01 08:30"},
+		{"unknown time zone", "Nowhere/Special", "", "UTC 2025-12-31 23:30"},
+		{"no time zone", "", "", "UTC 2025-12-31 23:30"},
+		{"context wins", "Asia/Tokyo", "America/New_York", "America/New_York 2025-12-31 18:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "tz", Value: tt.cookie})
+			}
+			if tt.ctxLoc != "" {
+				r = r.WithContext(hyperview.ContextWithLocation(r.Context(), request.LoadLocation(tt.ctxLoc)))
+			}
+
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path("event").Data(map[string]any{"Start": start}))
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package tracing traces the renders of a HyperView with OpenTelemetry. Each render is recorded as a span with the
+// adapter, template path, layout, and status code of the response, and template errors are recorded on the span:
+//
+//	hv, err := hyperview.NewHyperView(hyperview.WithRenderTracer(tracing.NewTracer()))
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "github.com/hypergopher/hyperview/tracing"
+
+// SpanName is the name of render spans.
+const SpanName = "hyperview.render"
+
+// Attribute keys of render spans.
+const (
+	AdapterKey    = attribute.Key("hyperview.adapter")
+	TemplateKey   = attribute.Key("hyperview.template")
+	LayoutKey     = attribute.Key("hyperview.layout")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Tracer is a hyperview.RenderTracer that starts an OpenTelemetry span for each render.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// Option configures a Tracer.
+type Option func(*config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider sets the tracer provider. Default is the global tracer provider (see otel.GetTracerProvider).
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// NewTracer creates a new Tracer.
+func NewTracer(opts ...Option) *Tracer {
+	c := config{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.provider == nil {
+		c.provider = otel.GetTracerProvider()
+	}
+
+	return &Tracer{tracer: c.provider.Tracer(ScopeName)}
+}
+
+// StartRender starts a span for the render as a child of the span in the context, if any. Spans started while
+// rendering, e.g. by template functions that load data, are children of the render span.
+func (t *Tracer) StartRender(ctx context.Context, adapter string, resp *response.Response) (context.Context, func(status int, err error)) {
+	ctx, span := t.tracer.Start(ctx, SpanName,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			AdapterKey.String(adapter),
+			TemplateKey.String(resp.TemplatePath()),
+			LayoutKey.String(resp.TemplateLayout()),
+		),
+	)
+
+	return ctx, func(status int, err error) {
+		span.SetAttributes(StatusCodeKey.Int(status))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		span.End()
+	}
+}
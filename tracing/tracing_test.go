@@ -0,0 +1,85 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+	"github.com/hypergopher/hyperview/tracing"
+)
+
+func TestTracer(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/home.html":   {Data: []byte(`{{define "page:main"}}Hello{{end}}`)},
+		"views/broken.html": {Data: []byte(`{{define "page:main"}}{{template "missing" .}}{{end}}`)},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCode   codes.Code
+		wantEvents int
+	}{
+		{"success", "views/home", http.StatusOK, codes.Unset, 0},
+		{"template error", "views/broken", http.StatusInternalServerError, codes.Error, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			hv, err := hyperview.NewHyperView(
+				hyperview.WithTemplateFS(fsys),
+				hyperview.WithRenderTracer(tracing.NewTracer(tracing.WithTracerProvider(provider))),
+			)
+			if err != nil {
+				t.Fatalf("NewHyperView() error = %v", err)
+			}
+
+			hv.Render(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil), response.NewResponse().Path(tt.path))
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+
+			if span.Name() != tracing.SpanName {
+				t.Errorf("span name = %q, want %q", span.Name(), tracing.SpanName)
+			}
+
+			attrs := map[string]any{}
+			for _, attr := range span.Attributes() {
+				attrs[string(attr.Key)] = attr.Value.AsInterface()
+			}
+			want := map[string]any{
+				string(tracing.AdapterKey):    "html",
+				string(tracing.TemplateKey):   tt.path,
+				string(tracing.LayoutKey):     "base",
+				string(tracing.StatusCodeKey): int64(tt.wantStatus),
+			}
+			for k, v := range want {
+				if attrs[k] != v {
+					t.Errorf("attribute %s = %v, want %v", k, attrs[k], v)
+				}
+			}
+
+			if span.Status().Code != tt.wantCode {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantCode)
+			}
+			if len(span.Events()) != tt.wantEvents {
+				t.Errorf("span events = %d, want %d", len(span.Events()), tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package unpoly
+
+import "encoding/json"
+
+// Event is an event emitted on the document with the X-Up-Events header.
+// See https://unpoly.com/X-Up-Events for more information.
+type Event map[string]any
+
+// NewEvent creates a new Event with the given type and properties
+func NewEvent(eventType string, props map[string]any) Event {
+	event := Event{}
+	for key, value := range props {
+		event[key] = value
+	}
+	event["type"] = eventType
+	return event
+}
+
+// EncodeEvents encodes the events as a JSON array for the X-Up-Events header
+func EncodeEvents(events []Event) (string, error) {
+	b, err := json.Marshal(events)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,59 @@
+package unpoly
+
+// Unpoly Request Headers
+const (
+	// XUpContext is the context of the layer targeted by the request, as a JSON object
+	XUpContext = "X-Up-Context"
+
+	// XUpFailContext is the context of the layer targeted for a failed response, as a JSON object
+	XUpFailContext = "X-Up-Fail-Context"
+
+	// XUpFailMode is the mode of the layer targeted for a failed response
+	XUpFailMode = "X-Up-Fail-Mode"
+
+	// XUpFailTarget is the CSS selector of the fragment that is updated for a failed response
+	XUpFailTarget = "X-Up-Fail-Target"
+
+	// XUpMode is the mode of the layer targeted by the request, e.g. "root" or "modal"
+	XUpMode = "X-Up-Mode"
+
+	// XUpValidate is the names of the form fields being validated, separated by spaces
+	XUpValidate = "X-Up-Validate"
+
+	// XUpVersion is the version of Unpoly that sent the request. It is sent with every Unpoly request.
+	XUpVersion = "X-Up-Version"
+)
+
+// Unpoly Response Headers
+const (
+	// XUpAcceptLayer accepts the current overlay with the given JSON value
+	XUpAcceptLayer = "X-Up-Accept-Layer"
+
+	// XUpDismissLayer dismisses the current overlay with the given JSON value
+	XUpDismissLayer = "X-Up-Dismiss-Layer"
+
+	// XUpEvents emits the given JSON array of events on the document
+	XUpEvents = "X-Up-Events"
+
+	// XUpEvictCache evicts the cached responses matching the given URL pattern
+	XUpEvictCache = "X-Up-Evict-Cache"
+
+	// XUpExpireCache expires the cached responses matching the given URL pattern
+	XUpExpireCache = "X-Up-Expire-Cache"
+
+	// XUpLocation is the URL of the response, e.g. after a redirect
+	XUpLocation = "X-Up-Location"
+
+	// XUpMethod is the HTTP method of the response, e.g. after a redirect
+	XUpMethod = "X-Up-Method"
+
+	// XUpTitle sets the document title as a JSON string
+	XUpTitle = "X-Up-Title"
+)
+
+// Dual-use Headers (request and response)
+const (
+	// XUpTarget is the CSS selector of the fragment that is updated when used in a request. As a response header,
+	// it changes the fragment that is updated.
+	XUpTarget = "X-Up-Target"
+)
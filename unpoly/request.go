@@ -0,0 +1,72 @@
+package unpoly
+
+import (
+	"net/http"
+	"strings"
+)
+
+// IsUnpolyRequest returns true if the current request was sent by Unpoly, which sends the X-Up-Version header
+// with every request.
+func IsUnpolyRequest(r *http.Request) bool {
+	return r.Header.Get(XUpVersion) != ""
+}
+
+// IsValidationRequest returns true if the current request is an Unpoly form validation request (up-validate)
+func IsValidationRequest(r *http.Request) bool {
+	_, ok := r.Header[http.CanonicalHeaderKey(XUpValidate)]
+	return ok
+}
+
+// Target returns the X-Up-Target header, if it exists
+func Target(r *http.Request) (string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpTarget)]; !ok {
+		return "", false
+	}
+
+	return r.Header.Get(XUpTarget), true
+}
+
+// FailTarget returns the X-Up-Fail-Target header, if it exists
+func FailTarget(r *http.Request) (string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpFailTarget)]; !ok {
+		return "", false
+	}
+
+	return r.Header.Get(XUpFailTarget), true
+}
+
+// Mode returns the X-Up-Mode header, if it exists
+func Mode(r *http.Request) (string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpMode)]; !ok {
+		return "", false
+	}
+
+	return r.Header.Get(XUpMode), true
+}
+
+// Context returns the X-Up-Context header (a JSON object), if it exists
+func Context(r *http.Request) (string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpContext)]; !ok {
+		return "", false
+	}
+
+	return r.Header.Get(XUpContext), true
+}
+
+// Validate returns the names of the form fields being validated from the X-Up-Validate header, if it exists
+func Validate(r *http.Request) ([]string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpValidate)]; !ok {
+		return nil, false
+	}
+
+	return strings.Fields(r.Header.Get(XUpValidate)), true
+}
+
+// Version returns the X-Up-Version header, if it exists
+func Version(r *http.Request) (string, bool) {
+	if _, ok := r.Header[http.CanonicalHeaderKey(XUpVersion)]; !ok {
+		return "", false
+	}
+
+	return r.Header.Get(XUpVersion), true
+}
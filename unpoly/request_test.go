@@ -0,0 +1,55 @@
+package unpoly_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hypergopher/hyperview/unpoly"
+)
+
+func TestRequestHeaders(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users", nil)
+	r.Header.Set(unpoly.XUpVersion, "3.9.0")
+	r.Header.Set(unpoly.XUpTarget, ".users")
+	r.Header.Set(unpoly.XUpFailTarget, "form")
+	r.Header.Set(unpoly.XUpMode, "modal")
+	r.Header.Set(unpoly.XUpContext, `{"lives":3}`)
+	r.Header.Set(unpoly.XUpValidate, "email password")
+
+	tests := []struct {
+		name   string
+		method func(*http.Request) (string, bool)
+		want   string
+	}{
+		{name: "Target", method: unpoly.Target, want: ".users"},
+		{name: "FailTarget", method: unpoly.FailTarget, want: "form"},
+		{name: "Mode", method: unpoly.Mode, want: "modal"},
+		{name: "Context", method: unpoly.Context, want: `{"lives":3}`},
+		{name: "Version", method: unpoly.Version, want: "3.9.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.method(r)
+			if !ok || got != tt.want {
+				t.Errorf("%s() = %q, %t, want %q, true", tt.name, got, ok, tt.want)
+			}
+
+			if _, ok := tt.method(httptest.NewRequest("GET", "/", nil)); ok {
+				t.Errorf("%s() on a request without the header = true, want false", tt.name)
+			}
+		})
+	}
+
+	if !unpoly.IsUnpolyRequest(r) {
+		t.Error("IsUnpolyRequest() = false, want true")
+	}
+	if !unpoly.IsValidationRequest(r) {
+		t.Error("IsValidationRequest() = false, want true")
+	}
+	if fields, _ := unpoly.Validate(r); !reflect.DeepEqual(fields, []string{"email", "password"}) {
+		t.Errorf("Validate() = %v, want [email password]", fields)
+	}
+}
@@ -0,0 +1,139 @@
+// Package validation converts validation errors into the field errors of the view data (see response.Data.AddErrors),
+// so that they are rendered next to their form fields, e.g. as the error attribute of InputAttrs.
+//
+// It supports the errors of github.com/go-playground/validator without depending on it, through the FieldError
+// interface that its field errors implement, and any error implementing FieldErrorer:
+//
+//	validate := validator.New()
+//	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+//		return field.Tag.Get("form")
+//	})
+//
+//	if err := validate.Struct(form); err != nil {
+//		return resp.Errors("Please fix the errors below", validation.Errors(err)), nil
+//	}
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/hypergopher/hyperview/funcs"
+)
+
+// FieldError is the validation error of a single field, implemented by the field errors of go-playground/validator.
+type FieldError interface {
+	Field() string // name of the field, used as the key of the field errors
+	Tag() string   // name of the failed rule, e.g. "required" or "min"
+	Param() string // parameter of the failed rule, e.g. "8" for min=8
+}
+
+// FieldErrorer is implemented by errors that already hold messages by field name, such as request.JSONError.
+type FieldErrorer interface {
+	FieldErrors() map[string]string
+}
+
+// Option configures Errors.
+type Option func(*config)
+
+type config struct {
+	messages  map[string]string
+	fieldName func(field string) string
+}
+
+// WithMessage sets the message of the failed rule with the tag, as a format string with the name of the field and
+// the parameter of the rule as arguments, e.g. "%[1]s must have at least %[2]s characters" for "min".
+func WithMessage(tag, format string) Option {
+	return func(c *config) {
+		c.messages[tag] = format
+	}
+}
+
+// WithFieldName sets the function that returns the name of a field in messages (default: funcs.Humanize), e.g. to
+// translate field names.
+func WithFieldName(fn func(field string) string) Option {
+	return func(c *config) {
+		c.fieldName = fn
+	}
+}
+
+// DefaultMessages are the messages of common rules of go-playground/validator, as format strings with the name of the
+// field and the parameter of the rule as arguments. Rules without a message use "%[1]s is invalid".
+var DefaultMessages = map[string]string{
+	"required": "%[1]s is required",
+	"email":    "%[1]s must be a valid email address",
+	"url":      "%[1]s must be a valid URL",
+	"min":      "%[1]s must be at least %[2]s",
+	"max":      "%[1]s must be at most %[2]s",
+	"len":      "%[1]s must have a length of %[2]s",
+	"gt":       "%[1]s must be greater than %[2]s",
+	"gte":      "%[1]s must be at least %[2]s",
+	"lt":       "%[1]s must be less than %[2]s",
+	"lte":      "%[1]s must be at most %[2]s",
+	"oneof":    "%[1]s must be one of %[2]s",
+	"eqfield":  "%[1]s must match %[2]s",
+	"numeric":  "%[1]s must be a number",
+	"alphanum": "%[1]s must only contain letters and numbers",
+}
+
+// Errors returns the messages of the validation errors in err by field name, e.g. {"email": "Email is required"}.
+// It supports errors implementing FieldErrorer and FieldError, slices of FieldError such as the
+// validator.ValidationErrors of go-playground/validator, and maps of messages by field name such as bind.FieldErrors,
+// also if they are wrapped. Other errors return an empty map, so that the result can always be passed to
+// Response.Errors.
+func Errors(err error, opts ...Option) map[string]string {
+	cfg := config{messages: maps.Clone(DefaultMessages), fieldName: funcs.Humanize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	fieldErrors := make(map[string]string)
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if errorer, ok := e.(FieldErrorer); ok {
+			maps.Copy(fieldErrors, errorer.FieldErrors())
+			return fieldErrors
+		}
+		if fe, ok := e.(FieldError); ok {
+			fieldErrors[fe.Field()] = cfg.message(fe)
+			return fieldErrors
+		}
+
+		v := reflect.ValueOf(e)
+		switch {
+		case v.Kind() == reflect.Slice:
+			found := false
+			for i := range v.Len() {
+				if fe, ok := v.Index(i).Interface().(FieldError); ok {
+					if _, exists := fieldErrors[fe.Field()]; !exists {
+						fieldErrors[fe.Field()] = cfg.message(fe)
+					}
+					found = true
+				}
+			}
+			if found {
+				return fieldErrors
+			}
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && v.Type().Elem().Kind() == reflect.String:
+			iter := v.MapRange()
+			for iter.Next() {
+				fieldErrors[iter.Key().String()] = iter.Value().String()
+			}
+			return fieldErrors
+		}
+	}
+
+	return fieldErrors
+}
+
+// message returns the message of the field error.
+func (c *config) message(fe FieldError) string {
+	format, ok := c.messages[fe.Tag()]
+	if !ok {
+		format = "%[1]s is invalid"
+	}
+
+	return fmt.Sprintf(format, c.fieldName(fe.Field()), strings.ReplaceAll(fe.Param(), " ", ", "))
+}
@@ -0,0 +1,113 @@
+package validation_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hypergopher/hyperview/bind"
+	"github.com/hypergopher/hyperview/request"
+	"github.com/hypergopher/hyperview/validation"
+)
+
+// fieldError is a field error like those of go-playground/validator.
+type fieldError struct {
+	field, tag, param string
+}
+
+func (e fieldError) Field() string { return e.field }
+func (e fieldError) Tag() string   { return e.tag }
+func (e fieldError) Param() string { return e.param }
+func (e fieldError) Error() string { return e.field + " failed " + e.tag }
+
+// validationErrors is a slice of field errors like validator.ValidationErrors.
+type validationErrors []validation.FieldError
+
+func (e validationErrors) Error() string { return fmt.Sprintf("%d validation errors", len(e)) }
+
+func TestErrors(t *testing.T) {
+	jsonErr := request.DecodeJSON(
+		httptestRequest(`{"quantity": "two"}`),
+		&struct {
+			Quantity int `json:"quantity"`
+		}{},
+	)
+
+	tests := []struct {
+		name string
+		err  error
+		opts []validation.Option
+		want map[string]string
+	}{
+		{
+			name: "validation errors",
+			err: validationErrors{
+				fieldError{field: "email", tag: "required"},
+				fieldError{field: "first_name", tag: "max", param: "50"},
+				fieldError{field: "plan", tag: "oneof", param: "free pro"},
+				fieldError{field: "zip", tag: "postcode_iso3166_alpha2"},
+			},
+			want: map[string]string{
+				"email":      "Email is required",
+				"first_name": "First name must be at most 50",
+				"plan":       "Plan must be one of free, pro",
+				"zip":        "Zip is invalid",
+			},
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("signup: %w", validationErrors{fieldError{field: "PasswordConfirm", tag: "eqfield", param: "Password"}}),
+			want: map[string]string{"PasswordConfirm": "Password confirm must match Password"},
+		},
+		{
+			name: "single field error",
+			err:  fieldError{field: "age", tag: "gte", param: "18"},
+			want: map[string]string{"age": "Age must be at least 18"},
+		},
+		{
+			name: "custom messages",
+			err:  validationErrors{fieldError{field: "password", tag: "min", param: "8"}},
+			opts: []validation.Option{
+				validation.WithMessage("min", "%[1]s needs %[2]s characters"),
+				validation.WithFieldName(strings.ToUpper),
+			},
+			want: map[string]string{"password": "PASSWORD needs 8 characters"},
+		},
+		{
+			name: "bind field errors",
+			err:  bind.FieldErrors{"age": "must be a whole number"},
+			want: map[string]string{"age": "must be a whole number"},
+		},
+		{
+			name: "field errorer",
+			err:  jsonErr,
+			want: map[string]string{"quantity": "must be a number"},
+		},
+		{
+			name: "other error",
+			err:  errors.New("database unavailable"),
+			want: map[string]string{},
+		},
+		{
+			name: "nil",
+			err:  nil,
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validation.Errors(tt.err, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Errors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func httptestRequest(body string) *http.Request {
+	return httptest.NewRequest("POST", "/", strings.NewReader(body))
+}
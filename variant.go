@@ -0,0 +1,66 @@
+package hyperview
+
+import (
+	"net/http"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// VariantSeparator separates the template path from the variant in the path of a variant template, e.g.
+// views/pricing@b.
+const VariantSeparator = "@"
+
+// VariantResolver returns the variant of the template path to render for the request, e.g. the bucket of an A/B
+// test derived from a cookie, or an empty string to render the template itself. The path is the template path of the
+// response, e.g. views/pricing. Resolvers are called on every render, so they must be safe for concurrent use.
+type VariantResolver func(r *http.Request, path string) string
+
+// VariantObserver is an optional interface of Metrics that also observe the exposures of template variants (see
+// WithVariantResolver), with the template path and the variant rendered.
+type VariantObserver interface {
+	ObserveVariant(template, variant string)
+}
+
+// TemplateFinder is an optional interface of adapters that can tell whether they have a template for the request,
+// such as the TemplateAdapter. Template variants that the adapter does not have fall back to the template itself.
+type TemplateFinder interface {
+	HasTemplate(r *http.Request, path string) bool
+}
+
+// WithVariantResolver selects a variant of the template of each render with the resolver, so that experiments don't
+// need to branch in their handlers. If the resolver returns a variant, e.g. "b", and the adapter has the variant
+// template, e.g. views/pricing@b, the variant is rendered instead of views/pricing and its exposure is reported to the
+// metrics if they implement VariantObserver. Adapters that do not implement TemplateFinder render the variant path
+// as is.
+//
+// Variants depend on the request, so pages with variants should not be stored in shared caches.
+func WithVariantResolver(resolver VariantResolver) Option {
+	return func(hgo *HyperView) error {
+		hgo.variants = resolver
+		return nil
+	}
+}
+
+// withVariant replaces the template path of the response with the path of the variant selected for the request, if
+// the adapter has it.
+func (s *HyperView) withVariant(r *http.Request, adapter Adapter, resp *response.Response) {
+	path := resp.TemplatePath()
+	if s.variants == nil || path == "" {
+		return
+	}
+
+	variant := s.variants(r, path)
+	if variant == "" {
+		return
+	}
+
+	variantPath := path + VariantSeparator + variant
+	if finder, ok := adapter.(TemplateFinder); ok && !finder.HasTemplate(r, variantPath) {
+		return
+	}
+
+	resp.Path(variantPath)
+	if observer, ok := s.metrics.(VariantObserver); ok {
+		observer.ObserveVariant(path, variant)
+	}
+}
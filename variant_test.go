@@ -0,0 +1,70 @@
+package hyperview_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/response"
+)
+
+func TestWithVariantResolver(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":    {Data: []byte(`{{define "layout:base"}}{{template "page:main" .}}{{end}}`)},
+		"views/pricing.html":   {Data: []byte(`{{define "page:main"}}Pricing{{end}}`)},
+		"views/pricing@b.html": {Data: []byte(`{{define "page:main"}}Pricing B{{end}}`)},
+		"views/about.html":     {Data: []byte(`{{define "page:main"}}About{{end}}`)},
+	}
+
+	metrics := &recordingMetrics{}
+	hv, err := hyperview.NewHyperView(
+		hyperview.WithTemplateFS(fsys),
+		hyperview.WithMetrics(metrics),
+		hyperview.WithVariantResolver(func(r *http.Request, _ string) string {
+			if cookie, err := r.Cookie("bucket"); err == nil {
+				return cookie.Value
+			}
+			return ""
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		bucket       string
+		want         string
+		wantVariants []string
+	}{
+		{"variant", "pricing", "b", "Pricing B", []string{"views/pricing@b"}},
+		{"missing variant", "pricing", "a", "Pricing", nil},
+		{"no variant", "pricing", "", "Pricing", nil},
+		{"template without variants", "about", "b", "About", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics.variants = nil
+
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.bucket != "" {
+				r.AddCookie(&http.Cookie{Name: "bucket", Value: tt.bucket})
+			}
+			w := httptest.NewRecorder()
+			hv.Render(w, r, response.NewResponse().Path(tt.path))
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(metrics.variants, tt.wantVariants) {
+				t.Errorf("variants = %v, want %v", metrics.variants, tt.wantVariants)
+			}
+		})
+	}
+}
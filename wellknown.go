@@ -0,0 +1,181 @@
+package hyperview
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hypergopher/hyperview/response"
+)
+
+// defaultWellKnownMaxAge is the default max-age of the Cache-Control header of robots.txt and security.txt.
+const defaultWellKnownMaxAge = 24 * time.Hour
+
+// defaultSecurityTxtExpiry is how long security.txt files without Expires are valid, well below the year that RFC 9116
+// recommends as the maximum.
+const defaultSecurityTxtExpiry = 90 * 24 * time.Hour
+
+// RobotsGroup is a group of robots.txt rules for one or more user agents.
+type RobotsGroup struct {
+	// UserAgents are the user agents of the group. Default is "*" (all user agents).
+	UserAgents []string
+	// Allow are the paths the user agents may crawl.
+	Allow []string
+	// Disallow are the paths the user agents may not crawl.
+	Disallow []string
+}
+
+// RobotsTxt is the content of a robots.txt file (see RobotsHandler).
+type RobotsTxt struct {
+	// Groups are the rule groups. Without groups, all user agents may crawl all paths.
+	Groups []RobotsGroup
+	// Sitemaps are the absolute URLs of the sitemaps of the site.
+	Sitemaps []string
+	// Template is the path of a template that is rendered instead, with the RobotsTxt as .Robots, e.g. "robots.txt"
+	// for an adapter registered for ".txt" (see TextTemplateAdapter).
+	Template string
+	// MaxAge is the max-age of the Cache-Control header. Default is 24 hours.
+	MaxAge time.Duration
+}
+
+// String returns the robots.txt file.
+func (robots RobotsTxt) String() string {
+	var b strings.Builder
+
+	groups := robots.Groups
+	if len(groups) == 0 {
+		groups = []RobotsGroup{{}}
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		agents := group.UserAgents
+		if len(agents) == 0 {
+			agents = []string{"*"}
+		}
+		for _, agent := range agents {
+			b.WriteString("User-agent: " + agent + "\n")
+		}
+
+		for _, path := range group.Allow {
+			b.WriteString("Allow: " + path + "\n")
+		}
+		for _, path := range group.Disallow {
+			b.WriteString("Disallow: " + path + "\n")
+		}
+		if len(group.Allow) == 0 && len(group.Disallow) == 0 {
+			// An empty disallow rule allows everything
+			b.WriteString("Disallow:\n")
+		}
+	}
+
+	if len(robots.Sitemaps) > 0 {
+		b.WriteString("\n")
+		for _, sitemap := range robots.Sitemaps {
+			b.WriteString("Sitemap: " + sitemap + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// RobotsHandler returns an http.Handler that serves the robots.txt file, e.g. at /robots.txt.
+func (s *HyperView) RobotsHandler(robots RobotsTxt) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setWellKnownCacheControl(w, robots.MaxAge)
+
+		if robots.Template != "" {
+			s.Render(w, r, response.NewResponse().Path(robots.Template).Data(map[string]any{"Robots": robots}))
+			return
+		}
+
+		writeText(w, robots.String())
+	})
+}
+
+// SecurityTxt is the content of a security.txt file as defined by RFC 9116 (see SecurityTxtHandler).
+type SecurityTxt struct {
+	// Contact are the URIs to report security issues to, e.g. "mailto:security@example.com" (required).
+	Contact []string
+	// Expires is the date after which the file is stale. Default is 90 days after the request.
+	Expires time.Time
+	// Encryption are the URIs of the keys to encrypt reports with.
+	Encryption []string
+	// Acknowledgments are the URIs of the pages that acknowledge reporters.
+	Acknowledgments []string
+	// PreferredLanguages are the languages of reports, e.g. "en".
+	PreferredLanguages []string
+	// Canonical are the URIs where the file is served.
+	Canonical []string
+	// Policy are the URIs of the vulnerability disclosure policies.
+	Policy []string
+	// Hiring are the URIs of security-related job openings.
+	Hiring []string
+	// Template is the path of a template that is rendered instead, with the SecurityTxt as .Security, e.g.
+	// "security.txt" for an adapter registered for ".txt" (see TextTemplateAdapter).
+	Template string
+	// MaxAge is the max-age of the Cache-Control header. Default is 24 hours.
+	MaxAge time.Duration
+}
+
+// String returns the security.txt file.
+func (security SecurityTxt) String() string {
+	var b strings.Builder
+
+	field := func(name string, values ...string) {
+		for _, value := range values {
+			b.WriteString(name + ": " + value + "\n")
+		}
+	}
+
+	field("Contact", security.Contact...)
+	expires := security.Expires
+	if expires.IsZero() {
+		expires = time.Now().Add(defaultSecurityTxtExpiry)
+	}
+	field("Expires", expires.UTC().Format(time.RFC3339))
+	field("Encryption", security.Encryption...)
+	field("Acknowledgments", security.Acknowledgments...)
+	if len(security.PreferredLanguages) > 0 {
+		field("Preferred-Languages", strings.Join(security.PreferredLanguages, ", "))
+	}
+	field("Canonical", security.Canonical...)
+	field("Policy", security.Policy...)
+	field("Hiring", security.Hiring...)
+
+	return b.String()
+}
+
+// SecurityTxtHandler returns an http.Handler that serves the security.txt file, which should be served at
+// /.well-known/security.txt.
+func (s *HyperView) SecurityTxtHandler(security SecurityTxt) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setWellKnownCacheControl(w, security.MaxAge)
+
+		if security.Template != "" {
+			s.Render(w, r, response.NewResponse().Path(security.Template).Data(map[string]any{"Security": security}))
+			return
+		}
+
+		writeText(w, security.String())
+	})
+}
+
+// setWellKnownCacheControl sets the Cache-Control header of a well-known file with the max-age, or the default.
+func setWellKnownCacheControl(w http.ResponseWriter, maxAge time.Duration) {
+	if maxAge <= 0 {
+		maxAge = defaultWellKnownMaxAge
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+}
+
+// writeText writes the text as a text/plain response.
+func writeText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(text))
+}
@@ -0,0 +1,132 @@
+package hyperview_test
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/hypergopher/hyperview"
+	"github.com/hypergopher/hyperview/constants"
+)
+
+func TestRobotsTxt_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		robots hyperview.RobotsTxt
+		want   string
+	}{
+		{"allow all", hyperview.RobotsTxt{}, "User-agent: *\nDisallow:\n"},
+		{"groups and sitemaps", hyperview.RobotsTxt{
+			Groups: []hyperview.RobotsGroup{
+				{Disallow: []string{"/admin", "/api"}, Allow: []string{"/api/docs"}},
+				{UserAgents: []string{"GPTBot", "CCBot"}, Disallow: []string{"/"}},
+			},
+			Sitemaps: []string{"https://example.com/sitemap.xml"},
+		}, "User-agent: *\nAllow: /api/docs\nDisallow: /admin\nDisallow: /api\n\n" +
+			"User-agent: GPTBot\nUser-agent: CCBot\nDisallow: /\n\n" +
+			"Sitemap: https://example.com/sitemap.xml\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.robots.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityTxt_String(t *testing.T) {
+	security := hyperview.SecurityTxt{
+		Contact:            []string{"mailto:security@example.com", "https://example.com/security"},
+		Expires:            time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		PreferredLanguages: []string{"en", "de"},
+		Policy:             []string{"https://example.com/disclosure"},
+	}
+
+	want := "Contact: mailto:security@example.com\n" +
+		"Contact: https://example.com/security\n" +
+		"Expires: 2030-01-01T00:00:00Z\n" +
+		"Preferred-Languages: en, de\n" +
+		"Policy: https://example.com/disclosure\n"
+	if got := security.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSecurityTxt_DefaultExpires(t *testing.T) {
+	security := hyperview.SecurityTxt{Contact: []string{"mailto:security@example.com"}}
+
+	_, value, ok := strings.Cut(security.String(), "Expires: ")
+	if !ok {
+		t.Fatalf("String() = %q, want an Expires field", security.String())
+	}
+	value, _, _ = strings.Cut(value, "\n")
+	expires, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("Expires = %q, want an RFC 3339 time: %v", value, err)
+	}
+	if days := time.Until(expires).Hours() / 24; days < 89 || days > 90 {
+		t.Errorf("Expires = %v, want 90 days from now", expires)
+	}
+}
+
+func TestWellKnownHandlers(t *testing.T) {
+	fsys := fstest.MapFS{
+		"views/robots.txt": {Data: []byte("User-agent: *\nDisallow: {{range .Robots.Groups}}{{range .Disallow}}{{.}}{{end}}{{end}}\n")},
+	}
+
+	hv, err := hyperview.NewHyperView(hyperview.WithTemplateFS(fsys))
+	if err != nil {
+		t.Fatalf("NewHyperView() error = %v", err)
+	}
+	err = hv.RegisterAdapter("txt", hyperview.NewTextTemplateAdapter(hyperview.TextTemplateAdapterOptions{
+		Extension:     ".txt",
+		FileSystemMap: map[string]fs.FS{constants.RootFSID: fsys},
+	}))
+	if err != nil {
+		t.Fatalf("RegisterAdapter() error = %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		handler          http.Handler
+		wantBody         string
+		wantCacheControl string
+	}{
+		{"robots", hv.RobotsHandler(hyperview.RobotsTxt{}), "User-agent: *\nDisallow:\n", "public, max-age=86400"},
+		{"robots template", hv.RobotsHandler(hyperview.RobotsTxt{
+			Template: "robots.txt",
+			Groups:   []hyperview.RobotsGroup{{Disallow: []string{"/private"}}},
+			MaxAge:   time.Hour,
+		}), "User-agent: *\nDisallow: /private\n", "public, max-age=3600"},
+		{"security", hv.SecurityTxtHandler(hyperview.SecurityTxt{
+			Contact: []string{"mailto:security@example.com"},
+			Expires: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		}), "Contact: mailto:security@example.com\nExpires: 2030-01-01T00:00:00Z\n", "public, max-age=86400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Content-Type"); got != "text/plain; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/plain; charset=utf-8", got)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCacheControl {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCacheControl)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
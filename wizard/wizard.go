@@ -0,0 +1,378 @@
+// Package wizard keeps the state of multi-step forms, such as checkouts, between requests.
+//
+// The values of the completed steps are kept in a signed hidden field of the form, or in a store such as the session
+// (see WithStore), and each step is validated on its own before the wizard advances to the next step:
+//
+//	checkout := wizard.New(secret, []wizard.Step{
+//		{Name: "address", Title: "Address", Fields: []string{"name", "street", "city"}, Validate: validateAddress},
+//		{Name: "shipping", Title: "Shipping", Fields: []string{"method"}},
+//		{Name: "payment", Title: "Payment", Fields: []string{"card"}, Validate: validatePayment},
+//	})
+//
+//	func checkoutHandler(r *http.Request) (*response.Response, error) {
+//		state, err := checkout.Load(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//
+//		resp := response.NewResponse().Path("checkout")
+//		if r.Method == http.MethodPost {
+//			if fieldErrors := checkout.Advance(r, state); len(fieldErrors) > 0 {
+//				resp.Errors("Please fix the errors below", fieldErrors)
+//			} else if state.Done() {
+//				var order orderForm
+//				if err := bind.Values(state.Values, &order); err != nil {
+//					return nil, err
+//				}
+//				// place the order ...
+//			}
+//		}
+//
+//		view, err := checkout.View(state)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return resp.AddDataItem("Wizard", view), nil
+//	}
+//
+// Templates render the current step with .Wizard.Step.Name, the progress with .Wizard.CurrentStep and
+// .Wizard.Progress, and the state with {{.Wizard.HiddenField}} inside the form.
+package wizard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFieldName is the name of the hidden field of the state, unless set with WithFieldName.
+const DefaultFieldName = "_wizard"
+
+// DefaultMaxAge is how long the state in the hidden field is valid after it was rendered, unless set with WithMaxAge.
+const DefaultMaxAge = 24 * time.Hour
+
+// ErrInvalidState is returned by Load if the state of the request has an invalid signature or can't be decoded.
+var ErrInvalidState = errors.New("wizard: invalid state")
+
+// ErrExpiredState is returned by Load if the state in the hidden field is older than the maximum age (see
+// WithMaxAge). It wraps ErrInvalidState.
+var ErrExpiredState = fmt.Errorf("%w: expired", ErrInvalidState)
+
+// Step is a step of a wizard.
+type Step struct {
+	Name   string   // name of the step, e.g. to select the template of the step
+	Title  string   // title of the step, e.g. for a progress indicator
+	Fields []string // names of the form fields of the step, which are kept when the step is completed
+	// Validate validates the submitted values of the fields of the step and returns the errors by field name, or nil
+	// if the values are valid. Steps without a validation function are always valid.
+	Validate func(values url.Values) map[string]string
+}
+
+// Store stores the state of a wizard between requests, e.g. in the session, instead of a hidden field.
+type Store interface {
+	// Load returns the value stored under the key for the request, or nil if there is none.
+	Load(r *http.Request, key string) ([]byte, error)
+	// Save stores the value under the key, or removes it if the value is nil.
+	Save(w http.ResponseWriter, r *http.Request, key string, value []byte) error
+}
+
+// Option configures a Wizard.
+type Option func(*Wizard)
+
+// WithStore keeps the state in the store instead of a hidden field, e.g. for large values or values that must not be
+// sent to the client. The state must be saved with Save after each change.
+func WithStore(store Store) Option {
+	return func(wz *Wizard) {
+		wz.store = store
+	}
+}
+
+// WithFieldName sets the name of the hidden field, and the key of the store, of the state (default:
+// DefaultFieldName), e.g. for several wizards on the same page.
+func WithFieldName(name string) Option {
+	return func(wz *Wizard) {
+		wz.fieldName = name
+	}
+}
+
+// WithMaxAge sets how long the state in the hidden field is valid after it was rendered (default: DefaultMaxAge), so
+// that old forms can't be submitted again. The state in a store expires with the store, e.g. with the session.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(wz *Wizard) {
+		wz.maxAge = maxAge
+	}
+}
+
+// Wizard is a multi-step form. It is safe for concurrent use.
+type Wizard struct {
+	secret    []byte
+	steps     []Step
+	store     Store
+	fieldName string
+	maxAge    time.Duration
+	now       func() time.Time
+}
+
+// New creates a new Wizard with the steps. The secret signs the state in the hidden field, so that clients can't
+// skip steps or change the values of validated steps; it should be at least 32 random bytes.
+func New(secret []byte, steps []Step, opts ...Option) *Wizard {
+	wz := &Wizard{
+		secret:    secret,
+		steps:     slices.Clone(steps),
+		fieldName: DefaultFieldName,
+		maxAge:    DefaultMaxAge,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(wz)
+	}
+	return wz
+}
+
+// State is the state of a wizard for a client.
+type State struct {
+	Step   int        `json:"step"`   // index of the current step, or the number of steps if all steps are completed
+	Values url.Values `json:"values"` // values of the fields of the completed steps
+
+	steps int
+}
+
+// Done returns true if all steps are completed.
+func (s *State) Done() bool {
+	return s.Step >= s.steps
+}
+
+// Load returns the state of the request, from the hidden field of the submitted form or from the store, or the state
+// of the first step if there is none. It returns ErrInvalidState if the state was tampered with, and ErrExpiredState
+// if the state in the hidden field is older than the maximum age.
+func (wz *Wizard) Load(r *http.Request) (*State, error) {
+	state := &State{Values: url.Values{}, steps: len(wz.steps)}
+
+	var payload []byte
+	if wz.store != nil {
+		var err error
+		payload, err = wz.store.Load(r, wz.fieldName)
+		if err != nil {
+			return nil, err
+		}
+	} else if token := r.PostFormValue(wz.fieldName); token != "" {
+		var err error
+		payload, err = wz.verify(token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if payload == nil {
+		return state, nil
+	}
+	if err := json.Unmarshal(payload, state); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidState, err)
+	}
+	state.Step = min(max(state.Step, 0), len(wz.steps))
+	if state.Values == nil {
+		state.Values = url.Values{}
+	}
+	return state, nil
+}
+
+// Advance validates the submitted values of the fields of the current step and, if they are valid, keeps them in the
+// state and advances to the next step. It returns the errors of the step by field name, or nil if the step is
+// completed. The request form is parsed if needed.
+func (wz *Wizard) Advance(r *http.Request, state *State) map[string]string {
+	if state.Done() {
+		return nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return map[string]string{wz.fieldName: "The form could not be read"}
+	}
+
+	step := wz.steps[state.Step]
+	values := make(url.Values, len(step.Fields))
+	for _, field := range step.Fields {
+		if submitted, ok := r.PostForm[field]; ok {
+			values[field] = submitted
+		}
+	}
+
+	if step.Validate != nil {
+		if fieldErrors := step.Validate(values); len(fieldErrors) > 0 {
+			return fieldErrors
+		}
+	}
+
+	for _, field := range step.Fields {
+		delete(state.Values, field)
+	}
+	for field, submitted := range values {
+		state.Values[field] = submitted
+	}
+	state.Step++
+	return nil
+}
+
+// Back returns to the previous step, keeping the values of the completed steps so that the form of the step can be
+// rendered with them (see View.Value).
+func (wz *Wizard) Back(state *State) {
+	state.Step = max(state.Step-1, 0)
+}
+
+// Save saves the state in the store. Without a store, the state is kept in the hidden field (see View.HiddenField)
+// and Save does nothing.
+func (wz *Wizard) Save(w http.ResponseWriter, r *http.Request, state *State) error {
+	if wz.store == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return wz.store.Save(w, r, wz.fieldName, payload)
+}
+
+// Reset removes the state from the store, e.g. after the last step was processed.
+func (wz *Wizard) Reset(w http.ResponseWriter, r *http.Request) error {
+	if wz.store == nil {
+		return nil
+	}
+	return wz.store.Save(w, r, wz.fieldName, nil)
+}
+
+// View returns the view of the state for templates, e.g. to add to the view data.
+func (wz *Wizard) View(state *State) (*View, error) {
+	view := &View{steps: wz.steps, state: state}
+	if wz.store != nil {
+		return view, nil
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	view.hiddenField = template.HTML(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+		html.EscapeString(wz.fieldName), wz.sign(payload)))
+	return view, nil
+}
+
+// sign returns the payload, the time it was signed, and the signature, encoded as URL-safe base64 and separated by
+// dots. The signature covers the name of the field, so that the state of a wizard can't be submitted to another.
+func (wz *Wizard) sign(payload []byte) string {
+	issued := strconv.FormatInt(wz.now().Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + issued + "." +
+		base64.RawURLEncoding.EncodeToString(wz.mac(issued, payload))
+}
+
+// verify returns the payload of a signed token, ErrInvalidState if the signature doesn't match, or ErrExpiredState if
+// the token is older than the maximum age.
+func (wz *Wizard) verify(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidState
+	}
+	encodedPayload, issued, encodedSignature := parts[0], parts[1], parts[2]
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	if !hmac.Equal(signature, wz.mac(issued, payload)) {
+		return nil, ErrInvalidState
+	}
+
+	issuedAt, err := strconv.ParseInt(issued, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	if wz.now().Sub(time.Unix(issuedAt, 0)) > wz.maxAge {
+		return nil, ErrExpiredState
+	}
+	return payload, nil
+}
+
+// mac returns the signature of the field name, the time the token was signed, and the payload.
+func (wz *Wizard) mac(issued string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, wz.secret)
+	mac.Write([]byte(wz.fieldName + "\x00" + issued + "\x00"))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// View is the state of a wizard for templates.
+type View struct {
+	steps       []Step
+	state       *State
+	hiddenField template.HTML
+}
+
+// CurrentStep returns the number of the current step, starting at 1.
+func (v *View) CurrentStep() int {
+	return min(v.state.Step+1, len(v.steps))
+}
+
+// TotalSteps returns the number of steps.
+func (v *View) TotalSteps() int {
+	return len(v.steps)
+}
+
+// Progress returns the percentage of completed steps, from 0 to 100.
+func (v *View) Progress() int {
+	if len(v.steps) == 0 {
+		return 100
+	}
+	return v.state.Step * 100 / len(v.steps)
+}
+
+// Step returns the current step, or the last step if all steps are completed.
+func (v *View) Step() Step {
+	if len(v.steps) == 0 {
+		return Step{}
+	}
+	return v.steps[v.CurrentStep()-1]
+}
+
+// Steps returns all steps, e.g. for a progress indicator.
+func (v *View) Steps() []Step {
+	return v.steps
+}
+
+// IsFirst returns true if the current step is the first step.
+func (v *View) IsFirst() bool {
+	return v.state.Step == 0
+}
+
+// IsLast returns true if the current step is the last step.
+func (v *View) IsLast() bool {
+	return v.state.Step >= len(v.steps)-1
+}
+
+// Done returns true if all steps are completed.
+func (v *View) Done() bool {
+	return v.state.Done()
+}
+
+// Value returns the kept value of the field, e.g. to fill the form of a step after going back.
+func (v *View) Value(field string) string {
+	return v.state.Values.Get(field)
+}
+
+// HiddenField returns the hidden field with the signed state, to be rendered inside the form of each step. It is
+// empty if the state is kept in a store.
+func (v *View) HiddenField() template.HTML {
+	return v.hiddenField
+}
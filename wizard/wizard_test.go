@@ -0,0 +1,240 @@
+package wizard_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypergopher/hyperview/wizard"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func checkoutSteps() []wizard.Step {
+	return []wizard.Step{
+		{
+			Name:   "address",
+			Title:  "Address",
+			Fields: []string{"name", "city"},
+			Validate: func(values url.Values) map[string]string {
+				if values.Get("name") == "" {
+					return map[string]string{"name": "Name is required"}
+				}
+				return nil
+			},
+		},
+		{Name: "shipping", Title: "Shipping", Fields: []string{"method"}},
+		{Name: "payment", Title: "Payment", Fields: []string{"card"}},
+	}
+}
+
+func postForm(values url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/checkout", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+var tokenPattern = regexp.MustCompile(`value="([^"]+)"`)
+
+// token returns the signed state of the hidden field of the view.
+func token(t *testing.T, wz *wizard.Wizard, state *wizard.State) string {
+	t.Helper()
+	view, err := wz.View(state)
+	if err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	match := tokenPattern.FindStringSubmatch(string(view.HiddenField()))
+	if match == nil {
+		t.Fatalf("HiddenField() = %q, want a value", view.HiddenField())
+	}
+	return match[1]
+}
+
+func TestWizardHiddenField(t *testing.T) {
+	wz := wizard.New(secret, checkoutSteps())
+
+	state, err := wz.Load(httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if state.Step != 0 || state.Done() {
+		t.Fatalf("Load() step = %d, done = %v, want the first step", state.Step, state.Done())
+	}
+
+	// An invalid step keeps the state and returns the errors of the step.
+	if fieldErrors := wz.Advance(postForm(url.Values{"city": {"Paris"}}), state); fieldErrors["name"] != "Name is required" {
+		t.Errorf("Advance() = %v, want an error for name", fieldErrors)
+	}
+	if state.Step != 0 || len(state.Values) != 0 {
+		t.Errorf("Advance() state = %+v, want the first step without values", state)
+	}
+
+	// A valid step keeps only the fields of the step.
+	first := postForm(url.Values{"name": {"Ada"}, "city": {"Paris"}, "card": {"4242"}})
+	if fieldErrors := wz.Advance(first, state); fieldErrors != nil {
+		t.Fatalf("Advance() = %v, want nil", fieldErrors)
+	}
+	if state.Step != 1 || state.Values.Get("name") != "Ada" || state.Values.Has("card") {
+		t.Errorf("Advance() state = %+v, want the second step with the address", state)
+	}
+
+	// The next request restores the state from the hidden field.
+	second := postForm(url.Values{wizard.DefaultFieldName: {token(t, wz, state)}, "method": {"express"}})
+	state, err = wz.Load(second)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if state.Step != 1 || state.Values.Get("city") != "Paris" {
+		t.Fatalf("Load() state = %+v, want the second step with the address", state)
+	}
+	if fieldErrors := wz.Advance(second, state); fieldErrors != nil {
+		t.Fatalf("Advance() = %v, want nil", fieldErrors)
+	}
+
+	view, err := wz.View(state)
+	if err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	if view.CurrentStep() != 3 || view.TotalSteps() != 3 || view.Progress() != 66 || view.Step().Name != "payment" {
+		t.Errorf("View() = step %d of %d, %d%%, %q, want step 3 of 3, 66%%, \"payment\"",
+			view.CurrentStep(), view.TotalSteps(), view.Progress(), view.Step().Name)
+	}
+	if !view.IsLast() || view.IsFirst() || view.Value("method") != "express" {
+		t.Errorf("View() last = %v, first = %v, method = %q", view.IsLast(), view.IsFirst(), view.Value("method"))
+	}
+
+	if fieldErrors := wz.Advance(postForm(url.Values{"card": {"4242"}}), state); fieldErrors != nil {
+		t.Fatalf("Advance() = %v, want nil", fieldErrors)
+	}
+	if !state.Done() || state.Values.Get("card") != "4242" {
+		t.Errorf("Advance() state = %+v, want all steps done", state)
+	}
+	if view, _ := wz.View(state); view.Progress() != 100 || view.Step().Name != "payment" {
+		t.Errorf("View() progress = %d, step = %q, want 100 and \"payment\"", view.Progress(), view.Step().Name)
+	}
+}
+
+func TestWizardBack(t *testing.T) {
+	wz := wizard.New(secret, checkoutSteps())
+	state, _ := wz.Load(httptest.NewRequest(http.MethodGet, "/checkout", nil))
+
+	wz.Back(state)
+	if state.Step != 0 {
+		t.Errorf("Back() step = %d, want 0", state.Step)
+	}
+
+	wz.Advance(postForm(url.Values{"name": {"Ada"}}), state)
+	wz.Back(state)
+	view, _ := wz.View(state)
+	if state.Step != 0 || view.Value("name") != "Ada" {
+		t.Errorf("Back() step = %d, name = %q, want 0 and the kept value", state.Step, view.Value("name"))
+	}
+}
+
+func TestWizardInvalidState(t *testing.T) {
+	wz := wizard.New(secret, checkoutSteps())
+	state, _ := wz.Load(httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	state.Step = 2
+	valid := token(t, wz, state)
+
+	other := wizard.New([]byte("another secret of thirty-two bytes"), checkoutSteps())
+	otherField := wizard.New(secret, checkoutSteps(), wizard.WithFieldName("_other"))
+
+	tests := []struct {
+		name  string
+		wz    *wizard.Wizard
+		token string
+	}{
+		{name: "other secret", wz: other, token: valid},
+		{name: "other field", wz: otherField, token: valid},
+		{name: "changed payload", wz: wz, token: "eyJzdGVwIjozfQ" + valid[strings.Index(valid, "."):]},
+		{name: "no signature", wz: wz, token: "eyJzdGVwIjozfQ"},
+		{name: "not base64", wz: wz, token: "!!!.!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.wz.Load(postForm(url.Values{wizard.DefaultFieldName: {tt.token}, "_other": {tt.token}}))
+			if !errors.Is(err, wizard.ErrInvalidState) {
+				t.Errorf("Load() error = %v, want ErrInvalidState", err)
+			}
+		})
+	}
+}
+
+func TestWizardExpiredState(t *testing.T) {
+	wz := wizard.New(secret, checkoutSteps(), wizard.WithMaxAge(time.Millisecond))
+	state, _ := wz.Load(httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	expired := token(t, wz, state)
+	time.Sleep(2 * time.Millisecond)
+
+	_, err := wz.Load(postForm(url.Values{wizard.DefaultFieldName: {expired}}))
+	if !errors.Is(err, wizard.ErrExpiredState) || !errors.Is(err, wizard.ErrInvalidState) {
+		t.Errorf("Load() error = %v, want ErrExpiredState", err)
+	}
+
+	// The state is valid for the default maximum age
+	wz = wizard.New(secret, checkoutSteps())
+	if _, err := wz.Load(postForm(url.Values{wizard.DefaultFieldName: {expired}})); err != nil {
+		t.Errorf("Load() error = %v, want nil", err)
+	}
+}
+
+type memoryStore map[string][]byte
+
+func (s memoryStore) Load(_ *http.Request, key string) ([]byte, error) {
+	return s[key], nil
+}
+
+func (s memoryStore) Save(_ http.ResponseWriter, _ *http.Request, key string, value []byte) error {
+	if value == nil {
+		delete(s, key)
+	} else {
+		s[key] = value
+	}
+	return nil
+}
+
+func TestWizardStore(t *testing.T) {
+	store := memoryStore{}
+	wz := wizard.New(secret, checkoutSteps(), wizard.WithStore(store), wizard.WithFieldName("checkout"))
+
+	r := postForm(url.Values{"name": {"Ada"}})
+	w := httptest.NewRecorder()
+	state, err := wz.Load(r)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	wz.Advance(r, state)
+	if err := wz.Save(w, r, state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, ok := store["checkout"]; !ok {
+		t.Fatalf("Save() store = %v, want the state under \"checkout\"", store)
+	}
+
+	view, _ := wz.View(state)
+	if view.HiddenField() != "" {
+		t.Errorf("HiddenField() = %q, want empty with a store", view.HiddenField())
+	}
+
+	state, err = wz.Load(httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if state.Step != 1 || state.Values.Get("name") != "Ada" {
+		t.Errorf("Load() state = %+v, want the second step with the name", state)
+	}
+
+	if err := wz.Reset(w, r); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if len(store) != 0 {
+		t.Errorf("Reset() store = %v, want empty", store)
+	}
+}
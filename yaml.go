@@ -0,0 +1,89 @@
+package hyperview
+
+import (
+	"bytes"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLSuccess creates a successful YAML response with the given data and optional headers.
+// It uses the same Envelope structure as the JSON responses.
+func YAMLSuccess(w http.ResponseWriter, data any, headers ...http.Header) error {
+	return YAMLSuccessWithStatus(w, http.StatusOK, data, headers...)
+}
+
+// YAMLSuccessWithStatus creates a YAML response with the specified status code and data.
+// It formats the response body as a success envelope and includes optional custom headers.
+func YAMLSuccessWithStatus(w http.ResponseWriter, status int, data any, headers ...http.Header) error {
+	envelope := Envelope{
+		Status:  "success",
+		Code:    status,
+		Message: "Success",
+		Data:    data,
+	}
+
+	return YAMLWithHeaders(w, status, envelope, headers...)
+}
+
+// YAMLFailure builds a YAML response with failure status, message and data.
+// The response code is set by the status parameter.
+func YAMLFailure(w http.ResponseWriter, data any, message string, status int, headers ...http.Header) error {
+	envelope := Envelope{
+		Status:  "fail",
+		Code:    status,
+		Message: message,
+		Data:    data,
+	}
+
+	return YAMLWithHeaders(w, status, envelope, headers...)
+}
+
+// YAMLError writes an error response in YAML format to the http.ResponseWriter.
+func YAMLError(w http.ResponseWriter, message string, status int, headers ...http.Header) error {
+	envelope := Envelope{
+		Status:  "error",
+		Message: message,
+		Code:    status,
+	}
+
+	return YAMLWithHeaders(w, status, envelope, headers...)
+}
+
+// YAMLWithHeaders serializes the given data to YAML format with specified headers
+// and writes it to the provided http.ResponseWriter. It also sets the HTTP status
+// code and the Content-Type header to "application/yaml; charset=UTF-8". If the
+// serialization fails, an error is returned and nothing is written.
+func YAMLWithHeaders(w http.ResponseWriter, status int, data any, headers ...http.Header) error {
+	out, err := marshalYAML(data)
+	if err != nil {
+		return err
+	}
+
+	for _, header := range headers {
+		for key, value := range header {
+			w.Header()[key] = value
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/yaml; charset=UTF-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(out)
+
+	return nil
+}
+
+// marshalYAML serializes the given data to YAML with an indentation of two spaces.
+func marshalYAML(data any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}